@@ -21,6 +21,21 @@ const (
 	Fail FailurePolicyType = "Fail"
 )
 
+// AdmissionOperation is the operation of an admission request.
+// +kubebuilder:validation:Enum=CREATE;CONNECT;UPDATE;DELETE
+type AdmissionOperation string
+
+const (
+	// Create is the operation of an admission request creating a resource.
+	Create AdmissionOperation = "CREATE"
+	// Connect is the operation of an admission request connecting to a resource.
+	Connect AdmissionOperation = "CONNECT"
+	// Update is the operation of an admission request updating a resource.
+	Update AdmissionOperation = "UPDATE"
+	// Delete is the operation of an admission request deleting a resource.
+	Delete AdmissionOperation = "DELETE"
+)
+
 // ApplyRulesType controls whether processing stops after one rule is applied or all rules are applied.
 // +kubebuilder:validation:Enum=All;One
 type ApplyRulesType string
@@ -32,6 +47,30 @@ const (
 	ApplyOne ApplyRulesType = "One"
 )
 
+// GenerateDeletionPolicy controls what happens to generated resources when the trigger resource or the policy is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete;Orphan
+type GenerateDeletionPolicy string
+
+const (
+	// GenerateDeletionPolicyRetain keeps the generated resources.
+	GenerateDeletionPolicyRetain GenerateDeletionPolicy = "Retain"
+	// GenerateDeletionPolicyDelete deletes the generated resources.
+	GenerateDeletionPolicyDelete GenerateDeletionPolicy = "Delete"
+	// GenerateDeletionPolicyOrphan keeps the generated resources and removes the labels managed by Kyverno.
+	GenerateDeletionPolicyOrphan GenerateDeletionPolicy = "Orphan"
+)
+
+// GenerateApplyMode controls how generated resources are written.
+// +kubebuilder:validation:Enum=Replace;ServerSideApply
+type GenerateApplyMode string
+
+const (
+	// GenerateApplyModeReplace creates or replaces the whole generated resource.
+	GenerateApplyModeReplace GenerateApplyMode = "Replace"
+	// GenerateApplyModeServerSideApply applies the generated data with server-side apply, fields owned by other managers are preserved.
+	GenerateApplyModeServerSideApply GenerateApplyMode = "ServerSideApply"
+)
+
 // AnyAllConditions consists of conditions wrapped denoting a logical criteria to be fulfilled.
 // AnyConditions get fulfilled when at least one of its sub-conditions passes.
 // AllConditions get fulfilled only when all of its sub-conditions pass.
@@ -60,8 +99,8 @@ type ContextEntry struct {
 	// ConfigMap is the ConfigMap reference.
 	ConfigMap *ConfigMapReference `json:"configMap,omitempty" yaml:"configMap,omitempty"`
 
-	// APICall defines an HTTP request to the Kubernetes API server. The JSON
-	// data retrieved is stored in the context.
+	// APICall defines an HTTP request to the Kubernetes API server, or to an
+	// external JSON web service. The JSON data retrieved is stored in the context.
 	APICall *APICall `json:"apiCall,omitempty" yaml:"apiCall,omitempty"`
 
 	// ImageRegistry defines requests to an OCI/Docker V2 registry to fetch image
@@ -70,6 +109,12 @@ type ContextEntry struct {
 
 	// Variable defines an arbitrary JMESPath context variable that can be defined inline.
 	Variable *Variable `json:"variable,omitempty" yaml:"variable,omitempty"`
+
+	// CacheTTL is the duration for which the data fetched by an APICall or an ImageRegistry
+	// entry is cached and shared across admission requests. Cached data is keyed by the
+	// substituted URL path, service request or image reference. Caching is disabled if not set.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty" yaml:"cacheTTL,omitempty"`
 }
 
 // Variable defines an arbitrary JMESPath context variable that can be defined inline.
@@ -112,15 +157,21 @@ type ConfigMapReference struct {
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
-// APICall defines an HTTP request to the Kubernetes API server. The JSON
-// data retrieved is stored in the context. An APICall contains a URLPath
-// used to perform the HTTP GET request and an optional JMESPath used to
-// transform the retrieved JSON data.
+// APICall defines an HTTP request to the Kubernetes API server, or to an external
+// JSON web service. The JSON data retrieved is stored in the context. An APICall
+// contains either a URLPath used to perform the HTTP GET request to the Kubernetes
+// API server or a Service used to call a web service, and an optional JMESPath
+// used to transform the retrieved JSON data.
 type APICall struct {
 	// URLPath is the URL path to be used in the HTTP GET request to the
 	// Kubernetes API server (e.g. "/api/v1/namespaces" or  "/apis/apps/v1/deployments").
 	// The format required is the same format used by the `kubectl get --raw` command.
-	URLPath string `json:"urlPath" yaml:"urlPath"`
+	// +optional
+	URLPath string `json:"urlPath,omitempty" yaml:"urlPath,omitempty"`
+
+	// Service is an API call to a JSON web service.
+	// +optional
+	Service *ServiceCall `json:"service,omitempty" yaml:"service,omitempty"`
 
 	// JMESPath is an optional JSON Match Expression that can be used to
 	// transform the JSON response returned from the API server. For example
@@ -131,6 +182,60 @@ type APICall struct {
 	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
 }
 
+// Method is the HTTP request type.
+// +kubebuilder:validation:Enum=GET;POST
+type Method string
+
+const (
+	// MethodGet is the HTTP GET request type.
+	MethodGet Method = "GET"
+	// MethodPost is the HTTP POST request type.
+	MethodPost Method = "POST"
+)
+
+// ServiceCall defines an HTTP request to an external JSON web service.
+type ServiceCall struct {
+	// URL is the JSON web service URL. A typical form is
+	// `https://{service}.{namespace}:{port}/{path}`.
+	URL string `json:"url" yaml:"url"`
+
+	// Method is the HTTP request type (GET or POST). Defaults to GET.
+	// +kubebuilder:default=GET
+	// +optional
+	Method Method `json:"method,omitempty" yaml:"method,omitempty"`
+
+	// Data specifies the POST data sent to the server as a JSON object.
+	// Values can contain variables.
+	// +optional
+	Data []RequestData `json:"data,omitempty" yaml:"data,omitempty"`
+
+	// CABundle is a PEM encoded CA bundle which will be used to validate
+	// the server certificate.
+	// +optional
+	CABundle string `json:"caBundle,omitempty" yaml:"caBundle,omitempty"`
+
+	// Timeout is the maximum duration of the request. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// GetMethod returns the HTTP request type, defaulting to GET.
+func (s *ServiceCall) GetMethod() Method {
+	if s.Method == "" {
+		return MethodGet
+	}
+	return s.Method
+}
+
+// RequestData contains the HTTP POST data.
+type RequestData struct {
+	// Key is a unique identifier for the data value.
+	Key string `json:"key" yaml:"key"`
+
+	// Value is the data value.
+	Value *apiextv1.JSON `json:"value" yaml:"value"`
+}
+
 // Condition defines variable-based conditional criteria for rule execution.
 type Condition struct {
 	// Key is the context entry (using JMESPath) for conditional rule evaluation.
@@ -242,6 +347,12 @@ type Mutation struct {
 	// ForEach applies mutation rules to a list of sub-elements by creating a context for each entry in the list and looping over it to apply the specified logic.
 	// +optional
 	ForEachMutation []ForEachMutation `json:"foreach,omitempty" yaml:"foreach,omitempty"`
+
+	// Action overrides the MutationAction of the policy for this rule.
+	// Allowed values are Audit or Enforce.
+	// +optional
+	// +kubebuilder:validation:Enum=Audit;Enforce
+	Action MutationAction `json:"action,omitempty" yaml:"action,omitempty"`
 }
 
 func (m *Mutation) GetPatchStrategicMerge() apiextensions.JSON {
@@ -476,6 +587,20 @@ type Generation struct {
 	// +optional
 	Synchronize bool `json:"synchronize,omitempty" yaml:"synchronize,omitempty"`
 
+	// DeletionPolicy controls what happens to the generated resources when the trigger resource or the policy is deleted.
+	// Retain keeps the generated resources, Delete deletes them and Orphan keeps them but removes the labels managed by Kyverno.
+	// Optional. If not specified, generated resources are deleted only when Synchronize is set to "true" and they are not cloned.
+	// +optional
+	DeletionPolicy GenerateDeletionPolicy `json:"deletionPolicy,omitempty" yaml:"deletionPolicy,omitempty"`
+
+	// ApplyMode controls how the generated resources are written. Replace creates or replaces the whole resource,
+	// ServerSideApply applies Data with server-side apply so that the fields managed by other controllers are preserved,
+	// conflicts with other managers are reported in the update request status and are not overwritten.
+	// ServerSideApply is only supported with Data.
+	// Optional. Defaults to "Replace" if not specified.
+	// +optional
+	ApplyMode GenerateApplyMode `json:"applyMode,omitempty" yaml:"applyMode,omitempty"`
+
 	// Data provides the resource declaration used to populate each generated resource.
 	// At most one of Data or Clone must be specified. If neither are provided, the generated
 	// resource will be created with default data only.
@@ -491,12 +616,63 @@ type Generation struct {
 	// CloneList specifies the list of source resource used to populate each generated resource.
 	// +optional
 	CloneList CloneList `json:"cloneList,omitempty" yaml:"cloneList,omitempty"`
+
+	// ForEach generates one resource for each element of a list by creating a context for each entry in the list.
+	// The kind, name, data or clone of the rule must not be specified when ForEach is used.
+	// +optional
+	ForEachGeneration []ForEachGeneration `json:"foreach,omitempty" yaml:"foreach,omitempty"`
+}
+
+// ForEachGeneration declares the resource generated for each element of a list.
+type ForEachGeneration struct {
+	// List specifies a JMESPath expression that results in one or more elements
+	// for which a resource is generated.
+	List string `json:"list,omitempty" yaml:"list,omitempty"`
+
+	// Context defines variables and data sources that can be used during rule execution.
+	// +optional
+	Context []ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
+
+	// AnyAllConditions are used to determine if a resource should be generated for an element by evaluating a
+	// set of conditions. The declaration can contain nested `any` or `all` statements.
+	// See: https://kyverno.io/docs/writing-policies/preconditions/
+	// +kubebuilder:validation:XPreserveUnknownFields
+	// +optional
+	AnyAllConditions *AnyAllConditions `json:"preconditions,omitempty" yaml:"preconditions,omitempty"`
+
+	// ResourceSpec contains information to select the resource generated for each element,
+	// the name usually references the element to be unique.
+	ResourceSpec `json:",omitempty" yaml:",omitempty"`
+
+	// Data provides the resource declaration used to populate each generated resource.
+	// At most one of Data or Clone must be specified.
+	// +optional
+	RawData *apiextv1.JSON `json:"data,omitempty" yaml:"data,omitempty"`
+
+	// Clone specifies the source resource used to populate each generated resource.
+	// At most one of Data or Clone can be specified.
+	// +optional
+	Clone CloneFrom `json:"clone,omitempty" yaml:"clone,omitempty"`
+}
+
+func (g *ForEachGeneration) GetData() apiextensions.JSON {
+	return FromJSON(g.RawData)
+}
+
+func (g *ForEachGeneration) SetData(in apiextensions.JSON) {
+	g.RawData = ToJSON(in)
 }
 
 type CloneList struct {
 	// Namespace specifies source resource namespace.
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 
+	// NamespaceSelector is a label selector for the source namespaces, resources are cloned
+	// from all the matching namespaces. Only one of Namespace or NamespaceSelector can be specified.
+	// wildcard characters are not supported.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+
 	// Kinds is a list of resource kinds.
 	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
 
@@ -504,6 +680,19 @@ type CloneList struct {
 	// wildcard characters are not supported.
 	// +optional
 	Selector *metav1.LabelSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+
+	// Name is a template for the name of the cloned resources, it can reference the source resource
+	// with the variables {{ source.name }}, {{ source.namespace }} and {{ source.kind }}.
+	// Defaults to the name of the source resource if not specified. It must reference {{ source.namespace }}
+	// when NamespaceSelector is specified, so that resources with the same name in different namespaces are
+	// not cloned to the same resource.
+	// +optional
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// IsServerSideApply returns true if the generated resources are written with server-side apply
+func (g *Generation) IsServerSideApply() bool {
+	return g.ApplyMode == GenerateApplyModeServerSideApply
 }
 
 func (g *Generation) GetData() apiextensions.JSON {
@@ -8,6 +8,12 @@ const (
 	AnnotationPolicyCategory = "policies.kyverno.io/category"
 	AnnotationPolicySeverity = "policies.kyverno.io/severity"
 	AnnotationPolicyScored   = "policies.kyverno.io/scored"
+	// AnnotationGenerateExistingBackfill defines the annotation key used to re-trigger the generate existing backfill,
+	// setting it to a new value runs the backfill again
+	AnnotationGenerateExistingBackfill = "policies.kyverno.io/generate-existing-backfill"
+	// LabelWebhookGroup defines the label key grouping policies in the same resource webhook
+	// when resource webhooks are configured per policy
+	LabelWebhookGroup = "webhooks.kyverno.io/group"
 	// ValueKyvernoApp defines the kyverno application value
 	ValueKyvernoApp = "kyverno"
 )
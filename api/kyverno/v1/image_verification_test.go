@@ -104,7 +104,7 @@ func Test_ImageVerification(t *testing.T) {
 			errors: func(i *ImageVerification) field.ErrorList {
 				return field.ErrorList{
 					field.Invalid(path.Child("attestors").Index(0).Child("entries").Index(0),
-						&i.Attestors[0].Entries[0], "keys, certificates, keyless, or a nested attestor is required"),
+						&i.Attestors[0].Entries[0], "keys, certificates, keyless, notary, or a nested attestor is required"),
 				}
 			},
 		},
@@ -136,6 +136,51 @@ func Test_ImageVerification(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid notary attestor",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Notary: &NotaryAttestor{Certificates: "bla", TrustedIdentities: []string{"O=wabbit-network.io"}},
+					}}},
+				},
+			},
+		},
+		{
+			name: "notary attestor without certificates",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Notary: &NotaryAttestor{},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestors").Index(0).Child("entries").Index(0).Child("notary"),
+						i.Attestors[0].Entries[0].Notary, "certs required"),
+				}
+			},
+		},
+		{
+			name: "notary attestor with attestations",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Notary: &NotaryAttestor{Certificates: "bla"},
+					}}},
+				},
+				Attestations: []Attestation{{PredicateType: "https://slsa.dev/provenance/v0.2"}},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path, i, "Attestations are not supported with notary attestors"),
+				}
+			},
+		},
 		{
 			name: "invalid keyless attestor",
 			subject: ImageVerification{
@@ -110,6 +110,11 @@ type Attestor struct {
 	// +kubebuilder:validation:Optional
 	Keyless *KeylessAttestor `json:"keyless,omitempty" yaml:"keyless,omitempty"`
 
+	// Notary is a set of attributes used to verify Notary v2 signatures created with Notation.
+	// See https://github.com/notaryproject/notaryproject.
+	// +kubebuilder:validation:Optional
+	Notary *NotaryAttestor `json:"notary,omitempty" yaml:"notary,omitempty"`
+
 	// Attestor is a nested AttestorSet used to specify a more complex set of match authorities
 	// +kubebuilder:validation:Optional
 	Attestor *apiextv1.JSON `json:"attestor,omitempty" yaml:"attestor,omitempty"`
@@ -199,6 +204,19 @@ type KeylessAttestor struct {
 	AdditionalExtensions map[string]string `json:"additionalExtensions,omitempty" yaml:"additionalExtensions,omitempty"`
 }
 
+type NotaryAttestor struct {
+	// Certificates is the trust store, a set of PEM encoded X.509 root or intermediate certificates.
+	// The certificate chain of the signature must lead to one of these certificates.
+	Certificates string `json:"certs" yaml:"certs"`
+
+	// TrustedIdentities is an optional list of distinguished names, for example "x509.subject: C=US, ST=WA, O=wabbit-network.io",
+	// the subject of the signing certificate must match one of them. A trusted identity must contain the C, ST and O
+	// attributes and each of its attributes must be present in the certificate subject with the same value, the
+	// "x509.subject:" prefix is optional. Any identity is trusted if the list is empty or contains "*".
+	// +kubebuilder:validation:Optional
+	TrustedIdentities []string `json:"trustedIdentities,omitempty" yaml:"trustedIdentities,omitempty"`
+}
+
 type CTLog struct {
 	// URL is the address of the transparency log. Defaults to the public log https://rekor.sigstore.dev.
 	// +kubebuilder:validation:Required
@@ -234,6 +252,10 @@ func (iv *ImageVerification) Validate(path *field.Path) (errs field.ErrorList) {
 		errs = append(errs, field.Invalid(path, iv, "An attestor is required"))
 	}
 
+	if hasAttestations && hasNotaryAttestor(copy.Attestors) {
+		errs = append(errs, field.Invalid(path, iv, "Attestations are not supported with notary attestors"))
+	}
+
 	attestorsPath := path.Child("attestors")
 	for i, as := range copy.Attestors {
 		attestorErrors := as.Validate(attestorsPath.Index(i))
@@ -243,6 +265,18 @@ func (iv *ImageVerification) Validate(path *field.Path) (errs field.ErrorList) {
 	return errs
 }
 
+func hasNotaryAttestor(attestorSets []AttestorSet) bool {
+	for _, as := range attestorSets {
+		for _, e := range as.Entries {
+			if e.Notary != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (as *AttestorSet) Validate(path *field.Path) (errs field.ErrorList) {
 	return validateAttestorSet(as, path)
 }
@@ -268,12 +302,14 @@ func validateAttestorSet(as *AttestorSet, path *field.Path) (errs field.ErrorLis
 }
 
 func (a *Attestor) Validate(path *field.Path) (errs field.ErrorList) {
-	if (a.Keys != nil && (a.Certificates != nil || a.Keyless != nil || a.Attestor != nil)) ||
-		(a.Certificates != nil && (a.Keys != nil || a.Keyless != nil || a.Attestor != nil)) ||
-		(a.Keyless != nil && (a.Certificates != nil || a.Keys != nil || a.Attestor != nil)) ||
-		(a.Attestor != nil && (a.Certificates != nil || a.Keys != nil || a.Keyless != nil)) ||
-		(a.Keys == nil && a.Certificates == nil && a.Keyless == nil && a.Attestor == nil) {
-		errs = append(errs, field.Invalid(path, a, "keys, certificates, keyless, or a nested attestor is required"))
+	count := 0
+	for _, set := range []bool{a.Keys != nil, a.Certificates != nil, a.Keyless != nil, a.Notary != nil, a.Attestor != nil} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		errs = append(errs, field.Invalid(path, a, "keys, certificates, keyless, notary, or a nested attestor is required"))
 	}
 
 	if a.Keys != nil {
@@ -294,6 +330,12 @@ func (a *Attestor) Validate(path *field.Path) (errs field.ErrorList) {
 		errs = append(errs, keylessErrors...)
 	}
 
+	if a.Notary != nil {
+		notaryPath := path.Child("notary")
+		notaryErrors := a.Notary.Validate(notaryPath)
+		errs = append(errs, notaryErrors...)
+	}
+
 	if a.Attestor != nil {
 		attestorPath := path.Child("attestor")
 		attestorSet, err := AttestorSetUnmarshal(a.Attestor)
@@ -336,6 +378,14 @@ func (ca *CertificateAttestor) Validate(path *field.Path) (errs field.ErrorList)
 	return errs
 }
 
+func (na *NotaryAttestor) Validate(path *field.Path) (errs field.ErrorList) {
+	if na.Certificates == "" {
+		errs = append(errs, field.Invalid(path, na, "certs required"))
+	}
+
+	return errs
+}
+
 func (ka *KeylessAttestor) Validate(path *field.Path) (errs field.ErrorList) {
 	if ka.Rekor == nil && ka.Roots == "" {
 		errs = append(errs, field.Invalid(path, ka, "Either Rekor URL or roots are required"))
@@ -33,6 +33,29 @@ type PolicyStatus struct {
 	// RuleCount describes total number of rules in a policy
 	// +optional
 	RuleCount RuleCountStatus `json:"rulecount" yaml:"rulecount"`
+	// GenerateExisting contains the progress of the generate existing backfill,
+	// it is set when generateExistingOnPolicyUpdate is enabled
+	// +optional
+	GenerateExisting *GenerateExistingStatus `json:"generateExisting,omitempty" yaml:"generateExisting,omitempty"`
+}
+
+// GenerateExistingStatus contains the progress of the generate existing backfill,
+// the counts are computed from the generate update requests of the policy
+type GenerateExistingStatus struct {
+	// TriggersDiscovered is the number of trigger resources matched by the last backfill
+	TriggersDiscovered int `json:"triggersDiscovered" yaml:"triggersDiscovered"`
+	// Processed is the number of update requests created by the backfill and completed or skipped
+	Processed int `json:"processed" yaml:"processed"`
+	// Failed is the number of update requests created by the backfill that failed
+	Failed int `json:"failed" yaml:"failed"`
+	// Pending is the number of update requests created by the backfill waiting to be processed
+	Pending int `json:"pending" yaml:"pending"`
+	// LastRunTime is the time the last backfill started
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty" yaml:"lastRunTime,omitempty"`
+	// Trigger is the value of the backfill annotation handled by the last backfill
+	// +optional
+	Trigger string `json:"trigger,omitempty" yaml:"trigger,omitempty"`
 }
 
 // RuleCountStatus contains four variables which describes counts for
@@ -51,6 +51,12 @@ type ResourceDescription struct {
 	// does not match an empty label set.
 	// +optional
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+
+	// Operations is a list of admission operations (CREATE, CONNECT, UPDATE or DELETE).
+	// When set, admission requests with other operations are not matched and are not sent
+	// to Kyverno by the API server. Operations are not checked in background processing.
+	// +optional
+	Operations []AdmissionOperation `json:"operations,omitempty" yaml:"operations,omitempty"`
 }
 
 // Validate implements programmatic validation
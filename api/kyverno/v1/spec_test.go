@@ -3,8 +3,11 @@ package v1
 import (
 	"testing"
 
+	"time"
+
 	"gotest.tools/assert"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -49,3 +52,46 @@ func Test_Validate_UniqueRuleName(t *testing.T) {
 	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
 	assert.Equal(t, errs[0].Detail, "Duplicate rule name: 'deny-privileged-disallowpriviligedescalation'")
 }
+
+func Test_Validate_MutateExistingInterval(t *testing.T) {
+	mutateExisting := Rule{
+		Name: "mutate-existing",
+		MatchResources: MatchResources{
+			ResourceDescription: ResourceDescription{
+				Kinds: []string{"ConfigMap"},
+			},
+		},
+		Mutation: Mutation{
+			Targets: []ResourceSpec{{APIVersion: "v1", Kind: "Secret"}},
+		},
+	}
+	intervalErrors := func(errs field.ErrorList) field.ErrorList {
+		var out field.ErrorList
+		for _, err := range errs {
+			if err.Field == "dummy.mutateExistingInterval" {
+				out = append(out, err)
+			}
+		}
+		return out
+	}
+	path := field.NewPath("dummy")
+
+	subject := Spec{Rules: []Rule{mutateExisting}, MutateExistingInterval: &metav1.Duration{Duration: 5 * time.Minute}}
+	assert.Equal(t, len(intervalErrors(subject.Validate(path, false, "", nil))), 0)
+	assert.Equal(t, subject.GetMutateExistingInterval(), 5*time.Minute)
+
+	subject = Spec{Rules: []Rule{mutateExisting}, MutateExistingInterval: &metav1.Duration{Duration: 30 * time.Second}}
+	errs := intervalErrors(subject.Validate(path, false, "", nil))
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+
+	mutate := mutateExisting
+	mutate.Mutation = Mutation{}
+	subject = Spec{Rules: []Rule{mutate}, MutateExistingInterval: &metav1.Duration{Duration: 5 * time.Minute}}
+	errs = intervalErrors(subject.Validate(path, false, "", nil))
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+
+	subject = Spec{Rules: []Rule{mutateExisting}}
+	assert.Equal(t, subject.GetMutateExistingInterval(), time.Duration(0))
+}
@@ -2,8 +2,10 @@ package v1
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/toggle"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -28,6 +30,21 @@ func (a ValidationFailureAction) Audit() bool {
 	return !a.Enforce()
 }
 
+// MutationAction defines the policy mutation action
+type MutationAction string
+
+// Policy Mutation Modes
+const (
+	// MutationActionEnforce applies the patches to the admission review request
+	MutationActionEnforce MutationAction = "Enforce"
+	// MutationActionAudit reports the patches without applying them
+	MutationActionAudit MutationAction = "Audit"
+)
+
+func (a MutationAction) Audit() bool {
+	return a == MutationActionAudit
+}
+
 type ValidationFailureActionOverride struct {
 	// +kubebuilder:validation:Enum=audit;enforce
 	Action     ValidationFailureAction `json:"action,omitempty" yaml:"action,omitempty"`
@@ -68,6 +85,13 @@ type Spec struct {
 	// +optional
 	ValidationFailureActionOverrides []ValidationFailureActionOverride `json:"validationFailureActionOverrides,omitempty" yaml:"validationFailureActionOverrides,omitempty"`
 
+	// MutationAction defines if the patches of mutate rules are applied to the admission review
+	// request (Enforce), or only reported in admission reports and events (Audit). Optional.
+	// Allowed values are Audit or Enforce. The default value is "Enforce".
+	// +optional
+	// +kubebuilder:validation:Enum=Audit;Enforce
+	MutationAction MutationAction `json:"mutationAction,omitempty" yaml:"mutationAction,omitempty"`
+
 	// Background controls if rules are applied to existing resources during a background scan.
 	// Optional. Default value is "true". The value must be set to "false" if the policy rule
 	// uses variables that are only available in the admission review request (e.g. user name).
@@ -83,6 +107,8 @@ type Spec struct {
 	// WebhookTimeoutSeconds specifies the maximum time in seconds allowed to apply this policy.
 	// After the configured time expires, the admission request may fail, or may simply ignore the policy results,
 	// based on the failure policy. The default timeout is 10s, the value must be between 1 and 30 seconds.
+	// During admission, the timeout of the webhook call is split across the policies it evaluates: rules that
+	// cannot be evaluated before the share of their policy expires report a timeout status.
 	WebhookTimeoutSeconds *int32 `json:"webhookTimeoutSeconds,omitempty" yaml:"webhookTimeoutSeconds,omitempty"`
 
 	// MutateExistingOnPolicyUpdate controls if a mutateExisting policy is applied on policy events.
@@ -90,6 +116,12 @@ type Spec struct {
 	// +optional
 	MutateExistingOnPolicyUpdate bool `json:"mutateExistingOnPolicyUpdate,omitempty" yaml:"mutateExistingOnPolicyUpdate,omitempty"`
 
+	// MutateExistingInterval configures the interval at which the targets of the mutateExisting rules
+	// are reconciled, in addition to admission and policy events. The minimum value is 1m.
+	// Reconciliation on a schedule is disabled if not specified.
+	// +optional
+	MutateExistingInterval *metav1.Duration `json:"mutateExistingInterval,omitempty" yaml:"mutateExistingInterval,omitempty"`
+
 	// GenerateExistingOnPolicyUpdate controls whether to trigger generate rule in existing resources
 	// If is set to "true" generate rule will be triggered and applied to existing matched resources.
 	// Defaults to "false" if not specified.
@@ -101,6 +133,17 @@ func (s *Spec) SetRules(rules []Rule) {
 	s.Rules = rules
 }
 
+// GetMutationAction returns the mutation action of a rule, the action of the rule overrides the one of the policy
+func (s *Spec) GetMutationAction(rule *Rule) MutationAction {
+	if rule.Mutation.Action != "" {
+		return rule.Mutation.Action
+	}
+	if s.MutationAction != "" {
+		return s.MutationAction
+	}
+	return MutationActionEnforce
+}
+
 // HasMutateOrValidateOrGenerate checks for rule types
 func (s *Spec) HasMutateOrValidateOrGenerate() bool {
 	for _, rule := range s.Rules {
@@ -201,6 +244,14 @@ func (s *Spec) GetMutateExistingOnPolicyUpdate() bool {
 	return s.MutateExistingOnPolicyUpdate
 }
 
+// GetMutateExistingInterval returns the interval at which mutateExisting rules are reconciled, zero if not set
+func (s *Spec) GetMutateExistingInterval() time.Duration {
+	if s.MutateExistingInterval == nil {
+		return 0
+	}
+	return s.MutateExistingInterval.Duration
+}
+
 // IsGenerateExistingOnPolicyUpdate return GenerateExistingOnPolicyUpdate set value
 func (s *Spec) IsGenerateExistingOnPolicyUpdate() bool {
 	return s.GenerateExistingOnPolicyUpdate
@@ -259,5 +310,12 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	if namespaced && len(s.ValidationFailureActionOverrides) > 0 {
 		errs = append(errs, field.Forbidden(path.Child("validationFailureActionOverrides"), "Use of validationFailureActionOverrides is supported only with ClusterPolicy"))
 	}
+	if s.MutateExistingInterval != nil {
+		if !s.IsMutateExisting() {
+			errs = append(errs, field.Forbidden(path.Child("mutateExistingInterval"), "mutateExistingInterval requires mutate rules with targets"))
+		} else if s.MutateExistingInterval.Duration < time.Minute {
+			errs = append(errs, field.Invalid(path.Child("mutateExistingInterval"), s.MutateExistingInterval.Duration.String(), "mutateExistingInterval must be at least 1m"))
+		}
+	}
 	return errs
 }
@@ -32,6 +32,11 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APICall) DeepCopyInto(out *APICall) {
 	*out = *in
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceCall)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APICall.
@@ -128,6 +133,11 @@ func (in *Attestor) DeepCopyInto(out *Attestor) {
 		*out = new(KeylessAttestor)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Notary != nil {
+		in, out := &in.Notary, &out.Notary
+		*out = new(NotaryAttestor)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Attestor != nil {
 		in, out := &in.Attestor, &out.Attestor
 		*out = new(apiextensionsv1.JSON)
@@ -254,6 +264,11 @@ func (in *CloneFrom) DeepCopy() *CloneFrom {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloneList) DeepCopyInto(out *CloneList) {
 	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Kinds != nil {
 		in, out := &in.Kinds, &out.Kinds
 		*out = make([]string, len(*in))
@@ -386,7 +401,7 @@ func (in *ContextEntry) DeepCopyInto(out *ContextEntry) {
 	if in.APICall != nil {
 		in, out := &in.APICall, &out.APICall
 		*out = new(APICall)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ImageRegistry != nil {
 		in, out := &in.ImageRegistry, &out.ImageRegistry
@@ -398,6 +413,11 @@ func (in *ContextEntry) DeepCopyInto(out *ContextEntry) {
 		*out = new(Variable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextEntry.
@@ -445,6 +465,40 @@ func (in *DryRunOption) DeepCopy() *DryRunOption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForEachGeneration) DeepCopyInto(out *ForEachGeneration) {
+	*out = *in
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = make([]ContextEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnyAllConditions != nil {
+		in, out := &in.AnyAllConditions, &out.AnyAllConditions
+		*out = new(AnyAllConditions)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ResourceSpec = in.ResourceSpec
+	if in.RawData != nil {
+		in, out := &in.RawData, &out.RawData
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Clone = in.Clone
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForEachGeneration.
+func (in *ForEachGeneration) DeepCopy() *ForEachGeneration {
+	if in == nil {
+		return nil
+	}
+	out := new(ForEachGeneration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForEachMutation) DeepCopyInto(out *ForEachMutation) {
 	*out = *in
@@ -524,6 +578,25 @@ func (in *ForEachValidation) DeepCopy() *ForEachValidation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenerateExistingStatus) DeepCopyInto(out *GenerateExistingStatus) {
+	*out = *in
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerateExistingStatus.
+func (in *GenerateExistingStatus) DeepCopy() *GenerateExistingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GenerateExistingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GenerateRequest) DeepCopyInto(out *GenerateRequest) {
 	*out = *in
@@ -648,6 +721,13 @@ func (in *Generation) DeepCopyInto(out *Generation) {
 	}
 	out.Clone = in.Clone
 	in.CloneList.DeepCopyInto(&out.CloneList)
+	if in.ForEachGeneration != nil {
+		in, out := &in.ForEachGeneration, &out.ForEachGeneration
+		*out = make([]ForEachGeneration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Generation.
@@ -908,6 +988,26 @@ func (in *Mutation) DeepCopy() *Mutation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotaryAttestor) DeepCopyInto(out *NotaryAttestor) {
+	*out = *in
+	if in.TrustedIdentities != nil {
+		in, out := &in.TrustedIdentities, &out.TrustedIdentities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotaryAttestor.
+func (in *NotaryAttestor) DeepCopy() *NotaryAttestor {
+	if in == nil {
+		return nil
+	}
+	out := new(NotaryAttestor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectFieldBinding) DeepCopyInto(out *ObjectFieldBinding) {
 	*out = *in
@@ -1046,6 +1146,11 @@ func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	}
 	in.Autogen.DeepCopyInto(&out.Autogen)
 	out.RuleCount = in.RuleCount
+	if in.GenerateExisting != nil {
+		in, out := &in.GenerateExisting, &out.GenerateExisting
+		*out = new(GenerateExistingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyStatus.
@@ -1058,6 +1163,26 @@ func (in *PolicyStatus) DeepCopy() *PolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestData) DeepCopyInto(out *RequestData) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestData.
+func (in *RequestData) DeepCopy() *RequestData {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestInfo) DeepCopyInto(out *RequestInfo) {
 	*out = *in
@@ -1119,6 +1244,11 @@ func (in *ResourceDescription) DeepCopyInto(out *ResourceDescription) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]AdmissionOperation, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDescription.
@@ -1268,6 +1398,33 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceCall) DeepCopyInto(out *ServiceCall) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]RequestData, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceCall.
+func (in *ServiceCall) DeepCopy() *ServiceCall {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceCall)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Spec) DeepCopyInto(out *Spec) {
 	*out = *in
@@ -1310,6 +1467,11 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MutateExistingInterval != nil {
+		in, out := &in.MutateExistingInterval, &out.MutateExistingInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Spec.
@@ -2,8 +2,10 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -156,3 +158,20 @@ func Test_doesMatchExcludeConflict(t *testing.T) {
 		}
 	}
 }
+
+func Test_CleanupPolicyStatus_SetExecuted(t *testing.T) {
+	var status CleanupPolicyStatus
+	assert.Assert(t, status.GetLastExecutionTime() == nil)
+	first := metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	status.SetExecuted(first, 1, nil, "deleted 2 resources")
+	assert.Equal(t, len(status.Conditions), 1)
+	assert.Equal(t, status.Conditions[0].Status, metav1.ConditionTrue)
+	assert.Equal(t, status.Conditions[0].Message, "deleted 2 resources")
+	assert.Equal(t, *status.GetLastExecutionTime(), first)
+	second := metav1.NewTime(first.Add(time.Hour))
+	status.SetExecuted(second, 1, errors.New("failed to delete"), "")
+	assert.Equal(t, len(status.Conditions), 1)
+	assert.Equal(t, status.Conditions[0].Status, metav1.ConditionFalse)
+	assert.Equal(t, status.Conditions[0].Message, "failed to delete")
+	assert.Equal(t, *status.GetLastExecutionTime(), second)
+}
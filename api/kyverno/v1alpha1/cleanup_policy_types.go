@@ -23,6 +23,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
 	"github.com/robfig/cron"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -164,11 +165,47 @@ type CleanupPolicySpec struct {
 	Conditions *kyvernov1.AnyAllConditions `json:"conditions,omitempty"`
 }
 
+const (
+	// CleanupConditionExecuted records the time and outcome of the last cleanup execution
+	CleanupConditionExecuted = "Executed"
+)
+
 // CleanupPolicyStatus stores the status of the policy.
 type CleanupPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
 
+// SetExecuted records the time and outcome of a cleanup execution
+func (status *CleanupPolicyStatus) SetExecuted(executionTime metav1.Time, generation int64, err error, message string) {
+	condition := metav1.Condition{
+		Type:               CleanupConditionExecuted,
+		ObservedGeneration: generation,
+		LastTransitionTime: executionTime,
+		Message:            message,
+	}
+	if err == nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kyvernov1.PolicyReasonSucceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kyvernov1.PolicyReasonFailed
+		condition.Message = err.Error()
+	}
+	// the transition time tracks the last execution, remove the condition
+	// first so that it is always updated
+	meta.RemoveStatusCondition(&status.Conditions, CleanupConditionExecuted)
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// GetLastExecutionTime returns the time of the last cleanup execution, if any
+func (status *CleanupPolicyStatus) GetLastExecutionTime() *metav1.Time {
+	condition := meta.FindStatusCondition(status.Conditions, CleanupConditionExecuted)
+	if condition == nil {
+		return nil
+	}
+	return &condition.LastTransitionTime
+}
+
 // Validate implements programmatic validation
 func (p *CleanupPolicySpec) Validate(path *field.Path, clusterResources sets.String, namespaced bool) (errs field.ErrorList) {
 	errs = append(errs, ValidateSchedule(path.Child("schedule"), p.Schedule)...)
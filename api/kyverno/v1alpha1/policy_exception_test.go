@@ -0,0 +1,70 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_PolicyException_Validate(t *testing.T) {
+	subject := PolicyException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-exception",
+			Namespace: "default",
+		},
+		Spec: PolicyExceptionSpec{
+			Match: kyvernov1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+				}},
+			},
+			Exceptions: []Exception{{PolicyName: "require-labels", RuleNames: []string{"check-team"}}},
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 0)
+}
+
+func Test_PolicyException_Validate_Errors(t *testing.T) {
+	subject := PolicyException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-exception",
+			Namespace: "default",
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 2)
+	assert.Equal(t, errs[0].Field, "spec.match")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+	assert.Equal(t, errs[1].Field, "spec.exceptions")
+	assert.Equal(t, errs[1].Type, field.ErrorTypeRequired)
+
+	subject.Spec.Match.Any = kyvernov1.ResourceFilters{{
+		ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+	}}
+	subject.Spec.Exceptions = []Exception{{}}
+	errs = subject.Validate(nil)
+	assert.Assert(t, len(errs) == 2)
+	assert.Equal(t, errs[0].Field, "spec.exceptions[0].policyName")
+	assert.Equal(t, errs[1].Field, "spec.exceptions[0].ruleNames")
+}
+
+func Test_PolicyException_Contains(t *testing.T) {
+	subject := PolicyException{
+		Spec: PolicyExceptionSpec{
+			Exceptions: []Exception{
+				{PolicyName: "require-labels", RuleNames: []string{"check-team"}},
+				{PolicyName: "default/disallow-latest", RuleNames: []string{"validate-tag", "validate-pull-policy"}},
+			},
+		},
+	}
+	assert.Assert(t, subject.Contains("require-labels", "check-team"))
+	assert.Assert(t, subject.Contains("require-labels", "autogen-check-team"))
+	assert.Assert(t, subject.Contains("require-labels", "autogen-cronjob-check-team"))
+	assert.Assert(t, subject.Contains("default/disallow-latest", "validate-pull-policy"))
+	assert.Assert(t, !subject.Contains("require-labels", "check-app"))
+	assert.Assert(t, !subject.Contains("disallow-latest", "validate-tag"))
+}
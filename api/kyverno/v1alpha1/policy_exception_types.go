@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=polex,categories=kyverno
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PolicyException declares resources to be excluded from specified policies.
+type PolicyException struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares policy exception behaviors.
+	Spec PolicyExceptionSpec `json:"spec"`
+}
+
+// GetSpec returns the policy exception spec
+func (p *PolicyException) GetSpec() *PolicyExceptionSpec {
+	return &p.Spec
+}
+
+func (p *PolicyException) GetKind() string {
+	return p.Kind
+}
+
+// Contains returns true if the exception applies to the rule of the policy
+func (p *PolicyException) Contains(policy string, rule string) bool {
+	return p.Spec.Contains(policy, rule)
+}
+
+// Validate implements programmatic validation
+func (p *PolicyException) Validate(clusterResources sets.String) (errs field.ErrorList) {
+	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), p.Name)...)
+	errs = append(errs, p.Spec.Validate(field.NewPath("spec"), clusterResources)...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicyExceptionList is a list of PolicyException instances.
+type PolicyExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []PolicyException `json:"items"`
+}
+
+// PolicyExceptionSpec stores policy exception spec
+type PolicyExceptionSpec struct {
+	// Match defines match clause used to check if a resource applies to the exception.
+	// The match criteria can include resource information (e.g. kind, name, namespace, labels)
+	// and admission review request information like the user name or role.
+	Match kyvernov1.MatchResources `json:"match"`
+
+	// Exceptions is a list policy/rules to be excluded
+	Exceptions []Exception `json:"exceptions"`
+}
+
+// Contains returns true if one of the exceptions applies to the rule of the policy
+func (p *PolicyExceptionSpec) Contains(policy string, rule string) bool {
+	for _, exception := range p.Exceptions {
+		if exception.Contains(policy, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate implements programmatic validation
+func (p *PolicyExceptionSpec) Validate(path *field.Path, clusterResources sets.String) (errs field.ErrorList) {
+	if reflect.DeepEqual(p.Match, kyvernov1.MatchResources{}) {
+		errs = append(errs, field.Invalid(path.Child("match"), p.Match, "An exception requires a match declaration"))
+	} else {
+		errs = append(errs, p.Match.Validate(path.Child("match"), false, clusterResources)...)
+	}
+	exceptionsPath := path.Child("exceptions")
+	if len(p.Exceptions) == 0 {
+		errs = append(errs, field.Required(exceptionsPath, "An exception requires at least one policy"))
+	}
+	for i, exception := range p.Exceptions {
+		errs = append(errs, exception.Validate(exceptionsPath.Index(i))...)
+	}
+	return errs
+}
+
+// Exception stores infos about a policy and rules
+type Exception struct {
+	// PolicyName identifies the policy to which the exception is applied.
+	// The policy name uses the format <namespace>/<name> unless it
+	// references a ClusterPolicy.
+	PolicyName string `json:"policyName"`
+
+	// RuleNames identifies the rules to which the exception is applied.
+	RuleNames []string `json:"ruleNames"`
+}
+
+// Contains returns true if the exception applies to the rule of the policy,
+// rules generated by auto-gen are excepted with the rule they were generated from
+func (p *Exception) Contains(policy string, rule string) bool {
+	if p.PolicyName != policy {
+		return false
+	}
+	rule = strings.TrimPrefix(strings.TrimPrefix(rule, "autogen-cronjob-"), "autogen-")
+	for _, ruleName := range p.RuleNames {
+		if ruleName == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate implements programmatic validation
+func (p *Exception) Validate(path *field.Path) (errs field.ErrorList) {
+	if p.PolicyName == "" {
+		errs = append(errs, field.Required(path.Child("policyName"), "An exception requires a policy name"))
+	}
+	if len(p.RuleNames) == 0 {
+		errs = append(errs, field.Required(path.Child("ruleNames"), "An exception requires at least one rule name"))
+	}
+	return errs
+}
@@ -9,9 +9,13 @@ const (
 	URMutatetriggerAPIVersionLabel = "mutate.updaterequest.kyverno.io/trigger-apiversion"
 
 	// URGeneratePolicyLabel adds the policy name to URs for generate policies
-	URGeneratePolicyLabel          = "generate.kyverno.io/policy-name"
-	URGenerateResourceNameLabel    = "generate.kyverno.io/resource-name"
-	URGenerateResourceNSLabel      = "generate.kyverno.io/resource-namespace"
-	URGenerateResourceKindLabel    = "generate.kyverno.io/resource-kind"
+	URGeneratePolicyLabel       = "generate.kyverno.io/policy-name"
+	URGenerateResourceNameLabel = "generate.kyverno.io/resource-name"
+	URGenerateResourceNSLabel   = "generate.kyverno.io/resource-namespace"
+	URGenerateResourceKindLabel = "generate.kyverno.io/resource-kind"
+	// URGenerateBackfillLabel marks the URs created by the generate existing backfill of a policy
+	URGenerateBackfillLabel = "generate.kyverno.io/backfill"
+	// URGenerateRetryCountAnnotation is not set anymore, retries are recorded in .status.retryCount
+	// Deprecated: use UpdateRequestStatus.RetryCount instead
 	URGenerateRetryCountAnnotation = "generate.kyverno.io/retry-count"
 )
@@ -38,6 +38,14 @@ type UpdateRequestStatus struct {
 	// This will track the resources that are updated by the generate Policy.
 	// Will be used during clean up resources.
 	GeneratedResources []kyvernov1.ResourceSpec `json:"generatedResources,omitempty" yaml:"generatedResources,omitempty"`
+
+	// RetryCount is the number of failed attempts to process the update request.
+	// +optional
+	RetryCount int `json:"retryCount,omitempty" yaml:"retryCount,omitempty"`
+
+	// LastError is the error returned by the last failed attempt to process the update request.
+	// +optional
+	LastError string `json:"lastError,omitempty" yaml:"lastError,omitempty"`
 }
 
 // +genclient
@@ -129,7 +137,8 @@ const (
 	// Pending - the Request is yet to be processed or resource has not been created.
 	Pending UpdateRequestState = "Pending"
 
-	// Failed - the Update Request Controller failed to process the rules.
+	// Failed - the Update Request Controller failed to process the rules, requests failing
+	// after all the retries are not processed anymore until they are set back to Pending.
 	Failed UpdateRequestState = "Failed"
 
 	// Completed - the Update Request Controller created resources defined in the policy.
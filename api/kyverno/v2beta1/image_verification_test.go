@@ -47,7 +47,7 @@ func Test_ImageVerification(t *testing.T) {
 			errors: func(i *ImageVerification) field.ErrorList {
 				return field.ErrorList{
 					field.Invalid(path.Child("attestors").Index(0).Child("entries").Index(0),
-						&i.Attestors[0].Entries[0], "keys, certificates, keyless, or a nested attestor is required"),
+						&i.Attestors[0].Entries[0], "keys, certificates, keyless, notary, or a nested attestor is required"),
 				}
 			},
 		},
@@ -41,6 +41,13 @@ type Spec struct {
 	// +optional
 	ValidationFailureActionOverrides []kyvernov1.ValidationFailureActionOverride `json:"validationFailureActionOverrides,omitempty" yaml:"validationFailureActionOverrides,omitempty"`
 
+	// MutationAction defines if the patches of mutate rules are applied to the admission review
+	// request (Enforce), or only reported in admission reports and events (Audit). Optional.
+	// Allowed values are Audit or Enforce. The default value is "Enforce".
+	// +optional
+	// +kubebuilder:validation:Enum=Audit;Enforce
+	MutationAction kyvernov1.MutationAction `json:"mutationAction,omitempty" yaml:"mutationAction,omitempty"`
+
 	// Background controls if rules are applied to existing resources during a background scan.
 	// Optional. Default value is "true". The value must be set to "false" if the policy rule
 	// uses variables that are only available in the admission review request (e.g. user name).
@@ -56,6 +63,8 @@ type Spec struct {
 	// WebhookTimeoutSeconds specifies the maximum time in seconds allowed to apply this policy.
 	// After the configured time expires, the admission request may fail, or may simply ignore the policy results,
 	// based on the failure policy. The default timeout is 10s, the value must be between 1 and 30 seconds.
+	// During admission, the timeout of the webhook call is split across the policies it evaluates: rules that
+	// cannot be evaluated before the share of their policy expires report a timeout status.
 	WebhookTimeoutSeconds *int32 `json:"webhookTimeoutSeconds,omitempty" yaml:"webhookTimeoutSeconds,omitempty"`
 
 	// MutateExistingOnPolicyUpdate controls if a mutateExisting policy is applied on policy events.
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"net/http"
 	"os"
@@ -11,10 +12,16 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/cmd/internal"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	kubeclientmetrics "github.com/kyverno/kyverno/pkg/clients/wrappers/metrics/kube"
+	kyvernoclientmetrics "github.com/kyverno/kyverno/pkg/clients/wrappers/metrics/kyverno"
 	kubeclienttraces "github.com/kyverno/kyverno/pkg/clients/wrappers/traces/kube"
+	kyvernoclienttraces "github.com/kyverno/kyverno/pkg/clients/wrappers/traces/kyverno"
 	"github.com/kyverno/kyverno/pkg/config"
+	cleanupcontroller "github.com/kyverno/kyverno/pkg/controllers/cleanup"
+	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	corev1 "k8s.io/api/core/v1"
@@ -24,14 +31,15 @@ import (
 )
 
 var (
-	kubeconfig           string
-	clientRateLimitQPS   float64
-	clientRateLimitBurst int
-	otel                 string
-	otelCollector        string
-	metricsPort          string
-	transportCreds       string
-	disableMetricsExport bool
+	kubeconfig                string
+	clientRateLimitQPS        float64
+	clientRateLimitBurst      int
+	otel                      string
+	otelCollector             string
+	metricsPort               string
+	transportCreds            string
+	disableMetricsExport      bool
+	leaderElectionRetryPeriod time.Duration
 )
 
 const (
@@ -48,6 +56,7 @@ func parseFlags(config internal.Configuration) {
 	flag.StringVar(&transportCreds, "transportCreds", "", "Set this flag to the CA secret containing the certificate which is used by our Opentelemetry Metrics Client. If empty string is set, means an insecure connection will be used")
 	flag.StringVar(&metricsPort, "metricsPort", "8000", "Expose prometheus metrics at the given port, default to 8000.")
 	flag.BoolVar(&disableMetricsExport, "disableMetrics", false, "Set this flag to 'true' to disable metrics.")
+	flag.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
 	flag.Parse()
 }
 
@@ -65,19 +74,24 @@ func createKubeClients(logger logr.Logger) (*rest.Config, kubernetes.Interface,
 	return clientConfig, kubeClient, nil
 }
 
-func createInstrumentedClients(ctx context.Context, logger logr.Logger, clientConfig *rest.Config, metricsConfig *metrics.MetricsConfig) (kubernetes.Interface, dclient.Interface, error) {
+func createInstrumentedClients(ctx context.Context, logger logr.Logger, clientConfig *rest.Config, metricsConfig *metrics.MetricsConfig) (kubernetes.Interface, versioned.Interface, dclient.Interface, error) {
 	logger = logger.WithName("instrumented-clients")
 	logger.Info("create instrumented clients...", "kubeconfig", kubeconfig, "qps", clientRateLimitQPS, "burst", clientRateLimitBurst)
 	kubeClient, err := kubeclientmetrics.NewForConfig(clientConfig, metricsConfig, metrics.KubeClient)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	kubeClient = kubeclienttraces.Wrap(kubeClient)
+	kyvernoClient, err := kyvernoclientmetrics.NewForConfig(clientConfig, metricsConfig, metrics.KyvernoClient)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	kyvernoClient = kyvernoclienttraces.Wrap(kyvernoClient)
 	dynamicClient, err := dclient.NewClient(ctx, clientConfig, kubeClient, metricsConfig, resyncPeriod)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	return kubeClient, dynamicClient, nil
+	return kubeClient, kyvernoClient, dynamicClient, nil
 }
 
 func setupMetrics(logger logr.Logger, kubeClient kubernetes.Interface) (*metrics.MetricsConfig, context.CancelFunc, error) {
@@ -155,7 +169,7 @@ func main() {
 		defer metricsShutdown()
 	}
 	// create instrumented clients
-	kubeClient, dynamicClient, err := createInstrumentedClients(signalCtx, logger, clientConfig, metricsConfig)
+	kubeClient, kyvernoClient, dynamicClient, err := createInstrumentedClients(signalCtx, logger, clientConfig, metricsConfig)
 	if err != nil {
 		logger.Error(err, "failed to create instrument clients")
 		os.Exit(1)
@@ -166,10 +180,53 @@ func main() {
 	)
 	secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister()
 	// start informers and wait for cache sync
-	// we need to call start again because we potentially registered new informers
 	if !internal.StartInformersAndWaitForCacheSync(signalCtx, kubeKyvernoInformer) {
 		os.Exit(1)
 	}
+	// setup leader election, policies are executed by the leader only
+	le, err := leaderelection.New(
+		logger.WithName("leader-election"),
+		"kyverno-cleanup-controller",
+		config.KyvernoNamespace(),
+		kubeClient,
+		config.KyvernoPodName(),
+		leaderElectionRetryPeriod,
+		func(ctx context.Context) {
+			logger := logger.WithName("leader")
+			// create leader factories
+			kubeInformer := kubeinformers.NewSharedInformerFactory(kubeClient, resyncPeriod)
+			kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
+			// create leader controllers
+			cleanupController := cleanupcontroller.NewController(
+				dynamicClient,
+				kyvernoClient,
+				kyvernoInformer.Kyverno().V1alpha1().ClusterCleanupPolicies(),
+				kyvernoInformer.Kyverno().V1alpha1().CleanupPolicies(),
+				kubeInformer.Core().V1().Namespaces(),
+			)
+			// start informers and wait for cache sync
+			if !internal.StartInformersAndWaitForCacheSync(ctx, kubeInformer, kyvernoInformer) {
+				logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+				os.Exit(1)
+			}
+			// start cleanup controller, it stops when the leadership is lost
+			cleanupController.Run(ctx, cleanupcontroller.Workers)
+		},
+		nil,
+	)
+	if err != nil {
+		logger.Error(err, "failed to initialize leader election")
+		os.Exit(1)
+	}
+	// start leader election
+	go func() {
+		select {
+		case <-signalCtx.Done():
+			return
+		default:
+			le.Run(signalCtx)
+		}
+	}()
 	server := NewServer(
 		policyHandlers,
 		func() ([]byte, []byte, error) {
@@ -0,0 +1,241 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	util "github.com/kyverno/kyverno/pkg/utils"
+)
+
+const (
+	branchPreconditionsMet    = "preconditions met"
+	branchPreconditionsNotMet = "preconditions not met"
+)
+
+// CoverageReport describes which rules of the tested policies were exercised by the test results
+type CoverageReport struct {
+	// Rules is the number of rules evaluated by the engine for at least one test result.
+	Rules CoverageSummary `json:"rules"`
+	// Branches is the number of branches of the rules hit by the engine, branches are the
+	// outcomes of preconditions and the alternatives of anyPattern.
+	Branches CoverageSummary  `json:"branches"`
+	Policies []PolicyCoverage `json:"policies"`
+}
+
+type CoverageSummary struct {
+	Covered    int     `json:"covered"`
+	Total      int     `json:"total"`
+	Percentage float64 `json:"percentage"`
+}
+
+type PolicyCoverage struct {
+	Name  string         `json:"name"`
+	Rules []RuleCoverage `json:"rules"`
+}
+
+type RuleCoverage struct {
+	Name string `json:"name"`
+	// Tested is true when the rule is referenced by a test result.
+	Tested bool `json:"tested"`
+	// Executed is true when the engine evaluated the rule for at least one resource.
+	Executed bool `json:"executed"`
+	// Results are the rule statuses reported by the engine.
+	Results  []string         `json:"results,omitempty"`
+	Branches []BranchCoverage `json:"branches,omitempty"`
+}
+
+type BranchCoverage struct {
+	Name string `json:"name"`
+	Hit  bool   `json:"hit"`
+}
+
+// coverage collects the coverage of the policy rules across the test files of a test command
+type coverage struct {
+	policies map[string]*PolicyCoverage
+	order    []string
+}
+
+func newCoverage() *coverage {
+	return &coverage{policies: map[string]*PolicyCoverage{}}
+}
+
+// addPolicies registers the rules of the policies, the rules referenced by the test results are marked as tested
+func (c *coverage) addPolicies(policies []kyvernov1.PolicyInterface, results []TestResults) {
+	for _, policy := range policies {
+		key := coveragePolicyKey(policy.GetNamespace(), policy.GetName())
+		pc, ok := c.policies[key]
+		if !ok {
+			pc = &PolicyCoverage{Name: key}
+			c.policies[key] = pc
+			c.order = append(c.order, key)
+		}
+
+		for _, rule := range policy.GetSpec().Rules {
+			rc := pc.rule(rule.Name)
+			if rc == nil {
+				pc.Rules = append(pc.Rules, RuleCoverage{Name: rule.Name, Branches: ruleBranches(rule)})
+				rc = &pc.Rules[len(pc.Rules)-1]
+			}
+
+			for _, result := range results {
+				if result.Rule == rule.Name && (result.Policy == key || result.Policy == policy.GetName()) {
+					rc.Tested = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// addEngineResponses records the rules evaluated by the engine and the branches they hit
+func (c *coverage) addEngineResponses(engineResponses []*response.EngineResponse) {
+	for _, resp := range engineResponses {
+		pc, ok := c.policies[coveragePolicyKey(resp.PolicyResponse.Policy.Namespace, resp.PolicyResponse.Policy.Name)]
+		if !ok {
+			continue
+		}
+
+		for _, ruleResp := range resp.PolicyResponse.Rules {
+			name := strings.TrimPrefix(strings.TrimPrefix(ruleResp.Name, "autogen-cronjob-"), "autogen-")
+			rc := pc.rule(name)
+			if rc == nil {
+				continue
+			}
+
+			rc.Executed = true
+			status := ruleResp.Status.String()
+			if !util.ContainsString(rc.Results, status) {
+				rc.Results = append(rc.Results, status)
+				sort.Strings(rc.Results)
+			}
+
+			for i := range rc.Branches {
+				if !rc.Branches[i].Hit && branchHit(rc.Branches[i].Name, ruleResp) {
+					rc.Branches[i].Hit = true
+				}
+			}
+		}
+	}
+}
+
+func (c *coverage) report() CoverageReport {
+	report := CoverageReport{}
+	for _, key := range c.order {
+		pc := c.policies[key]
+		for _, rc := range pc.Rules {
+			report.Rules.Total++
+			if rc.Tested && rc.Executed {
+				report.Rules.Covered++
+			}
+
+			for _, branch := range rc.Branches {
+				report.Branches.Total++
+				if branch.Hit {
+					report.Branches.Covered++
+				}
+			}
+		}
+		report.Policies = append(report.Policies, *pc)
+	}
+
+	report.Rules.Percentage = percentage(report.Rules.Covered, report.Rules.Total)
+	report.Branches.Percentage = percentage(report.Branches.Covered, report.Branches.Total)
+	return report
+}
+
+func (p *PolicyCoverage) rule(name string) *RuleCoverage {
+	for i := range p.Rules {
+		if p.Rules[i].Name == name {
+			return &p.Rules[i]
+		}
+	}
+	return nil
+}
+
+// ruleBranches returns the branches of a rule that can be observed in the engine responses
+func ruleBranches(rule kyvernov1.Rule) []BranchCoverage {
+	var branches []BranchCoverage
+	if rule.GetAnyAllConditions() != nil {
+		branches = append(branches, BranchCoverage{Name: branchPreconditionsMet}, BranchCoverage{Name: branchPreconditionsNotMet})
+	}
+
+	if rule.HasValidate() {
+		if anyPattern := rule.Validation.GetAnyPattern(); anyPattern != nil {
+			var patterns []interface{}
+			if data, err := json.Marshal(anyPattern); err == nil && json.Unmarshal(data, &patterns) == nil {
+				for i := range patterns {
+					branches = append(branches, BranchCoverage{Name: fmt.Sprintf("anyPattern[%d]", i)})
+				}
+			}
+		}
+	}
+
+	return branches
+}
+
+func branchHit(branch string, ruleResp response.RuleResponse) bool {
+	switch branch {
+	case branchPreconditionsMet:
+		return ruleResp.Status == response.RuleStatusPass || ruleResp.Status == response.RuleStatusFail
+	case branchPreconditionsNotMet:
+		return ruleResp.Status == response.RuleStatusSkip && ruleResp.Message == "preconditions not met"
+	default:
+		// anyPattern alternatives are reported in the message of the rule when they pass
+		return ruleResp.Status == response.RuleStatusPass && strings.Contains(ruleResp.Message, branch+" passed")
+	}
+}
+
+func coveragePolicyKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+func percentage(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(covered) * 100 / float64(total)
+}
+
+func printCoverageSummary(w io.Writer, report CoverageReport) {
+	fmt.Fprintf(w, "Test Coverage: %d of %d rules (%.2f%%) and %d of %d branches (%.2f%%) covered\n",
+		report.Rules.Covered, report.Rules.Total, report.Rules.Percentage,
+		report.Branches.Covered, report.Branches.Total, report.Branches.Percentage)
+	for _, pc := range report.Policies {
+		for _, rc := range pc.Rules {
+			if !rc.Tested {
+				fmt.Fprintf(w, "  %s/%s: not tested\n", pc.Name, rc.Name)
+				continue
+			}
+			if !rc.Executed {
+				fmt.Fprintf(w, "  %s/%s: not evaluated by the engine\n", pc.Name, rc.Name)
+				continue
+			}
+			var missed []string
+			for _, branch := range rc.Branches {
+				if !branch.Hit {
+					missed = append(missed, branch.Name)
+				}
+			}
+			if len(missed) > 0 {
+				fmt.Fprintf(w, "  %s/%s: branches not hit: %s\n", pc.Name, rc.Name, strings.Join(missed, ", "))
+			}
+		}
+	}
+}
+
+func writeCoverageFile(path string, report CoverageReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
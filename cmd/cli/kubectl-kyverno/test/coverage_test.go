@@ -0,0 +1,84 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"gotest.tools/assert"
+)
+
+var coveragePolicy = []byte(`{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "check-pods"
+  },
+  "spec": {
+    "rules": [
+      {
+        "name": "require-labels",
+        "match": {"resources": {"kinds": ["Pod"]}},
+        "preconditions": {"all": [{"key": "{{ request.operation }}", "operator": "Equals", "value": "CREATE"}]},
+        "validate": {
+          "anyPattern": [
+            {"metadata": {"labels": {"app": "?*"}}},
+            {"metadata": {"labels": {"team": "?*"}}}
+          ]
+        }
+      },
+      {
+        "name": "require-requests",
+        "match": {"resources": {"kinds": ["Pod"]}},
+        "validate": {"pattern": {"spec": {"containers": [{"resources": {"requests": {"memory": "?*"}}}]}}}
+      }
+    ]
+  }
+}`)
+
+func Test_Coverage(t *testing.T) {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(coveragePolicy, &policy))
+
+	c := newCoverage()
+	c.addPolicies([]kyvernov1.PolicyInterface{&policy}, []TestResults{{Policy: "check-pods", Rule: "require-labels"}})
+	c.addEngineResponses([]*response.EngineResponse{{
+		PolicyResponse: response.PolicyResponse{
+			Policy: response.PolicySpec{Name: "check-pods"},
+			Rules: []response.RuleResponse{
+				{Name: "autogen-require-labels", Status: response.RuleStatusPass, Message: "validation rule 'autogen-require-labels' anyPattern[1] passed."},
+				{Name: "require-labels", Status: response.RuleStatusSkip, Message: "preconditions not met"},
+			},
+		},
+	}})
+
+	report := c.report()
+	assert.Equal(t, report.Rules.Covered, 1)
+	assert.Equal(t, report.Rules.Total, 2)
+	assert.Equal(t, report.Rules.Percentage, float64(50))
+	assert.Equal(t, report.Branches.Covered, 3)
+	assert.Equal(t, report.Branches.Total, 4)
+
+	rules := report.Policies[0].Rules
+	assert.DeepEqual(t, rules[0].Results, []string{"pass", "skip"})
+	assert.DeepEqual(t, rules[0].Branches, []BranchCoverage{
+		{Name: branchPreconditionsMet, Hit: true},
+		{Name: branchPreconditionsNotMet, Hit: true},
+		{Name: "anyPattern[0]", Hit: false},
+		{Name: "anyPattern[1]", Hit: true},
+	})
+	assert.Equal(t, rules[1].Tested, false)
+	assert.Equal(t, rules[1].Executed, false)
+}
+
+func Test_ruleBranches_Foreach(t *testing.T) {
+	// the elements of foreach are not reported by the engine, they are not counted as branches
+	rule := kyvernov1.Rule{
+		Name: "check-containers",
+		Validation: kyvernov1.Validation{
+			ForEachValidation: []kyvernov1.ForEachValidation{{List: "request.object.spec.containers"}},
+		},
+	}
+	assert.Equal(t, len(ruleBranches(rule)), 0)
+}
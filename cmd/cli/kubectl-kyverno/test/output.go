@@ -0,0 +1,199 @@
+package test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+)
+
+const (
+	outputFormatJUnit = "junit"
+	outputFormatJSON  = "json"
+
+	testCasePass = "pass"
+	testCaseFail = "fail"
+
+	// diffProperty is the result property holding the difference between the expected and the actual resource
+	diffProperty = "diff"
+)
+
+// TestCase is the outcome of a single entry of the test results
+type TestCase struct {
+	// Policy is the name of the policy, prefixed by its namespace for namespaced policies.
+	Policy string `json:"policy"`
+	// Rule is the name of the rule.
+	Rule string `json:"rule"`
+	// Resource is the resource the policy was applied to, formatted as <namespace>/<kind>/<name>.
+	Resource string `json:"resource"`
+	// Expected is the result declared in the test file.
+	Expected string `json:"expected"`
+	// Actual is the result reported by the engine, empty when no result was found.
+	Actual string `json:"actual,omitempty"`
+	// Status is pass when the actual result matches the expected one, fail otherwise.
+	Status string `json:"status"`
+	// Message is the message of the rule reported by the engine.
+	Message string `json:"message,omitempty"`
+	// Diff is the difference between the expected and the patched or generated resource.
+	Diff string `json:"diff,omitempty"`
+}
+
+// TestSuite groups the test cases of a test file
+type TestSuite struct {
+	Name  string     `json:"name"`
+	Tests []TestCase `json:"tests"`
+}
+
+type testReport struct {
+	Summary  testSummary    `json:"summary"`
+	Suites   []TestSuite    `json:"suites"`
+	Coverage CoverageReport `json:"coverage"`
+}
+
+type testSummary struct {
+	Pass int `json:"pass"`
+	Fail int `json:"fail"`
+	Skip int `json:"skip"`
+}
+
+// testSuites collects the test suites of the test files of a test command
+type testSuites struct {
+	suites []TestSuite
+}
+
+func (s *testSuites) add(suite TestSuite) {
+	s.suites = append(s.suites, suite)
+}
+
+func isValidOutputFormat(format string) bool {
+	return format == "" || format == outputFormatJUnit || format == outputFormatJSON
+}
+
+func newTestCase(policy, rule, namespace, kind, resource string, expected policyreportv1alpha2.PolicyResult, testRes *policyreportv1alpha2.PolicyReportResult) TestCase {
+	testCase := TestCase{
+		Policy:   policy,
+		Rule:     rule,
+		Resource: kind + "/" + resource,
+		Expected: string(expected),
+	}
+	if namespace != "" {
+		testCase.Resource = namespace + "/" + testCase.Resource
+	}
+
+	if testRes == nil {
+		testCase.Status = testCaseFail
+		testCase.Message = "result not found"
+		return testCase
+	}
+
+	testCase.Actual = string(testRes.Result)
+	testCase.Message = strings.TrimSpace(testRes.Message)
+	if testRes.Result == expected {
+		testCase.Status = testCasePass
+	} else {
+		testCase.Status = testCaseFail
+		testCase.Diff = testRes.Properties[diffProperty]
+	}
+	return testCase
+}
+
+func printTestReport(w io.Writer, format string, rc *resultCounts, suites []TestSuite, coverage CoverageReport) error {
+	switch format {
+	case outputFormatJSON:
+		report := testReport{
+			Summary:  testSummary{Pass: rc.Pass, Fail: rc.Fail, Skip: rc.Skip},
+			Suites:   suites,
+			Coverage: coverage,
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case outputFormatJUnit:
+		data, err := xml.MarshalIndent(buildJUnitReport(suites), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, xml.Header+string(data))
+		return err
+	}
+	return fmt.Errorf("unsupported output format %s", format)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+func buildJUnitReport(suites []TestSuite) junitTestSuites {
+	report := junitTestSuites{}
+	for _, suite := range suites {
+		junitSuite := junitTestSuite{
+			Name:  suite.Name,
+			Tests: len(suite.Tests),
+		}
+		for _, test := range suite.Tests {
+			testCase := junitTestCase{
+				Name:      test.Policy + "/" + test.Rule + "/" + test.Resource,
+				Classname: suite.Name,
+			}
+			if test.Status == testCaseFail {
+				junitSuite.Failures++
+				testCase.Failure = &junitFailure{
+					Message:  fmt.Sprintf("expected result %s, received %s", test.Expected, receivedResult(test)),
+					Type:     "ResultMismatch",
+					Contents: failureDetails(test),
+				}
+			}
+			junitSuite.TestCases = append(junitSuite.TestCases, testCase)
+		}
+		report.Tests += junitSuite.Tests
+		report.Failures += junitSuite.Failures
+		report.Suites = append(report.Suites, junitSuite)
+	}
+	return report
+}
+
+func receivedResult(test TestCase) string {
+	if test.Actual == "" {
+		return "no result"
+	}
+	return test.Actual
+}
+
+func failureDetails(test TestCase) string {
+	var bldr strings.Builder
+	if test.Message != "" {
+		fmt.Fprintf(&bldr, "%s\n", test.Message)
+	}
+	if test.Diff != "" {
+		fmt.Fprintf(&bldr, "diff (-expected +actual):\n%s\n", test.Diff)
+	}
+	return bldr.String()
+}
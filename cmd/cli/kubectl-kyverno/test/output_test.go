@@ -0,0 +1,66 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+)
+
+func Test_newTestCase(t *testing.T) {
+	testCase := newTestCase("disallow-latest-tag", "require-image-tag", "default", "Pod", "nginx", policyreportv1alpha2.StatusPass, nil)
+	assert.Equal(t, testCase.Resource, "default/Pod/nginx")
+	assert.Equal(t, testCase.Status, testCaseFail)
+	assert.Equal(t, testCase.Message, "result not found")
+
+	testCase = newTestCase("add-label", "add-label", "", "Pod", "nginx", policyreportv1alpha2.StatusPass, &policyreportv1alpha2.PolicyReportResult{
+		Result:     policyreportv1alpha2.StatusFail,
+		Message:    "mutated resource ",
+		Properties: map[string]string{diffProperty: "-a\n+b"},
+	})
+	assert.Equal(t, testCase.Resource, "Pod/nginx")
+	assert.Equal(t, testCase.Status, testCaseFail)
+	assert.Equal(t, testCase.Actual, "fail")
+	assert.Equal(t, testCase.Message, "mutated resource")
+	assert.Equal(t, testCase.Diff, "-a\n+b")
+
+	testCase = newTestCase("add-label", "add-label", "", "Pod", "nginx", policyreportv1alpha2.StatusSkip, &policyreportv1alpha2.PolicyReportResult{
+		Result: policyreportv1alpha2.StatusSkip,
+	})
+	assert.Equal(t, testCase.Status, testCasePass)
+}
+
+func Test_buildJUnitReport(t *testing.T) {
+	report := buildJUnitReport([]TestSuite{{
+		Name: "test-simple",
+		Tests: []TestCase{
+			{Policy: "disallow-latest-tag", Rule: "require-image-tag", Resource: "Pod/pass", Expected: "pass", Actual: "pass", Status: testCasePass},
+			{Policy: "disallow-latest-tag", Rule: "require-image-tag", Resource: "Pod/fail", Expected: "pass", Actual: "fail", Status: testCaseFail, Message: "validation error"},
+		},
+	}})
+	assert.Equal(t, report.Tests, 2)
+	assert.Equal(t, report.Failures, 1)
+	assert.Equal(t, len(report.Suites), 1)
+	assert.Assert(t, report.Suites[0].TestCases[0].Failure == nil)
+	failure := report.Suites[0].TestCases[1].Failure
+	assert.Assert(t, failure != nil)
+	assert.Equal(t, failure.Message, "expected result pass, received fail")
+	assert.Equal(t, failure.Contents, "validation error\n")
+	assert.Equal(t, report.Suites[0].TestCases[1].Name, "disallow-latest-tag/require-image-tag/Pod/fail")
+}
+
+func Test_printTestReport(t *testing.T) {
+	suites := &testSuites{}
+	suites.add(TestSuite{Name: "test-simple", Tests: []TestCase{{Policy: "p", Rule: "r", Resource: "Pod/a", Expected: "pass", Actual: "pass", Status: testCasePass}}})
+
+	var out bytes.Buffer
+	assert.NilError(t, printTestReport(&out, outputFormatJSON, &resultCounts{Pass: 1}, suites.suites, CoverageReport{}))
+	var report testReport
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, report.Summary.Pass, 1)
+	assert.Equal(t, len(report.Suites), 1)
+
+	assert.ErrorContains(t, printTestReport(&out, "yaml", &resultCounts{}, nil, CoverageReport{}), "unsupported output format")
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/go-git/go-billy/v5"
 	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/google/go-cmp/cmp"
 	"github.com/kataras/tablewriter"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/api/kyverno/v1beta1"
@@ -155,8 +156,9 @@ func Command() *cobra.Command {
 	var cmd *cobra.Command
 	var testCase string
 	var testFile []byte
-	var fileName, gitBranch string
+	var fileName, gitBranch, outputFormat, coverageFile string
 	var registryAccess, failOnly, removeColor bool
+	var minCoverage float64
 	cmd = &cobra.Command{
 		Use: "test <path_to_folder_Containing_test.yamls> [flags]\n  kyverno test <path_to_gitRepository_with_dir> --git-branch <branchName>\n  kyverno test --manifest-mutate > kyverno-test.yaml\n  kyverno test --manifest-validate > kyverno-test.yaml",
 		// Args:    cobra.ExactArgs(1),
@@ -215,7 +217,7 @@ results:
 				return nil
 			}
 			store.SetRegistryAccess(registryAccess)
-			_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, removeColor)
+			_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, removeColor, outputFormat, coverageFile, minCoverage)
 			if err != nil {
 				log.Log.V(3).Info("a directory is required")
 				return err
@@ -232,6 +234,9 @@ results:
 	cmd.Flags().BoolVarP(&registryAccess, "registry", "", false, "If set to true, access the image registry using local docker credentials to populate external data")
 	cmd.Flags().BoolVarP(&failOnly, "fail-only", "", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVarP(&removeColor, "remove-color", "", false, "Remove any color from output")
+	cmd.Flags().StringVarP(&outputFormat, "output-format", "", "", "Print the test results in a machine readable format instead of a table, one of junit or json")
+	cmd.Flags().StringVarP(&coverageFile, "coverage-file", "", "", "Write the coverage of the policy rules by the test results to a JSON file")
+	cmd.Flags().Float64VarP(&minCoverage, "min-coverage", "", 0, "Fail the tests if the percentage of policy rules covered by the test results is below this threshold")
 	return cmd
 }
 
@@ -318,7 +323,7 @@ type testFilter struct {
 
 var ftable = []Table{}
 
-func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool) (rc *resultCounts, err error) {
+func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool, outputFormat string, coverageFile string, minCoverage float64) (rc *resultCounts, err error) {
 	var errors []error
 	fs := memfs.New()
 	rc = &resultCounts{}
@@ -331,12 +336,29 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 		return rc, sanitizederror.NewWithError("a directory is required", err)
 	}
 
+	if !isValidOutputFormat(outputFormat) {
+		return rc, sanitizederror.New(fmt.Sprintf("invalid output format %s, supported formats are junit and json", outputFormat))
+	}
+
+	if minCoverage < 0 || minCoverage > 100 {
+		return rc, sanitizederror.New(fmt.Sprintf("invalid minimum coverage %v, expected a percentage between 0 and 100", minCoverage))
+	}
+
+	testCoverage := newCoverage()
+	suites := &testSuites{}
+	// the report is the only content printed to stdout when an output format is set,
+	// progress and diagnostics are printed to stderr instead
+	var out io.Writer = os.Stdout
+	if outputFormat != "" {
+		out = os.Stderr
+	}
+
 	if len(testCase) != 0 {
 		parameters := map[string]string{"policy": "", "rule": "", "resource": ""}
 
 		for _, t := range strings.Split(testCase, ",") {
 			if !strings.Contains(t, "=") {
-				fmt.Printf("\n Invalid test-case-selector argument. Selecting all test cases. \n")
+				fmt.Fprintf(out, "\n Invalid test-case-selector argument. Selecting all test cases. \n")
 				tf.enabled = false
 				break
 			}
@@ -346,7 +368,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 			_, ok := parameters[key]
 			if !ok {
-				fmt.Printf("\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
+				fmt.Fprintf(out, "\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
 				tf.enabled = false
 				break
 			}
@@ -374,7 +396,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 		pathElems := strings.Split(gitURL.Path[1:], "/")
 		if len(pathElems) <= 1 {
 			err := fmt.Errorf("invalid URL path %s - expected https://github.com/:owner/:repository/:branch (without --git-branch flag) OR https://github.com/:owner/:repository/:directory (with --git-branch flag)", gitURL.Path)
-			fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to parse URL \nCause: %s\n", err)
 			os.Exit(1)
 		}
 
@@ -406,7 +428,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 		_, cloneErr := clone(repoURL, fs, gitBranch)
 		if cloneErr != nil {
-			fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+			fmt.Fprintf(out, "Error: failed to clone repository \nCause: %s\n", cloneErr)
 			log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 			os.Exit(1)
 		}
@@ -438,48 +460,78 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 					errors = append(errors, sanitizederror.NewWithError("failed to convert to JSON", err))
 					continue
 				}
-				if err := applyPoliciesFromPath(fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor); err != nil {
+				if err := applyPoliciesFromPath(out, testCoverage, suites, fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor, outputFormat); err != nil {
 					return rc, sanitizederror.NewWithError("failed to apply test command", err)
 				}
 			}
 		}
 
 		if testYamlCount == 0 {
-			fmt.Printf("\n No test yamls available \n")
+			fmt.Fprintf(out, "\n No test yamls available \n")
 		}
 	} else {
 		var testFiles int
 		path := filepath.Clean(dirPath[0])
-		errors = getLocalDirTestFiles(fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor)
+		errors = getLocalDirTestFiles(out, testCoverage, suites, fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor, outputFormat)
 
 		if testFiles == 0 {
-			fmt.Printf("\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
+			fmt.Fprintf(out, "\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
 		}
 	}
 
 	if len(errors) > 0 && log.Log.V(1).Enabled() {
-		fmt.Printf("test errors: \n")
+		fmt.Fprintf(out, "test errors: \n")
 		for _, e := range errors {
-			fmt.Printf("    %v \n", e.Error())
+			fmt.Fprintf(out, "    %v \n", e.Error())
+		}
+	}
+
+	coverage := testCoverage.report()
+	if coverageFile != "" {
+		if err := writeCoverageFile(coverageFile, coverage); err != nil {
+			return rc, sanitizederror.NewWithError("failed to write coverage file", err)
+		}
+	}
+	belowMinCoverage := minCoverage > 0 && coverage.Rules.Percentage < minCoverage
+
+	if outputFormat != "" {
+		printCoverageSummary(out, coverage)
+		if err := printTestReport(os.Stdout, outputFormat, rc, suites.suites, coverage); err != nil {
+			return rc, sanitizederror.NewWithError("failed to print test report", err)
 		}
+		if belowMinCoverage {
+			fmt.Fprintf(out, "Test coverage of %.2f%% is below the minimum of %.2f%%\n", coverage.Rules.Percentage, minCoverage)
+		}
+		if rc.Fail > 0 || belowMinCoverage {
+			os.Exit(1)
+		}
+		os.Exit(0)
 	}
 
 	if !failOnly {
-		fmt.Printf("\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
 	} else {
-		fmt.Printf("\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+	}
+	printCoverageSummary(out, coverage)
+	fmt.Fprintf(out, "\n")
+
+	if belowMinCoverage {
+		fmt.Fprintf(out, "Test coverage of %.2f%% is below the minimum of %.2f%%\n\n", coverage.Rules.Percentage, minCoverage)
+		if rc.Fail == 0 || failOnly {
+			os.Exit(1)
+		}
 	}
-	fmt.Printf("\n")
 
 	if rc.Fail > 0 && !failOnly {
-		printFailedTestResult()
+		printFailedTestResult(out)
 		os.Exit(1)
 	}
 	os.Exit(0)
 	return rc, nil
 }
 
-func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) []error {
+func getLocalDirTestFiles(out io.Writer, testCoverage *coverage, suites *testSuites, fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool, outputFormat string) []error {
 	var errors []error
 
 	files, err := os.ReadDir(path)
@@ -488,7 +540,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 	}
 	for _, file := range files {
 		if file.IsDir() {
-			getLocalDirTestFiles(fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor)
+			getLocalDirTestFiles(out, testCoverage, suites, fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor, outputFormat)
 			continue
 		}
 		if file.Name() == fileName {
@@ -504,7 +556,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 				errors = append(errors, sanitizederror.NewWithError("failed to convert json", err))
 				continue
 			}
-			if err := applyPoliciesFromPath(fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor); err != nil {
+			if err := applyPoliciesFromPath(out, testCoverage, suites, fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor, outputFormat); err != nil {
 				errors = append(errors, sanitizederror.NewWithError(fmt.Sprintf("failed to apply test command from file %s", file.Name()), err))
 				continue
 			}
@@ -513,7 +565,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 	return errors
 }
 
-func buildPolicyResults(engineResponses []*response.EngineResponse, testResults []TestResults, infos []common.Info, policyResourcePath string, fs billy.Filesystem, isGit bool) (map[string]policyreportv1alpha2.PolicyReportResult, []TestResults) {
+func buildPolicyResults(out io.Writer, engineResponses []*response.EngineResponse, testResults []TestResults, infos []common.Info, policyResourcePath string, fs billy.Filesystem, isGit bool) (map[string]policyreportv1alpha2.PolicyReportResult, []TestResults) {
 	results := make(map[string]policyreportv1alpha2.PolicyReportResult)
 	now := metav1.Timestamp{Seconds: time.Now().Unix()}
 
@@ -646,16 +698,19 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 
 					if rule.Status == response.RuleStatusSkip {
 						result.Result = policyreportv1alpha2.StatusSkip
-					} else if rule.Status == response.RuleStatusError {
+					} else if rule.Status == response.RuleStatusError || rule.Status == response.RuleStatusTimeout {
 						result.Result = policyreportv1alpha2.StatusError
 					} else {
-						var x string
+						var x, diff string
 						result.Result = policyreportv1alpha2.StatusFail
-						x = getAndCompareResource(test.GeneratedResource, rule.GeneratedResource, isGit, policyResourcePath, fs, true)
+						x, diff = getAndCompareResource(out, test.GeneratedResource, rule.GeneratedResource, isGit, policyResourcePath, fs, true)
 						if x == "pass" {
 							result.Result = policyreportv1alpha2.StatusPass
+						} else if diff != "" {
+							result.Properties = map[string]string{diffProperty: diff}
 						}
 					}
+					result.Message = rule.Message
 					results[resultKey] = result
 				}
 			}
@@ -680,20 +735,25 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 
 				if rule.Status == response.RuleStatusSkip {
 					result.Result = policyreportv1alpha2.StatusSkip
-				} else if rule.Status == response.RuleStatusError {
+				} else if rule.Status == response.RuleStatusError || rule.Status == response.RuleStatusTimeout {
 					result.Result = policyreportv1alpha2.StatusError
 				} else {
-					var x string
+					var x, diff string
 					for _, path := range patchedResourcePath {
 						result.Result = policyreportv1alpha2.StatusFail
-						x = getAndCompareResource(path, resp.PatchedResource, isGit, policyResourcePath, fs, false)
+						x, diff = getAndCompareResource(out, path, resp.PatchedResource, isGit, policyResourcePath, fs, false)
 						if x == "pass" {
 							result.Result = policyreportv1alpha2.StatusPass
+							result.Properties = nil
 							break
 						}
+						if diff != "" {
+							result.Properties = map[string]string{diffProperty: diff}
+						}
 					}
 				}
 
+				result.Message = rule.Message
 				results[resultKey] = result
 			}
 		}
@@ -721,6 +781,7 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 
 				result.Rule = rule.Name
 				result.Result = policyreportv1alpha2.PolicyResult(rule.Status)
+				result.Message = rule.Message
 				result.Source = kyvernov1.ValueKyvernoApp
 				result.Timestamp = now
 				results[resultKey] = result
@@ -770,9 +831,10 @@ func getUserDefinedPolicyNameAndNamespace(policyName string) (string, string) {
 }
 
 // getAndCompareResource --> Get the patchedResource or generatedResource from the path provided by user
-// And compare this resource with engine generated resource.
-func getAndCompareResource(path string, engineResource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, isGenerate bool) string {
-	var status string
+// And compare this resource with engine generated resource. The difference between both resources is
+// returned when they don't match.
+func getAndCompareResource(out io.Writer, path string, engineResource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, isGenerate bool) (string, string) {
+	var status, diff string
 	resourceType := "patchedResource"
 	if isGenerate {
 		resourceType = "generatedResource"
@@ -780,17 +842,18 @@ func getAndCompareResource(path string, engineResource unstructured.Unstructured
 
 	userResource, err := common.GetResourceFromPath(fs, path, isGit, policyResourcePath, resourceType)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
-		return ""
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
+		return "", ""
 	}
 	matched, err := generate.ValidateResourceWithPattern(log.Log, engineResource.UnstructuredContent(), userResource.UnstructuredContent())
 	if err != nil {
 		log.Log.V(3).Info(resourceType+" mismatch", "error", err.Error())
 		status = "fail"
+		diff = cmp.Diff(userResource.UnstructuredContent(), engineResource.UnstructuredContent())
 	} else if matched == "" {
 		status = "pass"
 	}
-	return status
+	return status, diff
 }
 
 func buildMessage(resp *response.EngineResponse) string {
@@ -816,7 +879,7 @@ func getFullPath(paths []string, policyResourcePath string, isGit bool) []string
 	return paths
 }
 
-func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) (err error) {
+func applyPoliciesFromPath(out io.Writer, testCoverage *coverage, suites *testSuites, fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool, outputFormat string) (err error) {
 	engineResponses := make([]*response.EngineResponse, 0)
 	var dClient dclient.Interface
 	values := &Test{}
@@ -842,11 +905,11 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		return nil
 	}
 
-	fmt.Printf("\nExecuting %s...", values.Name)
+	fmt.Fprintf(out, "\nExecuting %s...", values.Name)
 	valuesFile := values.Variables
 	userInfoFile := values.UserInfo
 
-	variables, globalValMap, valuesMap, namespaceSelectorMap, err := common.GetVariable(variablesString, values.Variables, fs, isGit, policyResourcePath)
+	variables, globalValMap, valuesMap, namespaceSelectorMap, err := common.GetVariable(out, variablesString, values.Variables, fs, isGit, policyResourcePath)
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
 			return sanitizederror.NewWithError("failed to decode yaml", err)
@@ -861,7 +924,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	if userInfoFile != "" {
 		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, userInfoFile, isGit, policyResourcePath)
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to load request info\nCause: %s\n", err)
 			os.Exit(1)
 		}
 		store.SetSubjects(subjectInfo)
@@ -886,9 +949,10 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 
 	policies, err := common.GetPoliciesFromPaths(fs, policyFullPath, isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
 		os.Exit(1)
 	}
+	testCoverage.addPolicies(policies, values.Results)
 
 	filteredPolicies := []kyvernov1.PolicyInterface{}
 	for _, p := range policies {
@@ -911,13 +975,13 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 					if rule.HasGenerate() {
 						ruleUnstr, err := generate.GetUnstrRule(rule.Generation.DeepCopy())
 						if err != nil {
-							fmt.Printf("Error: failed to get unstructured rule\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to get unstructured rule\nCause: %s\n", err)
 							break
 						}
 
 						genClone, _, err := unstructured.NestedMap(ruleUnstr.Object, "clone")
 						if err != nil {
-							fmt.Printf("Error: failed to read data\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to read data\nCause: %s\n", err)
 							break
 						}
 
@@ -940,7 +1004,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 
 	resources, err := common.GetResourceAccordingToResourcePath(fs, resourceFullPath, false, policies, dClient, "", false, isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
@@ -971,7 +1035,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	}
 
 	if len(policies) > 0 && len(resources) > 0 {
-		fmt.Printf("\napplying %s to %s... \n", msgPolicies, msgResources)
+		fmt.Fprintf(out, "\napplying %s to %s... \n", msgPolicies, msgResources)
 	}
 
 	for _, policy := range policies {
@@ -988,7 +1052,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			if len(variables) == 0 {
 				// check policy in variable file
 				if valuesFile == "" || valuesMap[policy.GetName()] == nil {
-					fmt.Printf("test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
+					fmt.Fprintf(out, "test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
 				}
 			}
 		}
@@ -1011,6 +1075,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 				Rc:                        &resultCounts,
 				RuleToCloneSourceResource: ruleToCloneSourceResource,
 				Client:                    dClient,
+				Out:                       out,
 			}
 			ers, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
@@ -1020,8 +1085,9 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			pvInfos = append(pvInfos, info)
 		}
 	}
-	resultsMap, testResults := buildPolicyResults(engineResponses, values.Results, pvInfos, policyResourcePath, fs, isGit)
-	resultErr := printTestResult(resultsMap, testResults, rc, failOnly, removeColor)
+	testCoverage.addEngineResponses(engineResponses)
+	resultsMap, testResults := buildPolicyResults(out, engineResponses, values.Results, pvInfos, policyResourcePath, fs, isGit)
+	resultErr := printTestResult(out, suites, resultsMap, testResults, values.Name, rc, failOnly, removeColor, outputFormat)
 	if resultErr != nil {
 		return sanitizederror.NewWithError("failed to print test result:", resultErr)
 	}
@@ -1029,8 +1095,8 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	return
 }
 
-func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []TestResults, rc *resultCounts, failOnly, removeColor bool) error {
-	printer := tableprinter.New(os.Stdout)
+func printTestResult(out io.Writer, suites *testSuites, resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []TestResults, testName string, rc *resultCounts, failOnly, removeColor bool, outputFormat string) error {
+	printer := tableprinter.New(out)
 	table := []Table{}
 	boldGreen := color.New(color.FgGreen).Add(color.Bold)
 	boldRed := color.New(color.FgRed).Add(color.Bold)
@@ -1039,7 +1105,13 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 
 	var countDeprecatedResource int
 	testCount := 1
+	suite := TestSuite{Name: testName}
 	for _, v := range testResults {
+		policyName := v.Policy
+		expected := v.Result
+		if expected == "" && v.Status != "" {
+			expected = v.Status
+		}
 		res := new(Table)
 		res.ID = testCount
 		if v.Resources == nil {
@@ -1106,8 +1178,10 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 					rc.Fail++
 					table = append(table, *res)
 					ftable = append(ftable, *res)
+					suite.Tests = append(suite.Tests, newTestCase(policyName, v.Rule, v.Namespace, v.Kind, resource, expected, nil))
 					continue
 				}
+				suite.Tests = append(suite.Tests, newTestCase(policyName, v.Rule, v.Namespace, v.Kind, resource, expected, &testRes))
 
 				if v.Result == "" && v.Status != "" {
 					v.Result = v.Status
@@ -1194,8 +1268,10 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				rc.Fail++
 				table = append(table, *res)
 				ftable = append(ftable, *res)
+				suite.Tests = append(suite.Tests, newTestCase(policyName, v.Rule, v.Namespace, v.Kind, v.Resource, expected, nil))
 				continue
 			}
+			suite.Tests = append(suite.Tests, newTestCase(policyName, v.Rule, v.Namespace, v.Kind, v.Resource, expected, &testRes))
 
 			if v.Result == "" && v.Status != "" {
 				v.Result = v.Status
@@ -1233,6 +1309,11 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 		}
 	}
 
+	suites.add(suite)
+	if outputFormat != "" {
+		return nil
+	}
+
 	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = true, true, true, true
 	printer.CenterSeparator = "│"
 	printer.ColumnSeparator = "│"
@@ -1245,17 +1326,17 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 		printer.HeaderBgColor = tablewriter.BgBlackColor
 		printer.HeaderFgColor = tablewriter.FgGreenColor
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 	printer.Print(table)
 	return nil
 }
 
-func printFailedTestResult() {
-	printer := tableprinter.New(os.Stdout)
+func printFailedTestResult(out io.Writer) {
+	printer := tableprinter.New(out)
 	for i, v := range ftable {
 		v.ID = i + 1
 	}
-	fmt.Printf("Aggregated Failed Test Cases : ")
+	fmt.Fprintf(out, "Aggregated Failed Test Cases : ")
 	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = true, true, true, true
 	printer.CenterSeparator = "│"
 	printer.ColumnSeparator = "│"
@@ -1267,6 +1348,6 @@ func printFailedTestResult() {
 
 	printer.HeaderBgColor = tablewriter.BgBlackColor
 	printer.HeaderFgColor = tablewriter.FgGreenColor
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 	printer.Print(ftable)
 }
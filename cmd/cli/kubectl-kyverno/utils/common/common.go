@@ -83,6 +83,15 @@ type ApplyPolicyConfig struct {
 	PrintPatchResource        bool
 	RuleToCloneSourceResource map[string]string
 	Client                    dclient.Interface
+	// Out receives the messages printed while applying the policy, defaults to stdout
+	Out io.Writer
+}
+
+func (c ApplyPolicyConfig) out() io.Writer {
+	if c.Out == nil {
+		return os.Stdout
+	}
+	return c.Out
 }
 
 // HasVariables - check for variables in the policy
@@ -213,7 +222,7 @@ func RemoveDuplicateAndObjectVariables(matches [][]string) string {
 	return variableStr
 }
 
-func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit bool, policyResourcePath string) (map[string]string, map[string]string, map[string]map[string]Resource, map[string]map[string]string, error) {
+func GetVariable(out io.Writer, variablesString, valuesFile string, fs billy.Filesystem, isGit bool, policyResourcePath string) (map[string]string, map[string]string, map[string]map[string]Resource, map[string]map[string]string, error) {
 	valuesMapResource := make(map[string]map[string]Resource)
 	valuesMapRule := make(map[string]map[string]Rule)
 	namespaceSelectorMap := make(map[string]map[string]string)
@@ -322,7 +331,7 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 	}
 
 	if reqObjVars != "" {
-		fmt.Printf(("\nNOTICE: request.object.* variables are automatically parsed from the supplied resource. Ignoring value of variables `%v`.\n"), reqObjVars)
+		fmt.Fprintf(out, ("\nNOTICE: request.object.* variables are automatically parsed from the supplied resource. Ignoring value of variables `%v`.\n"), reqObjVars)
 	}
 
 	if globalValMap != nil {
@@ -513,7 +522,7 @@ OuterLoop:
 		}
 		generateResponse := engine.ApplyBackgroundChecks(policyContext)
 		if generateResponse != nil && !generateResponse.IsEmpty() {
-			newRuleResponse, err := handleGeneratePolicy(generateResponse, *policyContext, c.RuleToCloneSourceResource)
+			newRuleResponse, err := handleGeneratePolicy(c.out(), generateResponse, *policyContext, c.RuleToCloneSourceResource)
 			if err != nil {
 				log.Log.Error(err, "failed to apply generate policy")
 			} else {
@@ -717,7 +726,7 @@ func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateRes
 						fmt.Printf("%d. %s: %s \n", i+1, valResponseRule.Name, valResponseRule.Message)
 					}
 
-				case response.RuleStatusError:
+				case response.RuleStatusError, response.RuleStatusTimeout:
 					rc.Error++
 					vrule.Status = policyreportv1alpha2.StatusError
 
@@ -847,17 +856,17 @@ func processMutateEngineResponse(c ApplyPolicyConfig, mutateResponse *response.E
 					c.Rc.Pass++
 					printMutatedRes = true
 				} else if mutateResponseRule.Status == response.RuleStatusSkip {
-					fmt.Printf("\nskipped mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
+					fmt.Fprintf(c.out(), "\nskipped mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
 					c.Rc.Skip++
-				} else if mutateResponseRule.Status == response.RuleStatusError {
-					fmt.Printf("\nerror while applying mutate policy %s -> resource %s\nerror: %s", c.Policy.GetName(), resPath, mutateResponseRule.Message)
+				} else if mutateResponseRule.Status == response.RuleStatusError || mutateResponseRule.Status == response.RuleStatusTimeout {
+					fmt.Fprintf(c.out(), "\nerror while applying mutate policy %s -> resource %s\nerror: %s", c.Policy.GetName(), resPath, mutateResponseRule.Message)
 					c.Rc.Error++
 				} else {
 					if printCount < 1 {
-						fmt.Printf("\nfailed to apply mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
+						fmt.Fprintf(c.out(), "\nfailed to apply mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s \n", i+1, mutateResponseRule.Name, mutateResponseRule.Message)
+					fmt.Fprintf(c.out(), "%d. %s - %s \n", i+1, mutateResponseRule.Name, mutateResponseRule.Message)
 					c.Rc.Fail++
 				}
 				continue
@@ -878,16 +887,16 @@ func processMutateEngineResponse(c ApplyPolicyConfig, mutateResponse *response.E
 			mutatedResource := string(yamlEncodedResource) + string("\n---")
 			if len(strings.TrimSpace(mutatedResource)) > 0 {
 				if !c.Stdin {
-					fmt.Printf("\nmutate policy %s applied to %s:", c.Policy.GetName(), resPath)
+					fmt.Fprintf(c.out(), "\nmutate policy %s applied to %s:", c.Policy.GetName(), resPath)
 				}
-				fmt.Printf("\n" + mutatedResource + "\n")
+				fmt.Fprintf(c.out(), "\n"+mutatedResource+"\n")
 			}
 		} else {
 			err := PrintMutatedOutput(c.MutateLogPath, c.MutateLogPathIsDir, string(yamlEncodedResource), c.Resource.GetName()+"-mutated")
 			if err != nil {
 				return sanitizederror.NewWithError("failed to print mutated result", err)
 			}
-			fmt.Printf("\n\nMutation:\nMutation has been applied successfully. Check the files.")
+			fmt.Fprintf(c.out(), "\n\nMutation:\nMutation has been applied successfully. Check the files.")
 		}
 	}
 
@@ -992,18 +1001,18 @@ func initializeMockController(objects []runtime.Object) (*generate.GenerateContr
 }
 
 // handleGeneratePolicy returns a new RuleResponse with the Kyverno generated resource configuration by applying the generate rule.
-func handleGeneratePolicy(generateResponse *response.EngineResponse, policyContext engine.PolicyContext, ruleToCloneSourceResource map[string]string) ([]response.RuleResponse, error) {
+func handleGeneratePolicy(out io.Writer, generateResponse *response.EngineResponse, policyContext engine.PolicyContext, ruleToCloneSourceResource map[string]string) ([]response.RuleResponse, error) {
 	objects := []runtime.Object{&policyContext.NewResource}
 	resources := []*unstructured.Unstructured{}
 	for _, rule := range generateResponse.PolicyResponse.Rules {
 		if path, ok := ruleToCloneSourceResource[rule.Name]; ok {
 			resourceBytes, err := getFileBytes(path)
 			if err != nil {
-				fmt.Printf("failed to get resource bytes\n")
+				fmt.Fprintf(out, "failed to get resource bytes\n")
 			} else {
 				resources, err = GetResource(resourceBytes)
 				if err != nil {
-					fmt.Printf("failed to convert resource bytes to unstructured format\n")
+					fmt.Fprintf(out, "failed to convert resource bytes to unstructured format\n")
 				}
 			}
 		}
@@ -1015,7 +1024,7 @@ func handleGeneratePolicy(generateResponse *response.EngineResponse, policyConte
 
 	c, err := initializeMockController(objects)
 	if err != nil {
-		fmt.Println("error at controller")
+		fmt.Fprintln(out, "error at controller")
 		return nil, err
 	}
 
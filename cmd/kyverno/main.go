@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/background"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
@@ -36,6 +37,10 @@ import (
 	resourcereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
 	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/admissioncache"
+	"github.com/kyverno/kyverno/pkg/engine/contextcache"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	event "github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -49,6 +54,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/utils"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/webhooks"
+	webhooksexception "github.com/kyverno/kyverno/pkg/webhooks/exception"
+	webhookshandlers "github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
@@ -63,6 +70,8 @@ import (
 const (
 	resyncPeriod         = 15 * time.Minute
 	metadataResyncPeriod = 15 * time.Minute
+	// auditQueueSize is the number of audit events queued for the http audit sink
+	auditQueueSize = 1000
 )
 
 var (
@@ -91,6 +100,27 @@ var (
 	backgroundScanWorkers      int
 	dumpPayload                bool
 	leaderElectionRetryPeriod  time.Duration
+	contextCacheMaxSize        int
+	imageVerifyCacheMaxSize    int
+	imageVerifyCacheTTL        time.Duration
+	admissionCacheTTL          time.Duration
+	maxInFlightAdmissions      int
+	admissionQPS               float64
+	admissionBurst             int
+	admissionSheddingMode      string
+	auditSink                  string
+	auditIncludeRequest        bool
+	exceptionNamespace         string
+	generateDriftInterval      time.Duration
+	generateDriftAutoHeal      bool
+	urGCInterval               time.Duration
+	urRetentionPending         time.Duration
+	urRetentionFailed          time.Duration
+	urRetentionCompleted       time.Duration
+	urRetentionSkip            time.Duration
+	maxPendingURsPerPolicy     int
+	shardUpdateRequests        bool
+	webhookPerPolicy           bool
 	// DEPRECATED: remove in 1.9
 	splitPolicyReport bool
 )
@@ -122,6 +152,27 @@ func parseFlags(config internal.Configuration) {
 	flag.IntVar(&reportsChunkSize, "reportsChunkSize", 1000, "Max number of results in generated reports, reports will be split accordingly if there are more results to be stored.")
 	flag.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flag.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
+	flag.IntVar(&contextCacheMaxSize, "contextCacheMaxSize", contextcache.DefaultMaxSize, "Configure the maximum number of entries in the cache shared by apiCall and imageRegistry context entries.")
+	flag.IntVar(&imageVerifyCacheMaxSize, "imageVerifyCacheMaxSize", imageverifycache.DefaultMaxSize, "Configure the maximum number of image verification results stored in the cache.")
+	flag.DurationVar(&imageVerifyCacheTTL, "imageVerifyCacheTTL", imageverifycache.DefaultTTL, "Configure how long image verification results are cached for an image digest, set to 0 to disable the cache.")
+	flag.DurationVar(&admissionCacheTTL, "admissionCacheTTL", admissioncache.DefaultTTL, "Configure how long the namespace labels and RBAC bindings resolved for an admission request are shared by its mutating and validating webhook calls, set to 0 to disable sharing.")
+	flag.IntVar(&maxInFlightAdmissions, "maxInFlightAdmissionRequests", 0, "Configure the maximum number of resource admission requests processed concurrently, requests above the limit are shed, set to 0 for no limit.")
+	flag.Float64Var(&admissionQPS, "admissionRequestsQPS", 0, "Configure the maximum rate of resource admission requests per kind and namespace, requests above the rate are shed, set to 0 for no limit. Each webhook call (mutate, validate and reinvocation) of a request consumes a token, requests filtered by the configuration don't.")
+	flag.IntVar(&admissionBurst, "admissionRequestsBurst", 100, "Configure the maximum burst of resource admission requests per kind and namespace when admissionRequestsQPS is set.")
+	flag.StringVar(&admissionSheddingMode, "admissionSheddingMode", string(webhookshandlers.SheddingFailurePolicy), "Configure the response to shed admission requests: 'failurePolicy' rejects the requests of webhooks failing closed and admits the others, 'fail' rejects them and 'allow' admits them.")
+	flag.StringVar(&auditSink, "auditSink", "", "Configure where admission decisions are audited as JSON: 'stdout', a file path or an http(s) URL receiving one POST per decision, leave empty to disable the audit.")
+	flag.BoolVar(&auditIncludeRequest, "auditIncludeRequest", false, "Set this flag to 'true' to add the admission request payload to the audit events, secret data is redacted.")
+	flag.StringVar(&exceptionNamespace, "exceptionNamespace", "", "Configure the namespace of the policy exceptions applying to resources of all namespaces, exceptions created in other namespaces only apply to resources of their own namespace.")
+	flag.DurationVar(&generateDriftInterval, "generateDriftInterval", 0, "Configure how often synchronized generated resources are compared with their desired state to detect drift, set to 0 to disable drift detection.")
+	flag.BoolVar(&generateDriftAutoHeal, "generateDriftAutoHeal", false, "Set this flag to 'true' to update generated resources when drift is detected and recreate the deleted ones.")
+	flag.DurationVar(&urGCInterval, "updateRequestGCInterval", 10*time.Minute, "Configure how often update requests are garbage collected, set to 0 to disable garbage collection.")
+	flag.DurationVar(&urRetentionPending, "updateRequestRetentionPending", 0, "Configure the maximum age of pending update requests, set to 0 to keep them.")
+	flag.DurationVar(&urRetentionFailed, "updateRequestRetentionFailed", 0, "Configure the maximum age of failed update requests, set to 0 to keep them.")
+	flag.DurationVar(&urRetentionCompleted, "updateRequestRetentionCompleted", 0, "Configure the maximum age of completed update requests, set to 0 to keep them.")
+	flag.DurationVar(&urRetentionSkip, "updateRequestRetentionSkip", 24*time.Hour, "Configure the maximum age of skipped update requests, set to 0 to keep them.")
+	flag.BoolVar(&shardUpdateRequests, "shardUpdateRequests", false, "Set this flag to 'true' to distribute update requests across the Kyverno replicas with consistent hashing, each replica only processes the requests of its shard.")
+	flag.BoolVar(&webhookPerPolicy, "webhookPerPolicy", false, "Set this flag to 'true' to register one resource webhook per policy, or per policy group set with the webhooks.kyverno.io/group label, with its own failure policy and timeout.")
+	flag.IntVar(&maxPendingURsPerPolicy, "maxPendingUpdateRequestsPerPolicy", 0, "Configure the maximum number of pending update requests per policy, the most recent requests above the limit are deleted and reported with a PolicyError event on the policy and the kyverno_update_request_drops_total metric, set to 0 for no limit.")
 	// DEPRECATED: remove in 1.9
 	flag.BoolVar(&splitPolicyReport, "splitPolicyReport", false, "This is deprecated, please don't use it, will be removed in v1.9.")
 	flag.Parse()
@@ -244,6 +295,48 @@ func setupCosign(logger logr.Logger) {
 	}
 }
 
+func setupContextCache(logger logr.Logger, metricsConfig *metrics.MetricsConfig) {
+	logger = logger.WithName("context-cache")
+	logger.Info("setup context cache...", "maxSize", contextCacheMaxSize)
+	contextcache.DefaultCache = contextcache.NewCache(contextCacheMaxSize, metricsConfig)
+}
+
+func setupImageVerifyCache(logger logr.Logger) {
+	logger = logger.WithName("image-verify-cache")
+	logger.Info("setup image verify cache...", "maxSize", imageVerifyCacheMaxSize, "ttl", imageVerifyCacheTTL)
+	imageverifycache.DefaultCache = imageverifycache.NewCache(imageVerifyCacheMaxSize, imageVerifyCacheTTL)
+}
+
+func setupAdmissionCache(logger logr.Logger) {
+	logger = logger.WithName("admission-cache")
+	logger.Info("setup admission cache...", "maxSize", admissioncache.DefaultMaxSize, "ttl", admissionCacheTTL)
+	admissioncache.DefaultCache = admissioncache.NewCache(admissioncache.DefaultMaxSize, admissionCacheTTL)
+}
+
+func setupAuditSink(ctx context.Context, logger logr.Logger) (webhookshandlers.AuditSink, func(), error) {
+	logger = logger.WithName("audit-sink")
+	if auditSink == "" {
+		return nil, func() {}, nil
+	}
+	logger.Info("setup audit sink...", "sink", auditSink, "includeRequest", auditIncludeRequest)
+	if auditSink == "stdout" {
+		return webhookshandlers.NewJSONAuditSink(os.Stdout), func() {}, nil
+	}
+	if strings.HasPrefix(auditSink, "http://") || strings.HasPrefix(auditSink, "https://") {
+		sink := webhookshandlers.NewHTTPAuditSink(auditSink, nil)
+		return webhookshandlers.NewAsyncAuditSink(ctx, logger, sink, auditQueueSize), func() {}, nil
+	}
+	file, err := os.OpenFile(auditSink, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, nil, err
+	}
+	return webhookshandlers.NewJSONAuditSink(file), func() {
+		if err := file.Close(); err != nil {
+			logger.Error(err, "failed to close audit file")
+		}
+	}, nil
+}
+
 func setupSignals() (context.Context, context.CancelFunc) {
 	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 }
@@ -271,6 +364,8 @@ func createNonLeaderControllers(
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kubeKyvernoInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
+	triggerInformer metadatainformers.SharedInformerFactory,
+	secretInformer metadatainformers.SharedInformerFactory,
 	kubeClient kubernetes.Interface,
 	kyvernoClient versioned.Interface,
 	dynamicClient dclient.Interface,
@@ -278,11 +373,19 @@ func createNonLeaderControllers(
 	policyCache policycache.Cache,
 	eventGenerator event.Interface,
 	manager openapi.Manager,
+	metricsConfig *metrics.MetricsConfig,
 ) ([]controller, func() error) {
+	// cached image verification results are invalidated when the secrets holding the public keys change
+	var secretGenericInformer kubeinformers.GenericInformer
+	if imageverifycache.DefaultCache.Enabled() {
+		secretGenericInformer = secretInformer.ForResource(corev1.SchemeGroupVersion.WithResource("secrets"))
+	}
 	policyCacheController := policycachecontroller.NewController(
 		policyCache,
+		imageverifycache.DefaultCache,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
+		secretGenericInformer,
 	)
 	openApiController := openapicontroller.NewController(
 		dynamicClient,
@@ -300,8 +403,14 @@ func createNonLeaderControllers(
 		kyvernoInformer.Kyverno().V1beta1().UpdateRequests(),
 		kubeInformer.Core().V1().Namespaces(),
 		kubeKyvernoInformer.Core().V1().Pods(),
+		triggerInformer,
 		eventGenerator,
 		configuration,
+		metricsConfig,
+		generateDriftInterval,
+		generateDriftAutoHeal,
+		admissionReports,
+		shardUpdateRequests,
 	)
 	return []controller{
 			newController(policycachecontroller.ControllerName, policyCacheController, policycachecontroller.Workers),
@@ -369,6 +478,7 @@ func createReportControllers(
 					kyvernoV1.Policies(),
 					kyvernoV1.ClusterPolicies(),
 					kubeInformer.Core().V1().Namespaces(),
+					kyvernoInformer.Kyverno().V1alpha1().PolicyExceptions(),
 					resourceReportController,
 				),
 				backgroundScanWorkers,
@@ -408,6 +518,24 @@ func createrLeaderControllers(
 	if err != nil {
 		return nil, err
 	}
+	updateRequestGC := background.NewGarbageCollector(
+		kyvernoClient,
+		kyvernoInformer.Kyverno().V1beta1().UpdateRequests(),
+		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+		kyvernoInformer.Kyverno().V1().Policies(),
+		eventGenerator,
+		metricsConfig,
+		background.GCOptions{
+			Interval: urGCInterval,
+			Retention: map[kyvernov1beta1.UpdateRequestState]time.Duration{
+				kyvernov1beta1.Pending:   urRetentionPending,
+				kyvernov1beta1.Failed:    urRetentionFailed,
+				kyvernov1beta1.Completed: urRetentionCompleted,
+				kyvernov1beta1.Skip:      urRetentionSkip,
+			},
+			MaxPendingPerPolicy: maxPendingURsPerPolicy,
+		},
+	)
 	certManager := certmanager.NewController(
 		kubeKyvernoInformer.Core().V1().Secrets(),
 		certRenewer,
@@ -430,11 +558,13 @@ func createrLeaderControllers(
 		int32(webhookTimeout),
 		autoUpdateWebhooks,
 		admissionReports,
+		webhookPerPolicy,
 		runtime,
 	)
 	return append(
 			[]controller{
 				newController("policy-controller", policyCtrl, 2),
+				newController("update-request-gc", updateRequestGC, 1),
 				newController(certmanager.ControllerName, certManager, certmanager.Workers),
 				newController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers),
 			},
@@ -501,6 +631,27 @@ func main() {
 	}
 	// setup cosign
 	setupCosign(logger)
+	// setup context cache
+	setupContextCache(logger, metricsConfig)
+	// setup image verify cache
+	setupImageVerifyCache(logger)
+	// setup admission cache
+	setupAdmissionCache(logger)
+	// setup policy exceptions
+	engine.ExceptionNamespace = exceptionNamespace
+	// parse admission shedding mode
+	sheddingMode, err := webhookshandlers.ParseSheddingMode(admissionSheddingMode)
+	if err != nil {
+		logger.Error(err, "failed to parse admission shedding mode")
+		os.Exit(1)
+	}
+	// setup audit sink
+	admissionAuditSink, closeAuditSink, err := setupAuditSink(signalCtx, logger)
+	if err != nil {
+		logger.Error(err, "failed to setup audit sink")
+		os.Exit(1)
+	}
+	defer closeAuditSink()
 	// check we can run
 	if err := sanityChecks(dynamicClient); err != nil {
 		logger.Error(err, "sanity checks failed")
@@ -549,11 +700,16 @@ func main() {
 		kubeKyvernoInformer.Apps().V1().Deployments(),
 		certRenewer,
 	)
+	// only the metadata of secrets is needed to invalidate image verification results
+	secretInformer := metadatainformers.NewSharedInformerFactory(metadataClient, metadataResyncPeriod)
 	// create non leader controllers
 	nonLeaderControllers, nonLeaderBootstrap := createNonLeaderControllers(
 		kubeInformer,
 		kubeKyvernoInformer,
 		kyvernoInformer,
+		// the informers of trigger resources are started by the update request controller
+		metadatainformers.NewSharedInformerFactory(metadataClient, metadataResyncPeriod),
+		secretInformer,
 		kubeClient,
 		kyvernoClient,
 		dynamicClient,
@@ -561,12 +717,18 @@ func main() {
 		policyCache,
 		eventGenerator,
 		openApiManager,
+		metricsConfig,
 	)
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(signalCtx, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
 		logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 		os.Exit(1)
 	}
+	internal.StartInformers(signalCtx, secretInformer)
+	if !internal.CheckCacheSync(secretInformer.WaitForCacheSync(signalCtx.Done())) {
+		logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+		os.Exit(1)
+	}
 	// bootstrap non leader controllers
 	if nonLeaderBootstrap != nil {
 		if err := nonLeaderBootstrap(); err != nil {
@@ -662,6 +824,7 @@ func main() {
 		dynamicClient,
 		openApiManager,
 	)
+	exceptionHandlers := webhooksexception.NewHandlers()
 	resourceHandlers := webhooksresource.NewHandlers(
 		dynamicClient,
 		kyvernoClient,
@@ -672,20 +835,28 @@ func main() {
 		kubeInformer.Rbac().V1().RoleBindings().Lister(),
 		kubeInformer.Rbac().V1().ClusterRoleBindings().Lister(),
 		kyvernoInformer.Kyverno().V1beta1().UpdateRequests().Lister().UpdateRequests(config.KyvernoNamespace()),
+		kyvernoInformer.Kyverno().V1alpha1().PolicyExceptions().Lister(),
 		urgen,
 		eventGenerator,
 		openApiManager,
 		admissionReports,
+		time.Duration(webhookTimeout)*time.Second,
 	)
 	secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister()
 	server := webhooks.NewServer(
 		policyHandlers,
+		exceptionHandlers,
 		resourceHandlers,
 		configuration,
 		metricsConfig,
 		webhooks.DebugModeOptions{
 			DumpPayload: dumpPayload,
 		},
+		webhookshandlers.NewLimiter(maxInFlightAdmissions, admissionQPS, admissionBurst, sheddingMode),
+		webhooks.AuditOptions{
+			Sink:           admissionAuditSink,
+			IncludeRequest: auditIncludeRequest,
+		},
 		func() ([]byte, []byte, error) {
 			secret, err := secretLister.Secrets(config.KyvernoNamespace()).Get(tls.GenerateTLSPairSecretName())
 			if err != nil {
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// DeletionPolicyLabel records the deletion policy of the generate rule on the generated resource,
+// it is used to clean up the resource when the policy no longer exists
+const DeletionPolicyLabel = "policy.kyverno.io/deletion-policy"
+
+// ForEachRuleLabel records the name of the foreach generate rule on the generated resource,
+// it is used to prune the resources of the elements removed from the foreach list
+const ForEachRuleLabel = "generate.kyverno.io/foreach-rule"
+
+// generateLabels are the labels set by Kyverno on generated resources, removed when the resource is orphaned
+var generateLabels = []string{
+	"kyverno.io/generated-by-kind",
+	"kyverno.io/generated-by-namespace",
+	"kyverno.io/generated-by-name",
+	"kyverno.io/background-gen-rule",
+	"policy.kyverno.io/policy-name",
+	"policy.kyverno.io/gr-name",
+	"policy.kyverno.io/synchronize",
+	"generate.kyverno.io/clone-policy-name",
+	DeletionPolicyLabel,
+	ForEachRuleLabel,
+}
+
+// GetDeletionPolicy returns the deletion policy of a generated resource from its labels.
+// If no deletion policy was recorded, data resources with synchronize enabled are deleted
+// and other resources are retained.
+func GetDeletionPolicy(labels map[string]string) kyvernov1.GenerateDeletionPolicy {
+	if policy := labels[DeletionPolicyLabel]; policy != "" {
+		return kyvernov1.GenerateDeletionPolicy(policy)
+	}
+
+	syncEnabled := labels["policy.kyverno.io/synchronize"] == "enable"
+	clone := labels["generate.kyverno.io/clone-policy-name"] != ""
+	if syncEnabled && !clone {
+		return kyvernov1.GenerateDeletionPolicyDelete
+	}
+	return kyvernov1.GenerateDeletionPolicyRetain
+}
+
+// CleanupGeneratedResource applies the deletion policy of a generated resource
+// once its trigger resource or policy is deleted
+func CleanupGeneratedResource(log logr.Logger, client dclient.Interface, targetSpec kyvernov1.ResourceSpec) error {
+	target, err := client.GetResource(targetSpec.APIVersion, targetSpec.Kind, targetSpec.Namespace, targetSpec.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to find generated resource %s/%s: %v", targetSpec.Namespace, targetSpec.Name, err)
+	}
+
+	labels := target.GetLabels()
+	switch GetDeletionPolicy(labels) {
+	case kyvernov1.GenerateDeletionPolicyDelete:
+		if err := client.DeleteResource(target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete generated resource %s/%s: %v", targetSpec.Namespace, targetSpec.Name, err)
+		}
+		log.V(3).Info("generated resource deleted", "kind", targetSpec.Kind, "namespace", targetSpec.Namespace, "name", targetSpec.Name)
+	case kyvernov1.GenerateDeletionPolicyOrphan:
+		if labels[kyvernov1.LabelAppManagedBy] == kyvernov1.ValueKyvernoApp {
+			delete(labels, kyvernov1.LabelAppManagedBy)
+		}
+		for _, key := range generateLabels {
+			delete(labels, key)
+		}
+		target.SetLabels(labels)
+		if _, err := client.UpdateResource(target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target, false); err != nil {
+			return fmt.Errorf("failed to orphan generated resource %s/%s: %v", targetSpec.Namespace, targetSpec.Name, err)
+		}
+		log.V(3).Info("generated resource orphaned", "kind", targetSpec.Kind, "namespace", targetSpec.Namespace, "name", targetSpec.Name)
+	default:
+		log.V(4).Info("generated resource retained", "kind", targetSpec.Kind, "namespace", targetSpec.Namespace, "name", targetSpec.Name)
+	}
+	return nil
+}
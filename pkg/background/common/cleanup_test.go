@@ -0,0 +1,94 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_GetDeletionPolicy(t *testing.T) {
+	testcases := []struct {
+		name   string
+		labels map[string]string
+		want   kyvernov1.GenerateDeletionPolicy
+	}{{
+		name:   "no-labels",
+		labels: nil,
+		want:   kyvernov1.GenerateDeletionPolicyRetain,
+	}, {
+		name:   "synchronize-data",
+		labels: map[string]string{"policy.kyverno.io/synchronize": "enable"},
+		want:   kyvernov1.GenerateDeletionPolicyDelete,
+	}, {
+		name:   "synchronize-clone",
+		labels: map[string]string{"policy.kyverno.io/synchronize": "enable", "generate.kyverno.io/clone-policy-name": "clone"},
+		want:   kyvernov1.GenerateDeletionPolicyRetain,
+	}, {
+		name:   "explicit-retain",
+		labels: map[string]string{"policy.kyverno.io/synchronize": "enable", DeletionPolicyLabel: "Retain"},
+		want:   kyvernov1.GenerateDeletionPolicyRetain,
+	}, {
+		name:   "explicit-orphan",
+		labels: map[string]string{DeletionPolicyLabel: "Orphan"},
+		want:   kyvernov1.GenerateDeletionPolicyOrphan,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, GetDeletionPolicy(tc.labels), tc.want)
+		})
+	}
+}
+
+func newConfigMap(name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func Test_CleanupGeneratedResource(t *testing.T) {
+	generatedLabels := func(deletionPolicy string) map[string]string {
+		return map[string]string{
+			kyvernov1.LabelAppManagedBy:             kyvernov1.ValueKyvernoApp,
+			"policy.kyverno.io/policy-name":         "generate",
+			"policy.kyverno.io/synchronize":         "enable",
+			"generate.kyverno.io/clone-policy-name": "generate",
+			DeletionPolicyLabel:                     deletionPolicy,
+			"team":                                  "platform",
+		}
+	}
+	objects := []runtime.Object{
+		newConfigMap("retained", generatedLabels("Retain")),
+		newConfigMap("deleted", generatedLabels("Delete")),
+		newConfigMap("orphaned", generatedLabels("Orphan")),
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"}, objects...)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	for _, name := range []string{"retained", "deleted", "orphaned", "missing"} {
+		err := CleanupGeneratedResource(logr.Discard(), client, kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: name})
+		assert.NilError(t, err)
+	}
+
+	retained, err := client.GetResource("v1", "ConfigMap", "default", "retained")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, retained.GetLabels(), generatedLabels("Retain"))
+
+	_, err = client.GetResource("v1", "ConfigMap", "default", "deleted")
+	assert.Assert(t, apierrors.IsNotFound(err))
+
+	orphaned, err := client.GetResource("v1", "ConfigMap", "default", "orphaned")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, orphaned.GetLabels(), map[string]string{"team": "platform"})
+}
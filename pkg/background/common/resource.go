@@ -5,6 +5,7 @@ import (
 	"time"
 
 	logr "github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/common"
@@ -53,3 +54,19 @@ func GetResource(client dclient.Interface, urSpec kyvernov1beta1.UpdateRequestSp
 	log.V(2).Info("fetched trigger resource", "resourceSpec", resourceSpec)
 	return resource, err
 }
+
+// CloneListNamespaces returns the namespaces of the source resources of a clone list
+func CloneListNamespaces(client dclient.Interface, cloneList kyvernov1.CloneList) ([]string, error) {
+	if cloneList.NamespaceSelector == nil {
+		return []string{cloneList.Namespace}, nil
+	}
+	namespaces, err := client.ListResource("v1", "Namespace", "", cloneList.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, ns := range namespaces.Items {
+		names = append(names, ns.GetName())
+	}
+	return names, nil
+}
@@ -48,6 +48,11 @@ func UpdateStatus(client versioned.Interface, urLister kyvernov1beta1listers.Upd
 		ur = ur.DeepCopy()
 		ur.Status.State = state
 		ur.Status.Message = message
+		// a request set back to pending is processed again with a fresh retry budget
+		if state == kyvernov1beta1.Pending {
+			ur.Status.RetryCount = 0
+			ur.Status.LastError = ""
+		}
 		if genResources != nil {
 			ur.Status.GeneratedResources = genResources
 		}
@@ -0,0 +1,229 @@
+package background
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1beta1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1beta1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+)
+
+// GCOptions configures the garbage collection of update requests
+type GCOptions struct {
+	// Interval is the period of the garbage collection, zero disables it
+	Interval time.Duration
+	// Retention is the maximum age of update requests by state, requests in a state without retention are kept
+	Retention map[kyvernov1beta1.UpdateRequestState]time.Duration
+	// MaxPendingPerPolicy caps the number of pending update requests of a policy, zero means no limit
+	MaxPendingPerPolicy int
+}
+
+// garbageCollector deletes the expired update requests, it must only run on the leader
+// so that replicas don't race deleting the same requests
+type garbageCollector struct {
+	kyvernoClient versioned.Interface
+	urLister      kyvernov1beta1listers.UpdateRequestNamespaceLister
+	urSynced      cache.InformerSynced
+	cpolLister    kyvernov1listers.ClusterPolicyLister
+	polLister     kyvernov1listers.PolicyLister
+	eventGen      event.Interface
+	metricsConfig metrics.MetricsConfigManager
+	gc            GCOptions
+}
+
+// NewGarbageCollector returns the controller garbage collecting update requests
+func NewGarbageCollector(
+	kyvernoClient versioned.Interface,
+	urInformer kyvernov1beta1informers.UpdateRequestInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	eventGen event.Interface,
+	metricsConfig metrics.MetricsConfigManager,
+	gc GCOptions,
+) Controller {
+	return &garbageCollector{
+		kyvernoClient: kyvernoClient,
+		urLister:      urInformer.Lister().UpdateRequests(config.KyvernoNamespace()),
+		urSynced:      urInformer.Informer().HasSynced,
+		cpolLister:    cpolInformer.Lister(),
+		polLister:     polInformer.Lister(),
+		eventGen:      eventGen,
+		metricsConfig: metricsConfig,
+		gc:            gc,
+	}
+}
+
+func (c *garbageCollector) Run(ctx context.Context, _ int) {
+	defer runtime.HandleCrash()
+
+	if !cache.WaitForNamedCacheSync("update-request-gc", ctx.Done(), c.urSynced) {
+		return
+	}
+	if c.gc.Interval > 0 {
+		go wait.UntilWithContext(ctx, c.collectGarbage, c.gc.Interval)
+	}
+	<-ctx.Done()
+}
+
+var (
+	requestTypes  = []kyvernov1beta1.RequestType{kyvernov1beta1.Mutate, kyvernov1beta1.Generate}
+	requestStates = []kyvernov1beta1.UpdateRequestState{kyvernov1beta1.Pending, kyvernov1beta1.Failed, kyvernov1beta1.Completed, kyvernov1beta1.Skip}
+)
+
+// collectGarbage deletes the update requests older than the retention of their state, and the most recent
+// pending requests of the policies above the cap. Requests being processed are never deleted, dropped
+// pending requests are reported with an event on the policy and a metric. The requests created by a
+// generate existing backfill are kept until the backfill status of their policy has recorded them.
+func (c *garbageCollector) collectGarbage(ctx context.Context) {
+	urs, err := c.urLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list update requests")
+		return
+	}
+	backlog := map[kyvernov1beta1.RequestType]map[kyvernov1beta1.UpdateRequestState]int64{}
+	for _, t := range requestTypes {
+		backlog[t] = map[kyvernov1beta1.UpdateRequestState]int64{}
+	}
+	pending := map[string][]*kyvernov1beta1.UpdateRequest{}
+	now := time.Now()
+	for _, ur := range urs {
+		state := urState(ur)
+		if _, ok := backlog[ur.Spec.Type]; !ok {
+			continue
+		}
+		if ur.Status.Handler == "" {
+			if retention := c.gc.Retention[state]; retention > 0 && now.Sub(ur.GetCreationTimestamp().Time) > retention && c.backfillRecorded(ur) {
+				if c.deleteUpdateRequest(ctx, ur, "retention expired") {
+					continue
+				}
+			}
+			if state == kyvernov1beta1.Pending {
+				pending[ur.Spec.Policy] = append(pending[ur.Spec.Policy], ur)
+			}
+		}
+		backlog[ur.Spec.Type][state]++
+	}
+	if c.gc.MaxPendingPerPolicy > 0 {
+		for policy, urs := range pending {
+			if len(urs) <= c.gc.MaxPendingPerPolicy {
+				continue
+			}
+			logger.Info("too many pending update requests for policy", "policy", policy, "count", len(urs), "max", c.gc.MaxPendingPerPolicy)
+			sort.Slice(urs, func(i, j int) bool {
+				return urs[i].GetCreationTimestamp().Time.Before(urs[j].GetCreationTimestamp().Time)
+			})
+			dropped := 0
+			for _, ur := range urs[c.gc.MaxPendingPerPolicy:] {
+				if c.deleteUpdateRequest(ctx, ur, "pending cap exceeded") {
+					backlog[ur.Spec.Type][kyvernov1beta1.Pending]--
+					c.reportDrop(ur)
+					dropped++
+				}
+			}
+			if dropped > 0 {
+				c.reportDrops(urs[0], dropped)
+			}
+		}
+	}
+	if c.metricsConfig != nil {
+		for _, t := range requestTypes {
+			for _, state := range requestStates {
+				c.metricsConfig.RecordUpdateRequests(string(t), string(state), backlog[t][state])
+			}
+		}
+	}
+}
+
+// backfillRecorded returns false for the requests created by a backfill which is not completed in the
+// status of their policy, deleting them would make the backfill progress go backwards
+func (c *garbageCollector) backfillRecorded(ur *kyvernov1beta1.UpdateRequest) bool {
+	if ur.GetLabels()[kyvernov1beta1.URGenerateBackfillLabel] == "" {
+		return true
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(ur.Spec.Policy)
+	if err != nil {
+		logger.Error(err, "failed to parse policy name", "policy", ur.Spec.Policy)
+		return true
+	}
+	var policy kyvernov1.PolicyInterface
+	if namespace == "" {
+		policy, err = c.cpolLister.Get(name)
+	} else {
+		policy, err = c.polLister.Policies(namespace).Get(name)
+	}
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get policy", "policy", ur.Spec.Policy)
+			return false
+		}
+		return true
+	}
+	if !policy.GetSpec().IsGenerateExistingOnPolicyUpdate() {
+		return true
+	}
+	status := policy.GetStatus().GenerateExisting
+	return status != nil && status.Pending == 0
+}
+
+// reportDrop records a metric for a pending update request deleted because its policy exceeded the cap
+func (c *garbageCollector) reportDrop(ur *kyvernov1beta1.UpdateRequest) {
+	if c.metricsConfig == nil {
+		return
+	}
+	policyNamespace, policyName, err := cache.SplitMetaNamespaceKey(ur.Spec.Policy)
+	if err != nil {
+		logger.Error(err, "failed to parse policy name", "policy", ur.Spec.Policy)
+		return
+	}
+	c.metricsConfig.RecordUpdateRequestDrops(string(ur.Spec.Type), policyNamespace, policyName)
+}
+
+// reportDrops emits an event on the policy of the pending update requests deleted because it exceeded the cap
+func (c *garbageCollector) reportDrops(ur *kyvernov1beta1.UpdateRequest, count int) {
+	if c.eventGen == nil {
+		return
+	}
+	policyNamespace, policyName, err := cache.SplitMetaNamespaceKey(ur.Spec.Policy)
+	if err != nil {
+		logger.Error(err, "failed to parse policy name", "policy", ur.Spec.Policy)
+		return
+	}
+	source := event.GeneratePolicyController
+	if ur.Spec.Type == kyvernov1beta1.Mutate {
+		source = event.MutateExistingController
+	}
+	c.eventGen.Add(event.NewUpdateRequestsDroppedEvent(source, policyNamespace, policyName, count, c.gc.MaxPendingPerPolicy))
+}
+
+func (c *garbageCollector) deleteUpdateRequest(ctx context.Context, ur *kyvernov1beta1.UpdateRequest, reason string) bool {
+	err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Delete(ctx, ur.GetName(), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "failed to delete update request", "ur", ur.GetName(), "reason", reason)
+		return false
+	}
+	logger.V(3).Info("deleted update request", "ur", ur.GetName(), "policy", ur.Spec.Policy, "state", urState(ur), "reason", reason)
+	return true
+}
+
+// urState returns the state of the update request, requests without state are pending
+func urState(ur *kyvernov1beta1.UpdateRequest) kyvernov1beta1.UpdateRequestState {
+	if ur.Status.State == "" {
+		return kyvernov1beta1.Pending
+	}
+	return ur.Status.State
+}
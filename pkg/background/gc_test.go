@@ -0,0 +1,148 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/event"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+type recordingEventGenerator struct {
+	events []event.Info
+}
+
+func (g *recordingEventGenerator) Add(infos ...event.Info) {
+	g.events = append(g.events, infos...)
+}
+
+func Test_collectGarbage(t *testing.T) {
+	now := time.Now()
+	newUR := func(name, policy string, state kyvernov1beta1.UpdateRequestState, handler string, age time.Duration) *kyvernov1beta1.UpdateRequest {
+		return &kyvernov1beta1.UpdateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         config.KyvernoNamespace(),
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec:   kyvernov1beta1.UpdateRequestSpec{Type: kyvernov1beta1.Generate, Policy: policy},
+			Status: kyvernov1beta1.UpdateRequestStatus{State: state, Handler: handler},
+		}
+	}
+	urs := []*kyvernov1beta1.UpdateRequest{
+		newUR("failed-old", "pol-a", kyvernov1beta1.Failed, "", 48*time.Hour),
+		newUR("failed-recent", "pol-a", kyvernov1beta1.Failed, "", time.Hour),
+		newUR("completed-old", "pol-a", kyvernov1beta1.Completed, "", 48*time.Hour),
+		newUR("pending-1", "pol-b", kyvernov1beta1.Pending, "", 3*time.Minute),
+		newUR("pending-2", "pol-b", "", "", 2*time.Minute),
+		newUR("pending-3", "pol-b", kyvernov1beta1.Pending, "", time.Minute),
+		newUR("pending-4", "pol-b", kyvernov1beta1.Pending, "kyverno-pod", 0),
+		newUR("pending-5", "pol-c", kyvernov1beta1.Pending, "", time.Minute),
+	}
+	var objects []runtime.Object
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, ur := range urs {
+		objects = append(objects, ur)
+		assert.NilError(t, indexer.Add(ur))
+	}
+	client := fake.NewSimpleClientset(objects...)
+	events := &recordingEventGenerator{}
+	c := garbageCollector{
+		kyvernoClient: client,
+		eventGen:      events,
+		urLister:      kyvernov1beta1listers.NewUpdateRequestLister(indexer).UpdateRequests(config.KyvernoNamespace()),
+		gc: GCOptions{
+			Retention: map[kyvernov1beta1.UpdateRequestState]time.Duration{
+				kyvernov1beta1.Failed: 24 * time.Hour,
+			},
+			MaxPendingPerPolicy: 2,
+		},
+	}
+
+	c.collectGarbage(context.TODO())
+
+	remaining, err := client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	var names []string
+	for _, ur := range remaining.Items {
+		names = append(names, ur.GetName())
+	}
+	assert.DeepEqual(t, names, []string{"completed-old", "failed-recent", "pending-1", "pending-2", "pending-4", "pending-5"})
+	// the dropped pending request is reported on its policy
+	assert.Equal(t, len(events.events), 1)
+	assert.Equal(t, events.events[0].Kind, "ClusterPolicy")
+	assert.Equal(t, events.events[0].Name, "pol-b")
+	assert.Equal(t, events.events[0].Message, "1 pending update request(s) dropped, the policy exceeded the limit of 2 pending update requests")
+}
+
+func Test_collectGarbage_Backfill(t *testing.T) {
+	now := time.Now()
+	newPolicy := func(name string, status *kyvernov1.GenerateExistingStatus) *kyvernov1.ClusterPolicy {
+		return &kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kyvernov1.Spec{GenerateExistingOnPolicyUpdate: true},
+			Status:     kyvernov1.PolicyStatus{GenerateExisting: status},
+		}
+	}
+	newUR := func(name, policy string) *kyvernov1beta1.UpdateRequest {
+		return &kyvernov1beta1.UpdateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         config.KyvernoNamespace(),
+				CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour)),
+				Labels:            map[string]string{kyvernov1beta1.URGenerateBackfillLabel: "true"},
+			},
+			Spec:   kyvernov1beta1.UpdateRequestSpec{Type: kyvernov1beta1.Generate, Policy: policy},
+			Status: kyvernov1beta1.UpdateRequestStatus{State: kyvernov1beta1.Completed},
+		}
+	}
+	policies := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, policies.Add(newPolicy("running", &kyvernov1.GenerateExistingStatus{Processed: 1, Pending: 1})))
+	assert.NilError(t, policies.Add(newPolicy("not-recorded", nil)))
+	assert.NilError(t, policies.Add(newPolicy("done", &kyvernov1.GenerateExistingStatus{Processed: 1})))
+	urs := []*kyvernov1beta1.UpdateRequest{
+		newUR("running-1", "running"),
+		newUR("not-recorded-1", "not-recorded"),
+		newUR("done-1", "done"),
+		newUR("deleted-1", "deleted"),
+	}
+	var objects []runtime.Object
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, ur := range urs {
+		objects = append(objects, ur)
+		assert.NilError(t, indexer.Add(ur))
+	}
+	client := fake.NewSimpleClientset(objects...)
+	c := garbageCollector{
+		kyvernoClient: client,
+		eventGen:      &recordingEventGenerator{},
+		urLister:      kyvernov1beta1listers.NewUpdateRequestLister(indexer).UpdateRequests(config.KyvernoNamespace()),
+		cpolLister:    kyvernov1listers.NewClusterPolicyLister(policies),
+		polLister:     kyvernov1listers.NewPolicyLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+		gc: GCOptions{
+			Retention: map[kyvernov1beta1.UpdateRequestState]time.Duration{
+				kyvernov1beta1.Completed: 24 * time.Hour,
+			},
+		},
+	}
+
+	c.collectGarbage(context.TODO())
+
+	remaining, err := client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	var names []string
+	for _, ur := range remaining.Items {
+		names = append(names, ur.GetName())
+	}
+	assert.DeepEqual(t, names, []string{"not-recorded-1", "running-1"})
+}
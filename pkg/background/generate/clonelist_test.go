@@ -0,0 +1,132 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_manageCloneList_NamespaceSelector(t *testing.T) {
+	newObject := func(kind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		obj.SetLabels(labels)
+		return obj
+	}
+	shared := map[string]string{"shared": "true"}
+	objects := []runtime.Object{
+		newObject("Namespace", "", "team-a", shared),
+		newObject("Namespace", "", "team-b", shared),
+		newObject("Namespace", "", "private", nil),
+		newObject("Secret", "team-a", "registry", shared),
+		newObject("Secret", "team-b", "ca-bundle", shared),
+		newObject("Secret", "private", "token", shared),
+		// previously cloned resource, it matches the selectors but is not a source
+		newObject("Secret", "team-a", "team-b-ca-bundle", map[string]string{"shared": "true", "app.kubernetes.io/managed-by": "kyverno", "policy.kyverno.io/policy-name": "sync-secrets"}),
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "namespaces"}: "NamespaceList",
+		{Version: "v1", Resource: "secrets"}:    "SecretList",
+	}, objects...)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	generation := kyvernov1.Generation{
+		ResourceSpec: kyvernov1.ResourceSpec{Namespace: "team-a"},
+		CloneList: kyvernov1.CloneList{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: shared},
+			Kinds:             []string{"v1/Secret"},
+			Selector:          &metav1.LabelSelector{MatchLabels: shared},
+			Name:              "{{ source.namespace }}-{{ source.name }}",
+		},
+	}
+	ctx := context.NewContext()
+	responses := manageCloneList(logr.Discard(), "team-a", "sync-secrets", kyvernov1beta1.UpdateRequest{}, generation, client, ctx)
+
+	actions := map[string]ResourceMode{}
+	for _, response := range responses {
+		assert.NilError(t, response.Error)
+		assert.Equal(t, response.GenNamespace, "team-a")
+		actions[response.GenName] = response.Action
+	}
+	assert.DeepEqual(t, actions, map[string]ResourceMode{
+		"team-a-registry":  Create,
+		"team-b-ca-bundle": Update,
+	})
+
+	// the source variables do not leak in the context
+	_, err = ctx.Query("source")
+	assert.ErrorContains(t, err, `Unknown key "source"`)
+}
+
+func Test_cloneListTargetName(t *testing.T) {
+	source := &unstructured.Unstructured{}
+	source.SetKind("Secret")
+	source.SetNamespace("team-a")
+	source.SetName("registry")
+
+	name, err := cloneListTargetName(logr.Discard(), context.NewContext(), "", source)
+	assert.NilError(t, err)
+	assert.Equal(t, name, "registry")
+
+	name, err = cloneListTargetName(logr.Discard(), context.NewContext(), "{{ to_lower(source.kind) }}-{{ source.name }}", source)
+	assert.NilError(t, err)
+	assert.Equal(t, name, "secret-registry")
+}
+
+func Test_manageCloneList_NameCollision(t *testing.T) {
+	newSecret := func(namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("Secret")
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+	newNamespace := func(name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("Namespace")
+		obj.SetName(name)
+		obj.SetLabels(map[string]string{"shared": "true"})
+		return obj
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "namespaces"}: "NamespaceList",
+		{Version: "v1", Resource: "secrets"}:    "SecretList",
+	}, newNamespace("team-a"), newNamespace("team-b"), newSecret("team-a", "registry"), newSecret("team-b", "registry"))
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	generation := kyvernov1.Generation{
+		ResourceSpec: kyvernov1.ResourceSpec{Namespace: "target"},
+		CloneList: kyvernov1.CloneList{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"shared": "true"}},
+			Kinds:             []string{"v1/Secret"},
+		},
+	}
+	// both sources are cloned to target/registry
+	responses := manageCloneList(logr.Discard(), "target", "sync-secrets", kyvernov1beta1.UpdateRequest{}, generation, client, context.NewContext())
+	last := responses[len(responses)-1]
+	assert.Equal(t, last.Action, Skip)
+	assert.ErrorContains(t, last.Error, "have the same name registry")
+
+	generation.CloneList.Name = "{{ source.namespace }}-{{ source.name }}"
+	responses = manageCloneList(logr.Discard(), "target", "sync-secrets", kyvernov1beta1.UpdateRequest{}, generation, client, context.NewContext())
+	assert.Equal(t, len(responses), 2)
+	for _, response := range responses {
+		assert.NilError(t, response.Error)
+	}
+}
@@ -0,0 +1,189 @@
+package generate
+
+import (
+	contextdefault "context"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	pkgcommon "github.com/kyverno/kyverno/pkg/common"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/event"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/cache"
+)
+
+// driftCheck collects the synchronized resources which no longer match their desired state
+type driftCheck struct {
+	// heal updates the drifted resources
+	heal   bool
+	drifts []drift
+}
+
+type drift struct {
+	rule     string
+	resource unstructured.Unstructured
+	message  string
+}
+
+// readOnly returns true when the generated resources must be left untouched
+func (d *driftCheck) readOnly() bool {
+	return d != nil && !d.heal
+}
+
+func (d *driftCheck) add(rule string, resource unstructured.Unstructured, path string, err error) {
+	msg := fmt.Sprintf("generated resource drifted from the desired state at %s: %v", path, err)
+	if d.heal {
+		msg += ", the resource was updated"
+	}
+	d.drifts = append(d.drifts, drift{rule: rule, resource: resource, message: msg})
+}
+
+// recreate records a deleted generated resource, it returns false when the resource must not be created again.
+// Outside of a drift check the resource is always created.
+func (d *driftCheck) recreate(rule kyvernov1.Rule, resource unstructured.Unstructured) bool {
+	if d == nil {
+		return true
+	}
+	// resources which are not synchronized can be deleted by users
+	if !rule.Generation.Synchronize {
+		return false
+	}
+	msg := "generated resource was deleted"
+	if d.heal {
+		msg += ", the resource was recreated"
+	}
+	d.drifts = append(d.drifts, drift{rule: rule.Name, resource: resource, message: msg})
+	return d.heal
+}
+
+// CheckDrift compares the synchronized resources generated for the update request with the state
+// declared by the policy. Drifted resources are reported with events and, if reports is true, with
+// admission reports aggregated in the policy reports. They are updated, or recreated when they were deleted,
+// only when heal is true.
+func (c *GenerateController) CheckDrift(ur *kyvernov1beta1.UpdateRequest, heal, reports bool) error {
+	logger := c.log.WithValues("name", ur.Name, "policy", ur.Spec.Policy, "kind", ur.Spec.Resource.Kind, "namespace", ur.Spec.Resource.Namespace, "name", ur.Spec.Resource.Name)
+
+	resourceSpec := ur.Spec.Resource
+	if resourceSpec.Kind == "Namespace" {
+		resourceSpec.Namespace = ""
+	}
+	resource, err := c.client.GetResource(resourceSpec.APIVersion, resourceSpec.Kind, resourceSpec.Namespace, resourceSpec.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if resource.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	policy, err := c.getPolicy(ur.Spec.Policy)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	policySpec, err := c.getPolicySpec(*ur)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// the update request and the generated resources are only read here, unlike applyGenerate
+	// which cleans up the update requests and resources that no longer apply
+	namespaceLabels := pkgcommon.GetNamespaceSelectorsFromNamespaceLister(resource.GetKind(), resource.GetNamespace(), c.nsLister, logger)
+	policyContext, _, err := common.NewBackgroundContext(c.client, ur, &policySpec, resource, c.configuration, namespaceLabels, logger)
+	if err != nil {
+		return err
+	}
+	var applicableRules []string
+	for _, r := range engine.GenerateResponse(policyContext, *ur).PolicyResponse.Rules {
+		if r.Status == response.RuleStatusPass {
+			applicableRules = append(applicableRules, r.Name)
+		}
+	}
+	if len(applicableRules) == 0 {
+		return nil
+	}
+
+	c.drift = &driftCheck{heal: heal}
+	defer func() { c.drift = nil }()
+
+	if _, _, err := c.ApplyGeneratePolicy(logger, policyContext, *ur, applicableRules); err != nil {
+		return err
+	}
+
+	for _, d := range c.drift.drifts {
+		logger.V(2).Info("generated resource drifted", "rule", d.rule, "genKind", d.resource.GetKind(), "genNamespace", d.resource.GetNamespace(), "genName", d.resource.GetName(), "healed", heal)
+		c.eventGen.Add(event.NewGeneratedResourceDriftEvent(ur.Spec.Policy, d.rule, &d.resource, d.message))
+		if reports {
+			if err := c.reportDrift(policy, d); err != nil {
+				logger.Error(err, "failed to create drift report")
+			}
+		}
+	}
+	return nil
+}
+
+func (c *GenerateController) getPolicy(key string) (kyvernov1.PolicyInterface, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		return c.policyLister.Get(name)
+	}
+	return c.npolicyLister.Policies(namespace).Get(name)
+}
+
+// reportDrift creates an admission report with a failed result for the drifted resource
+func (c *GenerateController) reportDrift(policy kyvernov1.PolicyInterface, d drift) error {
+	resource := d.resource
+	gvk := resource.GroupVersionKind()
+	if !reportutils.IsGvkSupported(gvk) {
+		return nil
+	}
+
+	engineResponse := &response.EngineResponse{
+		PatchedResource: resource,
+		Policy:          policy,
+		PolicyResponse: response.PolicyResponse{
+			Policy: response.PolicySpec{
+				Name:      policy.GetName(),
+				Namespace: policy.GetNamespace(),
+			},
+			Resource: response.ResourceSpec{
+				Kind:       resource.GetKind(),
+				APIVersion: resource.GetAPIVersion(),
+				Namespace:  resource.GetNamespace(),
+				Name:       resource.GetName(),
+				UID:        string(resource.GetUID()),
+			},
+			Rules: []response.RuleResponse{{
+				Name:    d.rule,
+				Type:    response.Generation,
+				Status:  response.RuleStatusFail,
+				Message: d.message,
+			}},
+		},
+	}
+
+	request := &admissionv1.AdmissionRequest{UID: uuid.NewUUID()}
+	report := reportutils.NewAdmissionReport(resource, request, metav1.GroupVersionKind(gvk), engineResponse)
+	controllerutils.SetOwner(report, resource.GetAPIVersion(), resource.GetKind(), resource.GetName(), resource.GetUID())
+	_, err := reportutils.CreateReport(contextdefault.TODO(), report, c.kyvernoClient)
+	return err
+}
@@ -0,0 +1,107 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_applyRule_Drift(t *testing.T) {
+	newClient := func() dclient.Interface {
+		generated := &unstructured.Unstructured{}
+		generated.SetAPIVersion("v1")
+		generated.SetKind("ConfigMap")
+		generated.SetNamespace("default")
+		generated.SetName("zk-kafka-address")
+		generated.SetLabels(map[string]string{"policy.kyverno.io/synchronize": "enable"})
+		assert.NilError(t, unstructured.SetNestedField(generated.Object, "modified", "data", "ZK_ADDRESS"))
+		client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"}, generated)
+		assert.NilError(t, err)
+		client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+		return client
+	}
+
+	rule := kyvernov1.Rule{
+		Name: "generate-configmap",
+		Generation: kyvernov1.Generation{
+			ResourceSpec: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "zk-kafka-address"},
+			Synchronize:  true,
+		},
+	}
+	rule.Generation.SetData(map[string]interface{}{"data": map[string]interface{}{"ZK_ADDRESS": "192.168.10.10:2181"}})
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "generate"}}
+	trigger := unstructured.Unstructured{}
+	trigger.SetKind("Namespace")
+	trigger.SetName("default")
+	ur := kyvernov1beta1.UpdateRequest{Status: kyvernov1beta1.UpdateRequestStatus{GeneratedResources: []kyvernov1.ResourceSpec{rule.Generation.ResourceSpec}}}
+
+	for _, heal := range []bool{false, true} {
+		client := newClient()
+		drift := &driftCheck{heal: heal}
+		_, err := applyRule(logr.Discard(), client, rule, trigger, context.NewContext(), policy, ur, "", drift)
+		assert.NilError(t, err)
+		assert.Equal(t, len(drift.drifts), 1)
+		assert.Equal(t, drift.drifts[0].rule, "generate-configmap")
+		assert.Equal(t, drift.drifts[0].resource.GetName(), "zk-kafka-address")
+
+		generated, err := client.GetResource("v1", "ConfigMap", "default", "zk-kafka-address")
+		assert.NilError(t, err)
+		value, _, _ := unstructured.NestedString(generated.Object, "data", "ZK_ADDRESS")
+		if heal {
+			assert.Equal(t, value, "192.168.10.10:2181")
+		} else {
+			assert.Equal(t, value, "modified")
+		}
+	}
+}
+
+func Test_applyRule_DriftDeleted(t *testing.T) {
+	rule := kyvernov1.Rule{
+		Name: "generate-configmap",
+		Generation: kyvernov1.Generation{
+			ResourceSpec: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "zk-kafka-address"},
+			Synchronize:  true,
+		},
+	}
+	rule.Generation.SetData(map[string]interface{}{"data": map[string]interface{}{"ZK_ADDRESS": "192.168.10.10:2181"}})
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "generate"}}
+	trigger := unstructured.Unstructured{}
+	trigger.SetKind("Namespace")
+	trigger.SetName("default")
+	ur := kyvernov1beta1.UpdateRequest{Status: kyvernov1beta1.UpdateRequestStatus{GeneratedResources: []kyvernov1.ResourceSpec{rule.Generation.ResourceSpec}}}
+
+	for _, synchronize := range []bool{false, true} {
+		for _, heal := range []bool{false, true} {
+			client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"})
+			assert.NilError(t, err)
+			client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+			rule.Generation.Synchronize = synchronize
+			drift := &driftCheck{heal: heal}
+			_, err = applyRule(logr.Discard(), client, rule, trigger, context.NewContext(), policy, ur, "", drift)
+			assert.NilError(t, err)
+
+			_, err = client.GetResource("v1", "ConfigMap", "default", "zk-kafka-address")
+			if synchronize {
+				assert.Equal(t, len(drift.drifts), 1)
+				assert.Equal(t, drift.drifts[0].resource.GetName(), "zk-kafka-address")
+			} else {
+				assert.Equal(t, len(drift.drifts), 0)
+			}
+			if synchronize && heal {
+				assert.NilError(t, err)
+			} else {
+				assert.Assert(t, apierrors.IsNotFound(err))
+			}
+		}
+	}
+}
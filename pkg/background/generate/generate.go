@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
 	"time"
 
@@ -29,6 +28,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/event"
 	kyvernoutils "github.com/kyverno/kyverno/pkg/utils"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"go.uber.org/multierr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -52,6 +52,9 @@ type GenerateController struct {
 	configuration config.Configuration
 	eventGen      event.Interface
 
+	// drift collects the drifted resources during a drift check
+	drift *driftCheck
+
 	log logr.Logger
 }
 
@@ -94,52 +97,14 @@ func (c *GenerateController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) error {
 	// 1 - Check if the trigger exists
 	resource, err = common.GetResource(c.client, ur.Spec, c.log)
 	if err != nil {
-		// Don't update status
-		// re-queueing the UR by updating the annotation
-		// retry - 5 times
-		logger.V(3).Info("resource does not exist or is pending creation, re-queueing", "details", err.Error(), "retry")
-		urAnnotations := ur.Annotations
-
-		if len(urAnnotations) == 0 {
-			urAnnotations = map[string]string{
-				urAnnotations[kyvernov1beta1.URGenerateRetryCountAnnotation]: "1",
-			}
-		} else {
-			if val, ok := urAnnotations[kyvernov1beta1.URGenerateRetryCountAnnotation]; ok {
-				sleepCountInt64, err := strconv.ParseUint(val, 10, 32)
-				if err != nil {
-					logger.Error(err, "unable to convert retry-count")
-					return err
-				}
-
-				sleepCountInt := int(sleepCountInt64) + 1
-				if sleepCountInt > 5 {
-					if err := deleteGeneratedResources(logger, c.client, *ur); err != nil {
-						return err
-					}
-					// - trigger-resource is deleted
-					// - generated-resources are deleted
-					// - > Now delete the UpdateRequest CR
-					return c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Delete(contextdefault.TODO(), ur.Name, metav1.DeleteOptions{})
-				} else {
-					time.Sleep(time.Second * time.Duration(sleepCountInt))
-					incrementedCountString := strconv.Itoa(sleepCountInt)
-					urAnnotations[kyvernov1beta1.URGenerateRetryCountAnnotation] = incrementedCountString
-				}
-			} else {
-				time.Sleep(time.Second * 1)
-				urAnnotations[kyvernov1beta1.URGenerateRetryCountAnnotation] = "1"
-			}
-		}
-
-		ur.SetAnnotations(urAnnotations)
-		_, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Update(contextdefault.TODO(), ur, metav1.UpdateOptions{})
-		if err != nil {
-			logger.Error(err, "failed to update annotation in update request for the resource", "update request", ur.Name, "resourceVersion", ur.GetResourceVersion())
-			return err
+		if c.triggerDeleted(*ur) {
+			logger.V(3).Info("trigger resource was deleted, cleaning up generated resources based on the deletion policy")
+			return c.cleanupOnTriggerDeletion(logger, *ur)
 		}
-
-		return err
+		// Don't update status, the update request controller retries with backoff
+		// and moves the request to the Failed state once the retries are exhausted
+		logger.V(3).Info("resource does not exist or is pending creation, re-queueing", "details", err.Error())
+		return fmt.Errorf("failed to get trigger resource: %w", err)
 	}
 
 	// trigger resource is being terminated
@@ -169,6 +134,33 @@ func (c *GenerateController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) error {
 
 const doesNotApply = "policy does not apply to resource"
 
+// GenerateFieldManager is the field manager of the resources generated with server-side apply
+const GenerateFieldManager = "kyverno-generate"
+
+// triggerDeleted checks if the trigger resource of an update request which already generated resources no longer exists
+func (c *GenerateController) triggerDeleted(ur kyvernov1beta1.UpdateRequest) bool {
+	if len(ur.Status.GeneratedResources) == 0 {
+		return false
+	}
+
+	resourceSpec := ur.Spec.Resource
+	if resourceSpec.Kind == "Namespace" {
+		resourceSpec.Namespace = ""
+	}
+	_, err := c.client.GetResource(resourceSpec.APIVersion, resourceSpec.Kind, resourceSpec.Namespace, resourceSpec.Name)
+	return apierrors.IsNotFound(err)
+}
+
+// cleanupOnTriggerDeletion applies the deletion policy to the generated resources and deletes the update request
+func (c *GenerateController) cleanupOnTriggerDeletion(logger logr.Logger, ur kyvernov1beta1.UpdateRequest) error {
+	for _, e := range ur.Status.GeneratedResources {
+		if err := common.CleanupGeneratedResource(logger, c.client, e); err != nil {
+			return err
+		}
+	}
+	return c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Delete(contextdefault.TODO(), ur.Name, metav1.DeleteOptions{})
+}
+
 func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, ur kyvernov1beta1.UpdateRequest, namespaceLabels map[string]string) ([]kyvernov1.ResourceSpec, bool, error) {
 	logger := c.log.WithValues("name", ur.GetName(), "policy", ur.Spec.Policy, "kind", ur.Spec.Resource.Kind, "apiVersion", ur.Spec.Resource.APIVersion, "namespace", ur.Spec.Resource.Namespace, "name", ur.Spec.Resource.Name)
 	logger.V(3).Info("applying generate policy rule")
@@ -177,8 +169,8 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			for _, e := range ur.Status.GeneratedResources {
-				if err := c.cleanupClonedResource(e); err != nil {
-					logger.Error(err, "failed to clean up cloned resource on policy deletion")
+				if err := common.CleanupGeneratedResource(logger, c.client, e); err != nil {
+					logger.Error(err, "failed to clean up generated resource on policy deletion")
 				}
 			}
 			return nil, false, nil
@@ -232,31 +224,6 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	return c.ApplyGeneratePolicy(logger, policyContext, ur, applicableRules)
 }
 
-// cleanupClonedResource deletes cloned resource if sync is not enabled for the clone policy
-func (c *GenerateController) cleanupClonedResource(targetSpec kyvernov1.ResourceSpec) error {
-	target, err := c.client.GetResource(targetSpec.APIVersion, targetSpec.Kind, targetSpec.Namespace, targetSpec.Name)
-	if err != nil {
-		if !apierrors.IsNotFound(err) {
-			return fmt.Errorf("failed to find generated resource %s/%s: %v", targetSpec.Namespace, targetSpec.Name, err)
-		}
-	}
-
-	if target == nil {
-		return nil
-	}
-
-	labels := target.GetLabels()
-	syncEnabled := labels["policy.kyverno.io/synchronize"] == "enable"
-	clone := labels["generate.kyverno.io/clone-policy-name"] != ""
-
-	if syncEnabled && !clone {
-		if err := c.client.DeleteResource(target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target.GetName(), false); err != nil {
-			return fmt.Errorf("cloned resource is not deleted %s/%s: %v", targetSpec.Namespace, targetSpec.Name, err)
-		}
-	}
-	return nil
-}
-
 // getPolicySpec gets the policy spec from the ClusterPolicy/Policy
 func (c *GenerateController) getPolicySpec(ur kyvernov1beta1.UpdateRequest) (kyvernov1.ClusterPolicy, error) {
 	var policy kyvernov1.ClusterPolicy
@@ -346,17 +313,38 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 			return nil, processExisting, err
 		}
 
+		// foreach declarations reference element variables, they are substituted for each element
+		// and the name template of a clone list references source variables, it is substituted for each source
+		foreach, cloneListName := rule.Generation.ForEachGeneration, rule.Generation.CloneList.Name
+		rule.Generation.ForEachGeneration, rule.Generation.CloneList.Name = nil, ""
 		if rule, err = variables.SubstituteAllInRule(log, policyContext.JSONContext, rule); err != nil {
 			log.Error(err, "variable substitution failed for rule %s", rule.Name)
 			return nil, processExisting, err
 		}
+		rule.Generation.ForEachGeneration, rule.Generation.CloneList.Name = foreach, cloneListName
 
 		if policy.GetSpec().IsGenerateExistingOnPolicyUpdate() || !processExisting {
-			genResource, err = applyRule(log, c.client, rule, resource, jsonContext, policy, ur)
+			if len(rule.Generation.ForEachGeneration) != 0 {
+				genResource, err = applyForEach(log, c.client, rule, resource, policyContext, ur, c.drift)
+			} else {
+				genResource, err = applyRule(log, c.client, rule, resource, jsonContext, policy, ur, "", c.drift)
+			}
 			if err != nil {
 				log.Error(err, "failed to apply generate rule", "policy", policy.GetName(),
 					"rule", rule.Name, "resource", resource.GetName(), "suggestion", "users need to grant Kyverno's service account additional privileges")
-				return nil, processExisting, err
+				// the resources generated before the failure are tracked along with the ones tracked
+				// previously, the remaining rules were not processed
+				for _, generated := range genResource {
+					if generated.Name != "" {
+						genResources = append(genResources, generated)
+					}
+				}
+				for _, tracked := range ur.Status.GeneratedResources {
+					if !containsResourceSpec(genResources, tracked) {
+						genResources = append(genResources, tracked)
+					}
+				}
+				return genResources, processExisting, err
 			}
 			ruleNameToProcessingTime[rule.Name] = time.Since(startTime)
 			genResources = append(genResources, genResource...)
@@ -406,7 +394,177 @@ func getResourceInfoForDataAndClone(rule kyvernov1.Rule) (kind, name, namespace,
 	return
 }
 
-func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, resource unstructured.Unstructured, ctx context.EvalInterface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) ([]kyvernov1.ResourceSpec, error) {
+// applyForEach generates a resource for each element of the foreach lists in the rule.
+// Each element is processed with its own variable scope, and the synchronize behaviour
+// is tracked per generated resource. The errors of the elements are collected so that the
+// resources of the other elements are still generated and tracked. When synchronize is enabled
+// and all the elements were processed, the resources of the elements removed from the lists are
+// cleaned up based on the deletion policy of the rule.
+func applyForEach(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, resource unstructured.Unstructured, policyContext *engine.PolicyContext, ur kyvernov1beta1.UpdateRequest, drift *driftCheck) ([]kyvernov1.ResourceSpec, error) {
+	var genResources []kyvernov1.ResourceSpec
+	var errs []error
+	jsonContext := policyContext.JSONContext
+	jsonContext.Checkpoint()
+	defer jsonContext.Restore()
+
+	for i, foreach := range rule.Generation.ForEachGeneration {
+		elements, err := evaluateList(foreach.List, jsonContext)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to evaluate list %s in foreach[%d]: %w", foreach.List, i, err))
+			continue
+		}
+
+		for index, element := range elements {
+			if element == nil {
+				continue
+			}
+			genResource, err := applyForEachElement(log, client, rule, i, foreach, element, index, resource, policyContext, ur, drift)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to generate resource for foreach[%d] element %d: %w", i, index, err))
+				continue
+			}
+			genResources = append(genResources, genResource...)
+		}
+	}
+
+	if len(errs) == 0 && rule.Generation.Synchronize && !drift.readOnly() {
+		for _, tracked := range ur.Status.GeneratedResources {
+			if containsResourceSpec(genResources, tracked) {
+				continue
+			}
+			if err := pruneForEachResource(log, client, rule.Name, tracked); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return genResources, multierr.Combine(errs...)
+}
+
+// applyForEachElement generates the resource of a single foreach element, no resource is returned
+// when the preconditions of the element are not met
+func applyForEachElement(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, i int, foreach kyvernov1.ForEachGeneration, element interface{}, index int, resource unstructured.Unstructured, policyContext *engine.PolicyContext, ur kyvernov1beta1.UpdateRequest, drift *driftCheck) ([]kyvernov1.ResourceSpec, error) {
+	jsonContext := policyContext.JSONContext
+	jsonContext.Reset()
+	if err := jsonContext.AddElement(element, index); err != nil {
+		return nil, fmt.Errorf("failed to add element to context: %w", err)
+	}
+
+	if err := engine.LoadContext(log, foreach.Context, policyContext, rule.Name); err != nil {
+		return nil, fmt.Errorf("failed to load context for foreach[%d]: %w", i, err)
+	}
+
+	if foreach.AnyAllConditions != nil {
+		conditions, err := variables.SubstituteAllInConditions(log, jsonContext, []kyvernov1.AnyAllConditions{*foreach.AnyAllConditions})
+		if err != nil {
+			return nil, fmt.Errorf("failed to substitute variables in foreach[%d] preconditions: %w", i, err)
+		}
+		if !variables.EvaluateAnyAllConditions(log, jsonContext, conditions) {
+			log.V(3).Info("foreach preconditions not met", "index", index)
+			return nil, nil
+		}
+	}
+
+	generation, err := substituteForEach(log, jsonContext, foreach)
+	if err != nil {
+		return nil, fmt.Errorf("variable substitution failed for foreach[%d]: %w", i, err)
+	}
+
+	elementRule := *rule.DeepCopy()
+	elementRule.Generation = kyvernov1.Generation{
+		ResourceSpec:   generation.ResourceSpec,
+		Synchronize:    rule.Generation.Synchronize,
+		DeletionPolicy: rule.Generation.DeletionPolicy,
+		ApplyMode:      rule.Generation.ApplyMode,
+		RawData:        generation.RawData,
+		Clone:          generation.Clone,
+	}
+
+	if _, err := applyRule(log, client, elementRule, resource, jsonContext, policyContext.Policy, elementUpdateRequest(ur, generation.ResourceSpec), rule.Name, drift); err != nil {
+		return nil, err
+	}
+	// the resource is tracked even when it did not need to be created or updated
+	spec := generation.ResourceSpec
+	return []kyvernov1.ResourceSpec{newGenResource(spec.APIVersion, spec.Kind, spec.Namespace, spec.Name)}, nil
+}
+
+func containsResourceSpec(specs []kyvernov1.ResourceSpec, spec kyvernov1.ResourceSpec) bool {
+	for _, s := range specs {
+		if s.Kind == spec.Kind && s.Namespace == spec.Namespace && s.Name == spec.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneForEachResource cleans up a resource previously generated by the foreach rule
+// which is no longer generated, resources generated by other rules are left untouched
+func pruneForEachResource(log logr.Logger, client dclient.Interface, ruleName string, spec kyvernov1.ResourceSpec) error {
+	target, err := client.GetResource(spec.APIVersion, spec.Kind, spec.Namespace, spec.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get generated resource %s/%s: %w", spec.Namespace, spec.Name, err)
+	}
+	if target.GetLabels()[common.ForEachRuleLabel] != ruleName {
+		return nil
+	}
+	log.V(3).Info("pruning resource of a removed foreach element", "kind", spec.Kind, "namespace", spec.Namespace, "name", spec.Name)
+	return common.CleanupGeneratedResource(log, client, spec)
+}
+
+func evaluateList(jmesPath string, ctx context.EvalInterface) ([]interface{}, error) {
+	i, err := ctx.Query(jmesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	l, ok := i.([]interface{})
+	if !ok {
+		return []interface{}{i}, nil
+	}
+
+	return l, nil
+}
+
+func substituteForEach(log logr.Logger, ctx context.EvalInterface, foreach kyvernov1.ForEachGeneration) (kyvernov1.ForEachGeneration, error) {
+	var generation kyvernov1.ForEachGeneration
+	untyped, err := variables.DocumentToUntyped(foreach)
+	if err != nil {
+		return generation, err
+	}
+
+	untyped, err = variables.SubstituteAll(log, ctx, untyped)
+	if err != nil {
+		return generation, err
+	}
+
+	jsonBytes, err := json.Marshal(untyped)
+	if err != nil {
+		return generation, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &generation)
+	return generation, err
+}
+
+// elementUpdateRequest returns a copy of the update request that only tracks the resource
+// generated for a single foreach element, so that synchronize applies per element
+func elementUpdateRequest(ur kyvernov1beta1.UpdateRequest, spec kyvernov1.ResourceSpec) kyvernov1beta1.UpdateRequest {
+	elementUR := *ur.DeepCopy()
+	elementUR.Status.GeneratedResources = nil
+	for _, generated := range ur.Status.GeneratedResources {
+		if generated.Kind == spec.Kind && generated.Namespace == spec.Namespace && generated.Name == spec.Name {
+			elementUR.Status.GeneratedResources = append(elementUR.Status.GeneratedResources, generated)
+		}
+	}
+	return elementUR
+}
+
+// applyRule generates the resource of the rule, foreachRule is the name of the foreach rule when the rule
+// was built for a foreach element
+func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, resource unstructured.Unstructured, ctx context.Interface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest, foreachRule string, drift *driftCheck) ([]kyvernov1.ResourceSpec, error) {
 	rdatas := []GenerateResponse{}
 	var cresp, dresp map[string]interface{}
 	var err error
@@ -434,7 +592,7 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 			Error:         err,
 		})
 	} else if len(rule.Generation.CloneList.Kinds) != 0 {
-		rdatas = manageCloneList(logger, genNamespace, policy.GetName(), ur, rule.Generation, client)
+		rdatas = manageCloneList(logger, genNamespace, policy.GetName(), ur, rule.Generation, client, ctx)
 	} else {
 		dresp, mode, err = manageData(logger, genAPIVersion, genKind, genNamespace, genName, rule.Generation.RawData, rule.Generation.Synchronize, ur, client)
 		rdatas = append(rdatas, GenerateResponse{
@@ -450,9 +608,9 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 
 	for _, rdata := range rdatas {
 		if rdata.Error != nil {
-			logger.Error(err, "failed to generate resource", "mode", rdata.Action)
+			logger.Error(rdata.Error, "failed to generate resource", "mode", rdata.Action)
 			newGenResources = append(newGenResources, noGenResource)
-			return newGenResources, err
+			return newGenResources, rdata.Error
 		}
 
 		logger.V(3).Info("applying generate rule", "mode", rdata.Action)
@@ -494,19 +652,30 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 
 		label["policy.kyverno.io/policy-name"] = policy.GetName()
 		label["policy.kyverno.io/gr-name"] = ur.Name
+		if foreachRule != "" {
+			label[common.ForEachRuleLabel] = foreachRule
+		}
 		if rdata.Action == Create {
+			if !drift.recreate(rule, *newResource) {
+				continue
+			}
 			if rule.Generation.Synchronize {
 				label["policy.kyverno.io/synchronize"] = "enable"
 			} else {
 				label["policy.kyverno.io/synchronize"] = "disable"
 			}
+			manageDeletionPolicyLabel(label, rule.Generation.DeletionPolicy)
 
 			// Reset resource version
 			newResource.SetResourceVersion("")
 			newResource.SetLabels(label)
 
 			// Create the resource
-			_, err = client.CreateResource(rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
+			if rule.Generation.IsServerSideApply() {
+				err = serverSideApply(client, rdata, newResource)
+			} else {
+				_, err = client.CreateResource(rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
+			}
 			if err != nil {
 				if !apierrors.IsAlreadyExists(err) {
 					newGenResources = append(newGenResources, noGenResource)
@@ -519,8 +688,15 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 			generatedObj, err := client.GetResource(rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, rdata.GenName)
 			if err != nil {
 				logger.Error(err, fmt.Sprintf("generated resource not found  name:%v namespace:%v kind:%v", genName, genNamespace, genKind))
+				if !drift.recreate(rule, *newResource) {
+					continue
+				}
 				logger.V(2).Info(fmt.Sprintf("creating generate resource name:name:%v namespace:%v kind:%v", genName, genNamespace, genKind))
-				_, err = client.CreateResource(rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
+				if rule.Generation.IsServerSideApply() {
+					err = serverSideApply(client, rdata, newResource)
+				} else {
+					_, err = client.CreateResource(rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
+				}
 				if err != nil {
 					newGenResources = append(newGenResources, noGenResource)
 					return newGenResources, err
@@ -531,6 +707,7 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 				if rule.Generation.Synchronize {
 					logger.V(4).Info("updating existing resource")
 					label["policy.kyverno.io/synchronize"] = "enable"
+					manageDeletionPolicyLabel(label, rule.Generation.DeletionPolicy)
 					newResource.SetLabels(label)
 
 					if rdata.GenAPIVersion == "" {
@@ -541,23 +718,39 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 						newResource.SetNamespace("default")
 					}
 
-					if _, err := ValidateResourceWithPattern(logger, generatedObj.Object, newResource.Object); err != nil {
-						_, err = client.UpdateResource(rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
-						if err != nil {
-							logger.Error(err, "failed to update resource")
-							newGenResources = append(newGenResources, noGenResource)
-							return newGenResources, err
+					if path, err := ValidateResourceWithPattern(logger, generatedObj.Object, newResource.Object); err != nil {
+						if drift != nil {
+							drift.add(rule.Name, *generatedObj, path, err)
+						}
+						if !drift.readOnly() {
+							if rule.Generation.IsServerSideApply() {
+								err = serverSideApply(client, rdata, newResource)
+							} else {
+								_, err = client.UpdateResource(rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
+							}
+							if err != nil {
+								logger.Error(err, "failed to update resource")
+								newGenResources = append(newGenResources, noGenResource)
+								return newGenResources, err
+							}
 						}
 					}
-				} else {
+				} else if !drift.readOnly() {
 					currentGeneratedResourcelabel := generatedObj.GetLabels()
+					if currentGeneratedResourcelabel == nil {
+						currentGeneratedResourcelabel = map[string]string{}
+					}
 					currentSynclabel := currentGeneratedResourcelabel["policy.kyverno.io/synchronize"]
 
+					currentDeletionPolicyLabel := currentGeneratedResourcelabel[common.DeletionPolicyLabel]
+
 					// update only if the labels mismatches
 					if (!rule.Generation.Synchronize && currentSynclabel == "enable") ||
-						(rule.Generation.Synchronize && currentSynclabel == "disable") {
+						(rule.Generation.Synchronize && currentSynclabel == "disable") ||
+						currentDeletionPolicyLabel != string(rule.Generation.DeletionPolicy) {
 						logger.V(4).Info("updating label in existing resource")
 						currentGeneratedResourcelabel["policy.kyverno.io/synchronize"] = "disable"
+						manageDeletionPolicyLabel(currentGeneratedResourcelabel, rule.Generation.DeletionPolicy)
 						generatedObj.SetLabels(currentGeneratedResourcelabel)
 
 						_, err = client.UpdateResource(rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, generatedObj, false)
@@ -575,6 +768,45 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 	return newGenResources, nil
 }
 
+// serverSideApply applies the generated resource with the generate field manager,
+// the fields owned by other managers are not overwritten and conflicts are returned as an error
+func serverSideApply(client dclient.Interface, rdata GenerateResponse, resource *unstructured.Unstructured) error {
+	obj := resource.DeepCopy()
+	obj.SetResourceVersion("")
+	_, err := client.ApplyResource(rdata.GenAPIVersion, rdata.GenKind, obj.GetNamespace(), rdata.GenName, obj, GenerateFieldManager, false, false)
+	return applyConflictError(err)
+}
+
+// applyConflictError lists the fields of a server-side apply conflict and their managers
+func applyConflictError(err error) error {
+	if err == nil || !apierrors.IsConflict(err) {
+		return err
+	}
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return err
+	}
+	var conflicts []string
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+		}
+	}
+	if len(conflicts) == 0 {
+		return err
+	}
+	return fmt.Errorf("server-side apply conflicts with other field managers: %s", strings.Join(conflicts, "; "))
+}
+
+// manageDeletionPolicyLabel records the deletion policy of the rule on the generated resource
+func manageDeletionPolicyLabel(labels map[string]string, deletionPolicy kyvernov1.GenerateDeletionPolicy) {
+	if deletionPolicy == "" {
+		delete(labels, common.DeletionPolicyLabel)
+	} else {
+		labels[common.DeletionPolicyLabel] = string(deletionPolicy)
+	}
+}
+
 func newGenResource(genAPIVersion, genKind, genNamespace, genName string) kyvernov1.ResourceSpec {
 	// Resource to be generated
 	newGenResource := kyvernov1.ResourceSpec{
@@ -673,15 +905,15 @@ func manageClone(log logr.Logger, apiVersion, kind, namespace, name, policy stri
 	return obj.UnstructuredContent(), Create, nil
 }
 
-func manageCloneList(log logr.Logger, namespace, policy string, ur kyvernov1beta1.UpdateRequest, clone kyvernov1.Generation, client dclient.Interface) []GenerateResponse {
+func manageCloneList(log logr.Logger, namespace, policy string, ur kyvernov1beta1.UpdateRequest, clone kyvernov1.Generation, client dclient.Interface, ctx context.Interface) []GenerateResponse {
 	var response []GenerateResponse
 
-	rNamespace := clone.CloneList.Namespace
-	if rNamespace == "" {
-		log.V(4).Info("resource namespace %s , optional in case of cluster scope resource", rNamespace)
+	cloneList := clone.CloneList
+	if cloneList.Namespace == "" && cloneList.NamespaceSelector == nil {
+		log.V(4).Info("resource namespace %s , optional in case of cluster scope resource", cloneList.Namespace)
 	}
 
-	kinds := clone.CloneList.Kinds
+	kinds := cloneList.Kinds
 	if len(kinds) == 0 {
 		response = append(response, GenerateResponse{
 			Data:   nil,
@@ -690,97 +922,148 @@ func manageCloneList(log logr.Logger, namespace, policy string, ur kyvernov1beta
 		})
 	}
 
+	sourceNamespaces, err := common.CloneListNamespaces(client, cloneList)
+	if err != nil {
+		return append(response, GenerateResponse{
+			Data:   nil,
+			Action: Skip,
+			Error:  fmt.Errorf("failed to list source namespaces. %v", err),
+		})
+	}
+
+	// sources of the same kind must not be cloned to the same name, it happens when sources from different
+	// namespaces have the same name and the name template doesn't reference their namespace
+	sources := map[string]string{}
 	for _, kind := range kinds {
 		apiVersion, kind := kubeutils.GetKindFromGVK(kind)
-		resources, err := client.ListResource(apiVersion, kind, rNamespace, clone.CloneList.Selector)
-		if err != nil {
-			response = append(response, GenerateResponse{
-				Data:   nil,
-				Action: Skip,
-				Error:  fmt.Errorf("failed to list resource %s %s/%s. %v", apiVersion, kind, rNamespace, err),
-			})
-		}
-
-		for _, rName := range resources.Items {
-			if rNamespace == namespace {
-				log.V(4).Info("skip resource self-clone")
-				response = append(response, GenerateResponse{
-					Data:   nil,
-					Action: Skip,
-					Error:  nil,
-				})
-			}
-
-			// check if the resource as reference in clone exists?
-			obj, err := client.GetResource(apiVersion, kind, rNamespace, rName.GetName())
+		for _, rNamespace := range sourceNamespaces {
+			resources, err := client.ListResource(apiVersion, kind, rNamespace, cloneList.Selector)
 			if err != nil {
-				log.Error(err, "failed to get resoruce", apiVersion, "apiVersion", kind, "kind", rNamespace, "rNamespace", rName.GetName(), "name")
 				response = append(response, GenerateResponse{
 					Data:   nil,
 					Action: Skip,
-					Error:  fmt.Errorf("source resource %s %s/%s/%s not found. %v", apiVersion, kind, rNamespace, rName.GetName(), err),
+					Error:  fmt.Errorf("failed to list resource %s %s/%s. %v", apiVersion, kind, rNamespace, err),
 				})
-				return response
+				continue
 			}
 
-			// check if cloned resource exists
-			cobj, err := client.GetResource(apiVersion, kind, namespace, rName.GetName())
-			if apierrors.IsNotFound(err) && len(ur.Status.GeneratedResources) != 0 && !clone.Synchronize {
-				log.V(4).Info("synchronization is disabled, recreation will be skipped", "resource", cobj)
-				response = append(response, GenerateResponse{
-					Data:   nil,
-					Action: Skip,
-					Error:  nil,
-				})
-			}
+			for i := range resources.Items {
+				obj := &resources.Items[i]
 
-			// remove ownerReferences when cloning resources to other namespace
-			if rNamespace != namespace && obj.GetOwnerReferences() != nil {
-				obj.SetOwnerReferences(nil)
-			}
+				// resources generated by the policy are not sources, they may match the selectors
+				if isGeneratedBy(obj, policy) {
+					continue
+				}
 
-			// check if resource to be generated exists
-			newResource, err := client.GetResource(apiVersion, kind, namespace, rName.GetName())
-			if err == nil && newResource != nil {
-				obj.SetUID(newResource.GetUID())
-				obj.SetSelfLink(newResource.GetSelfLink())
-				obj.SetCreationTimestamp(newResource.GetCreationTimestamp())
-				obj.SetManagedFields(newResource.GetManagedFields())
-				obj.SetResourceVersion(newResource.GetResourceVersion())
+				name, err := cloneListTargetName(log, ctx, cloneList.Name, obj)
+				if err != nil {
+					return append(response, GenerateResponse{
+						Data:   nil,
+						Action: Skip,
+						Error:  fmt.Errorf("failed to compute the name of the clone of %s %s/%s/%s. %v", apiVersion, kind, rNamespace, obj.GetName(), err),
+					})
+				}
 
-				if reflect.DeepEqual(obj, newResource) {
-					response = append(response, GenerateResponse{
+				if rNamespace == namespace && name == obj.GetName() {
+					log.V(4).Info("skip resource self-clone")
+					continue
+				}
+
+				target := apiVersion + "/" + kind + "/" + name
+				source := rNamespace + "/" + obj.GetName()
+				if previous, ok := sources[target]; ok {
+					return append(response, GenerateResponse{
 						Data:   nil,
 						Action: Skip,
-						Error:  nil,
+						Error:  fmt.Errorf("the clones of %s %s and %s have the same name %s, the cloneList name must reference {{ source.namespace }}", kind, previous, source, name),
 					})
-				} else {
+				}
+				sources[target] = source
+
+				// check if cloned resource exists
+				newResource, err := client.GetResource(apiVersion, kind, namespace, name)
+				if apierrors.IsNotFound(err) && len(ur.Status.GeneratedResources) != 0 && !clone.Synchronize {
+					log.V(4).Info("synchronization is disabled, recreation will be skipped", "name", name)
+					continue
+				}
+
+				// remove ownerReferences when cloning resources to other namespace
+				if rNamespace != namespace && obj.GetOwnerReferences() != nil {
+					obj.SetOwnerReferences(nil)
+				}
+				obj.SetName(name)
+				obj.SetNamespace(namespace)
+
+				// check if resource to be generated exists
+				if err == nil && newResource != nil {
+					obj.SetUID(newResource.GetUID())
+					obj.SetSelfLink(newResource.GetSelfLink())
+					obj.SetCreationTimestamp(newResource.GetCreationTimestamp())
+					obj.SetManagedFields(newResource.GetManagedFields())
+					obj.SetResourceVersion(newResource.GetResourceVersion())
+
+					if reflect.DeepEqual(obj, newResource) {
+						continue
+					}
 					response = append(response, GenerateResponse{
 						Data:          obj.UnstructuredContent(),
 						Action:        Update,
 						GenKind:       kind,
-						GenName:       rName.GetName(),
+						GenName:       name,
 						GenNamespace:  namespace,
 						GenAPIVersion: apiVersion,
 						Error:         nil,
 					})
+					continue
 				}
+
+				// create the resource based on the reference clone
+				response = append(response, GenerateResponse{
+					Data:          obj.UnstructuredContent(),
+					Action:        Create,
+					GenKind:       kind,
+					GenName:       name,
+					GenNamespace:  namespace,
+					GenAPIVersion: apiVersion,
+					Error:         nil,
+				})
 			}
-			// create the resource based on the reference clone
-			response = append(response, GenerateResponse{
-				Data:          obj.UnstructuredContent(),
-				Action:        Create,
-				GenKind:       kind,
-				GenName:       rName.GetName(),
-				GenNamespace:  namespace,
-				GenAPIVersion: apiVersion,
-				Error:         nil,
-			})
 		}
 	}
 	return response
 }
 
+// cloneListTargetName substitutes the source variables in the name template of a clone list
+func cloneListTargetName(log logr.Logger, ctx context.Interface, template string, source *unstructured.Unstructured) (string, error) {
+	if template == "" {
+		return source.GetName(), nil
+	}
+	ctx.Checkpoint()
+	defer ctx.Restore()
+	if err := ctx.AddVariable("source", map[string]interface{}{
+		"name":      source.GetName(),
+		"namespace": source.GetNamespace(),
+		"kind":      source.GetKind(),
+	}); err != nil {
+		return "", err
+	}
+	name, err := variables.SubstituteAll(log, ctx, template)
+	if err != nil {
+		return "", err
+	}
+	result, ok := name.(string)
+	if !ok || result == "" {
+		return "", fmt.Errorf("name template %s did not resolve to a name", template)
+	}
+	return result, nil
+}
+
+// isGeneratedBy checks if the resource was generated by the policy
+func isGeneratedBy(resource *unstructured.Unstructured, policy string) bool {
+	labels := resource.GetLabels()
+	return labels["app.kubernetes.io/managed-by"] == "kyverno" && labels["policy.kyverno.io/policy-name"] == policy
+}
+
 type GenerateResponse struct {
 	Data                                          map[string]interface{}
 	Action                                        ResourceMode
@@ -838,15 +1121,3 @@ func (c *GenerateController) GetUnstrResource(genResourceSpec kyvernov1.Resource
 	}
 	return resource, nil
 }
-
-func deleteGeneratedResources(log logr.Logger, client dclient.Interface, ur kyvernov1beta1.UpdateRequest) error {
-	for _, genResource := range ur.Status.GeneratedResources {
-		err := client.DeleteResource("", genResource.Kind, genResource.Namespace, genResource.Name, false)
-		if err != nil && !apierrors.IsNotFound(err) {
-			return err
-		}
-
-		log.V(3).Info("generated resource deleted", "genKind", ur.Spec.Resource.Kind, "genNamespace", ur.Spec.Resource.Namespace, "genName", ur.Spec.Resource.Name)
-	}
-	return nil
-}
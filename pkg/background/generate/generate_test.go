@@ -0,0 +1,202 @@
+package generate
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_substituteForEach(t *testing.T) {
+	rawForEach := []byte(`
+	{
+		"list": "request.object.spec.containers",
+		"kind": "ConfigMap",
+		"name": "{{ element.name }}-config",
+		"namespace": "default",
+		"data": {
+			"data": {
+				"image": "{{ element.image }}",
+				"index": "{{ elementIndex }}"
+			}
+		}
+	}`)
+
+	var foreach kyvernov1.ForEachGeneration
+	assert.NilError(t, json.Unmarshal(rawForEach, &foreach))
+
+	ctx := context.NewContext()
+	element := map[string]interface{}{"name": "nginx", "image": "nginx:latest"}
+	assert.NilError(t, ctx.AddElement(element, 1))
+
+	generation, err := substituteForEach(logr.Discard(), ctx, foreach)
+	assert.NilError(t, err)
+	assert.Equal(t, generation.Name, "nginx-config")
+	assert.Equal(t, generation.Namespace, "default")
+	assert.DeepEqual(t, generation.GetData(), map[string]interface{}{
+		"data": map[string]interface{}{
+			"image": "nginx:latest",
+			"index": int64(1),
+		},
+	})
+}
+
+func Test_elementUpdateRequest(t *testing.T) {
+	ur := kyvernov1beta1.UpdateRequest{
+		Status: kyvernov1beta1.UpdateRequestStatus{
+			GeneratedResources: []kyvernov1.ResourceSpec{
+				{Kind: "ConfigMap", Namespace: "default", Name: "nginx-config"},
+				{Kind: "ConfigMap", Namespace: "default", Name: "redis-config"},
+			},
+		},
+	}
+
+	elementUR := elementUpdateRequest(ur, kyvernov1.ResourceSpec{Kind: "ConfigMap", Namespace: "default", Name: "redis-config"})
+	assert.DeepEqual(t, elementUR.Status.GeneratedResources, []kyvernov1.ResourceSpec{{Kind: "ConfigMap", Namespace: "default", Name: "redis-config"}})
+	assert.Equal(t, len(ur.Status.GeneratedResources), 2)
+
+	elementUR = elementUpdateRequest(ur, kyvernov1.ResourceSpec{Kind: "ConfigMap", Namespace: "default", Name: "busybox-config"})
+	assert.Equal(t, len(elementUR.Status.GeneratedResources), 0)
+}
+
+func Test_applyConflictError(t *testing.T) {
+	assert.NilError(t, applyConflictError(nil))
+
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "cm")
+	assert.Equal(t, applyConflictError(notFound), notFound)
+
+	conflict := &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   409,
+		Reason: metav1.StatusReasonConflict,
+		Details: &metav1.StatusDetails{
+			Causes: []metav1.StatusCause{
+				{Type: metav1.CauseTypeFieldManagerConflict, Field: ".data.key", Message: `conflict with "kubectl-edit" using v1`},
+				{Type: metav1.CauseTypeFieldManagerConflict, Field: ".metadata.labels.team", Message: `conflict with "helm" using v1`},
+			},
+		},
+	}}
+	err := applyConflictError(conflict)
+	assert.Error(t, err, `server-side apply conflicts with other field managers: .data.key: conflict with "kubectl-edit" using v1; .metadata.labels.team: conflict with "helm" using v1`)
+
+	other := errors.New("failed")
+	assert.Equal(t, applyConflictError(other), other)
+}
+
+func Test_applyForEach(t *testing.T) {
+	rawRule := []byte(`{
+		"name": "generate-configmaps",
+		"generate": {
+			"synchronize": true,
+			"foreach": [{
+				"list": "request.object.spec.apps",
+				"kind": "ConfigMap",
+				"apiVersion": "v1",
+				"namespace": "default",
+				"name": "{{ element.name }}-config",
+				"data": {
+					"data": {
+						"app": "{{ element.name }}"
+					}
+				}
+			}]
+		}
+	}`)
+	var rule kyvernov1.Rule
+	assert.NilError(t, json.Unmarshal(rawRule, &rule))
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "generate"}}
+	stale := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "stale-config"}
+	other := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "other-config"}
+	newGenerated := func(name, foreachRule string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		obj.SetLabels(map[string]string{
+			kyvernov1.LabelAppManagedBy:     kyvernov1.ValueKyvernoApp,
+			"policy.kyverno.io/synchronize": "enable",
+			common.ForEachRuleLabel:         foreachRule,
+		})
+		return obj
+	}
+
+	testcases := []struct {
+		name      string
+		apps      []interface{}
+		generated []string
+		wantErr   bool
+		pruned    bool
+	}{{
+		name:      "prune-removed-elements",
+		apps:      []interface{}{map[string]interface{}{"name": "nginx"}, map[string]interface{}{"name": "redis"}},
+		generated: []string{"nginx-config", "redis-config"},
+		pruned:    true,
+	}, {
+		name:      "collect-element-errors",
+		apps:      []interface{}{map[string]interface{}{"name": "nginx"}, map[string]interface{}{"image": "busybox"}, map[string]interface{}{"name": "redis"}},
+		generated: []string{"nginx-config", "redis-config"},
+		wantErr:   true,
+		pruned:    false,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"},
+				newGenerated("stale-config", "generate-configmaps"),
+				newGenerated("other-config", "another-rule"),
+			)
+			assert.NilError(t, err)
+			client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+			trigger := unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Application",
+				"metadata":   map[string]interface{}{"name": "app", "namespace": "default"},
+				"spec":       map[string]interface{}{"apps": tc.apps},
+			}}
+			jsonContext := context.NewContext()
+			assert.NilError(t, jsonContext.AddResource(trigger.Object))
+			policyContext := &engine.PolicyContext{Policy: policy, JSONContext: jsonContext}
+			ur := kyvernov1beta1.UpdateRequest{Status: kyvernov1beta1.UpdateRequestStatus{GeneratedResources: []kyvernov1.ResourceSpec{stale, other}}}
+
+			genResources, err := applyForEach(logr.Discard(), client, rule, trigger, policyContext, ur, nil)
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "foreach[0] element 1")
+			} else {
+				assert.NilError(t, err)
+			}
+			var names []string
+			for _, r := range genResources {
+				names = append(names, r.Name)
+			}
+			assert.DeepEqual(t, names, tc.generated)
+			for _, name := range tc.generated {
+				generated, err := client.GetResource("v1", "ConfigMap", "default", name)
+				assert.NilError(t, err)
+				assert.Equal(t, generated.GetLabels()[common.ForEachRuleLabel], "generate-configmaps")
+			}
+
+			_, err = client.GetResource("v1", "ConfigMap", "default", "stale-config")
+			if tc.pruned {
+				assert.Assert(t, apierrors.IsNotFound(err))
+			} else {
+				assert.NilError(t, err)
+			}
+			_, err = client.GetResource("v1", "ConfigMap", "default", "other-config")
+			assert.NilError(t, err)
+		})
+	}
+}
@@ -97,13 +97,17 @@ func (c *MutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 				err := fmt.Errorf("failed to mutate existing resource, rule response%v: %s", r.Status, r.Message)
 				logger.Error(err, "")
 				errs = append(errs, err)
-				c.report(err, ur.Spec.Policy, rule.Name, patched)
+				c.report(err, ur.Spec.Policy, rule.Name, patched, nil)
 
 			case response.RuleStatusSkip:
 				logger.Info("mutate existing rule skipped", "rule", r.Name, "message", r.Message)
-				c.report(err, ur.Spec.Policy, rule.Name, patched)
+				c.report(err, ur.Spec.Policy, rule.Name, patched, nil)
 
 			case response.RuleStatusPass:
+				if len(r.Patches) == 0 {
+					logger.WithName(rule.Name).V(4).Info("target resource is up to date, no patches to apply")
+					continue
+				}
 
 				patchedNew, err := addAnnotation(policy, patched, r)
 				if err != nil {
@@ -127,7 +131,7 @@ func (c *MutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 						logger.WithName(rule.Name).V(4).Info("successfully mutated existing resource", "namespace", patchedNew.GetNamespace(), "name", patchedNew.GetName())
 					}
 
-					c.report(updateErr, ur.Spec.Policy, rule.Name, patched)
+					c.report(updateErr, ur.Spec.Policy, rule.Name, patched, patchedPaths(r))
 				}
 			}
 		}
@@ -150,7 +154,7 @@ func (c *MutateExistingController) getPolicy(key string) (kyvernov1.PolicyInterf
 	return c.policyLister.Get(pName)
 }
 
-func (c *MutateExistingController) report(err error, policy, rule string, target *unstructured.Unstructured) {
+func (c *MutateExistingController) report(err error, policy, rule string, target *unstructured.Unstructured, paths []string) {
 	var events []event.Info
 
 	if target == nil {
@@ -160,7 +164,7 @@ func (c *MutateExistingController) report(err error, policy, rule string, target
 	if err != nil {
 		events = event.NewBackgroundFailedEvent(err, policy, rule, event.MutateExistingController, target)
 	} else {
-		events = event.NewBackgroundSuccessEvent(policy, rule, event.MutateExistingController, target)
+		events = event.NewBackgroundSuccessEvent(policy, rule, event.MutateExistingController, target, paths)
 	}
 
 	c.eventGen.Add(events...)
@@ -179,6 +183,21 @@ func updateURStatus(statusControl common.StatusControlInterface, ur kyvernov1bet
 	return nil
 }
 
+// patchedPaths returns the paths changed by the rule patches
+func patchedPaths(r response.RuleResponse) []string {
+	var paths []string
+	for _, patch := range r.Patches {
+		var patchmap map[string]interface{}
+		if err := json.Unmarshal(patch, &patchmap); err != nil {
+			continue
+		}
+		if path, ok := patchmap["path"].(string); ok {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
 func addAnnotation(policy kyvernov1.PolicyInterface, patched *unstructured.Unstructured, r response.RuleResponse) (patchedNew *unstructured.Unstructured, err error) {
 	if patched == nil {
 		return
@@ -0,0 +1,199 @@
+package background
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// memberLabels identify the replicas of the Kyverno deployment, other labels can differ between the
+// revisions of the deployment (pod-template-hash, version) or be added to the pods by other tools
+var memberLabels = []string{"app.kubernetes.io/name", "app.kubernetes.io/instance"}
+
+// sharder assigns update requests to the live Kyverno replicas using rendezvous hashing,
+// when a replica comes or goes only the requests it owns, or will own, change hands
+type sharder struct {
+	podLister corev1listers.PodNamespaceLister
+	self      string
+
+	lock    sync.Mutex
+	members []string
+}
+
+func newSharder(podLister corev1listers.PodLister, self string) *sharder {
+	return &sharder{
+		podLister: podLister.Pods(config.KyvernoNamespace()),
+		self:      self,
+		members:   []string{self},
+	}
+}
+
+// refresh computes the live replicas, it returns true if they changed since the last refresh
+func (s *sharder) refresh() (bool, error) {
+	members, err := s.liveMembers()
+	if err != nil {
+		return false, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if equalMembers(s.members, members) {
+		return false, nil
+	}
+	s.members = members
+	return true, nil
+}
+
+// liveMembers returns the sorted names of the ready replicas, the current replica is always a member.
+// The replicas are the pods sharing the name and instance labels of the current replica, when they
+// are not set the current replica is the only member.
+func (s *sharder) liveMembers() ([]string, error) {
+	self, err := s.podLister.Get(s.self)
+	if err != nil {
+		return []string{s.self}, nil
+	}
+	selector := labels.Set{}
+	for _, key := range memberLabels {
+		value, ok := self.GetLabels()[key]
+		if !ok {
+			logger.V(3).Info("replica has no label to select the other replicas", "label", key)
+			return []string{s.self}, nil
+		}
+		selector[key] = value
+	}
+	pods, err := s.podLister.List(labels.SelectorFromSet(selector))
+	if err != nil {
+		return nil, err
+	}
+	members := []string{s.self}
+	for _, pod := range pods {
+		if pod.GetName() != s.self && isPodReady(pod) {
+			members = append(members, pod.GetName())
+		}
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// snapshot returns the live replicas computed by the last refresh
+func (s *sharder) snapshot() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.members
+}
+
+// owner returns the replica owning the update request key
+func (s *sharder) owner(key string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return rendezvousOwner(key, s.members)
+}
+
+// owns returns true if the current replica owns the update request key
+func (s *sharder) owns(key string) bool {
+	return s.owner(key) == s.self
+}
+
+func rendezvousOwner(key string, members []string) string {
+	var owner string
+	var max uint64
+	for _, member := range members {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(member))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(key))
+		if weight := h.Sum64(); owner == "" || weight > max {
+			owner, max = member, weight
+		}
+	}
+	return owner
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.GetDeletionTimestamp() != nil || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func containsMember(members []string, member string) bool {
+	i := sort.SearchStrings(members, member)
+	return i < len(members) && members[i] == member
+}
+
+func equalMembers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// rebalance refreshes the replicas and requeues the pending update requests when they changed,
+// so that the requests of a replica that left are picked up by their new owner. The requests in
+// other states still held by a replica that left are requeued too, so that the new owner releases
+// them and they can be garbage collected or requeued on trigger deletion.
+func (c *controller) rebalance() {
+	changed, err := c.sharder.refresh()
+	if err != nil {
+		logger.Error(err, "failed to list kyverno replicas")
+		return
+	}
+	if !changed {
+		return
+	}
+	urs, err := c.urLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list update requests")
+		return
+	}
+	members := c.sharder.snapshot()
+	var owned int64
+	for _, ur := range urs {
+		if urState(ur) != kyvernov1beta1.Pending {
+			if ur.Status.Handler != "" && !containsMember(members, ur.Status.Handler) {
+				c.enqueueUpdateRequest(ur)
+			}
+			continue
+		}
+		c.enqueueUpdateRequest(ur)
+		if c.sharder.owns(urKey(ur)) {
+			owned++
+		}
+	}
+	logger.Info("kyverno replicas changed, update requests rebalanced", "members", members, "owned", owned)
+	if c.metricsConfig != nil {
+		c.metricsConfig.RecordUpdateRequestShardPending(c.sharder.self, owned)
+	}
+}
+
+func (c *controller) onPodEvent(obj interface{}) {
+	c.rebalance()
+}
+
+func (c *controller) onPodUpdate(_, obj interface{}) {
+	c.rebalance()
+}
+
+// ownsUR returns true if the update request is handled by the current replica
+func (c *controller) ownsUR(ur *kyvernov1beta1.UpdateRequest) bool {
+	return c.sharder == nil || c.sharder.owns(urKey(ur))
+}
+
+func urKey(ur *kyvernov1beta1.UpdateRequest) string {
+	return ur.GetNamespace() + "/" + ur.GetName()
+}
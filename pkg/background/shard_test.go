@@ -0,0 +1,146 @@
+package background
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func Test_rendezvousOwner(t *testing.T) {
+	members := []string{"kyverno-a", "kyverno-b", "kyverno-c"}
+	owners := map[string]string{}
+	counts := map[string]int{}
+	for i := 0; i < 300; i++ {
+		key := fmt.Sprintf("kyverno/ur-%d", i)
+		owners[key] = rendezvousOwner(key, members)
+		counts[owners[key]]++
+		// the owner does not depend on the order of the members
+		assert.Equal(t, rendezvousOwner(key, []string{"kyverno-c", "kyverno-a", "kyverno-b"}), owners[key])
+	}
+	for _, member := range members {
+		assert.Assert(t, counts[member] > 50, "member %s owns %d keys", member, counts[member])
+	}
+	// when a replica leaves, only its keys change hands
+	for key, owner := range owners {
+		newOwner := rendezvousOwner(key, []string{"kyverno-a", "kyverno-c"})
+		if owner != "kyverno-b" {
+			assert.Equal(t, newOwner, owner)
+		} else {
+			assert.Assert(t, newOwner != "kyverno-b")
+		}
+	}
+	assert.Equal(t, rendezvousOwner("kyverno/ur-1", nil), "")
+}
+
+func Test_sharder_refresh(t *testing.T) {
+	newPod := func(name, hash string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: config.KyvernoNamespace(),
+				Labels: map[string]string{
+					"app.kubernetes.io/name":     "kyverno",
+					"app.kubernetes.io/instance": "kyverno",
+					"app.kubernetes.io/version":  hash,
+					"pod-template-hash":          hash,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+	other := newPod("kyverno-cleanup", "abc", true)
+	other.Labels["app.kubernetes.io/name"] = "kyverno-cleanup-controller"
+	otherInstance := newPod("kyverno-other", "abc", true)
+	otherInstance.Labels["app.kubernetes.io/instance"] = "kyverno-other"
+	// labels added to a single replica don't exclude it
+	b := newPod("kyverno-b", "def", true)
+	b.Labels["sidecar.istio.io/inject"] = "true"
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pod := range []*corev1.Pod{newPod("kyverno-a", "abc", true), b, newPod("kyverno-c", "abc", false), other, otherInstance} {
+		assert.NilError(t, indexer.Add(pod))
+	}
+	s := newSharder(corev1listers.NewPodLister(indexer), "kyverno-a")
+
+	changed, err := s.refresh()
+	assert.NilError(t, err)
+	assert.Assert(t, changed)
+	assert.DeepEqual(t, s.snapshot(), []string{"kyverno-a", "kyverno-b"})
+
+	changed, err = s.refresh()
+	assert.NilError(t, err)
+	assert.Assert(t, !changed)
+
+	assert.NilError(t, indexer.Update(newPod("kyverno-c", "abc", true)))
+	changed, err = s.refresh()
+	assert.NilError(t, err)
+	assert.Assert(t, changed)
+	assert.DeepEqual(t, s.snapshot(), []string{"kyverno-a", "kyverno-b", "kyverno-c"})
+	assert.Equal(t, s.owns("kyverno/ur-1"), s.owner("kyverno/ur-1") == "kyverno-a")
+}
+
+func Test_rebalance(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kyverno-a",
+			Namespace: config.KyvernoNamespace(),
+			Labels:    map[string]string{"app.kubernetes.io/name": "kyverno", "app.kubernetes.io/instance": "kyverno"},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NilError(t, podIndexer.Add(pod))
+	newUR := func(name string, state kyvernov1beta1.UpdateRequestState, handler string) *kyvernov1beta1.UpdateRequest {
+		return &kyvernov1beta1.UpdateRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: config.KyvernoNamespace()},
+			Status:     kyvernov1beta1.UpdateRequestStatus{State: state, Handler: handler},
+		}
+	}
+	urIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, ur := range []*kyvernov1beta1.UpdateRequest{
+		newUR("pending", kyvernov1beta1.Pending, ""),
+		newUR("failed-departed", kyvernov1beta1.Failed, "kyverno-b"),
+		newUR("failed-live", kyvernov1beta1.Failed, "kyverno-a"),
+		newUR("failed-released", kyvernov1beta1.Failed, ""),
+		newUR("completed", kyvernov1beta1.Completed, ""),
+	} {
+		assert.NilError(t, urIndexer.Add(ur))
+	}
+	c := controller{
+		urLister: kyvernov1beta1listers.NewUpdateRequestLister(urIndexer).UpdateRequests(config.KyvernoNamespace()),
+		queue:    workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		sharder:  newSharder(corev1listers.NewPodLister(podIndexer), "kyverno-a"),
+	}
+	// kyverno-b left the replicas
+	c.sharder.members = []string{"kyverno-a", "kyverno-b"}
+
+	c.rebalance()
+
+	var keys []string
+	for c.queue.Len() > 0 {
+		key, _ := c.queue.Get()
+		keys = append(keys, key.(string))
+		c.queue.Done(key)
+	}
+	sort.Strings(keys)
+	assert.DeepEqual(t, keys, []string{config.KyvernoNamespace() + "/failed-departed", config.KyvernoNamespace() + "/pending"})
+}
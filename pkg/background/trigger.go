@@ -0,0 +1,114 @@
+package background
+
+import (
+	"context"
+	"sync"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+)
+
+// triggerWatcher watches the kinds of the trigger resources of generate requests,
+// the informers are only created for the kinds referenced by update requests
+type triggerWatcher struct {
+	factory metadatainformer.SharedInformerFactory
+	stopCh  <-chan struct{}
+
+	lock    sync.Mutex
+	watched map[schema.GroupVersionResource]struct{}
+}
+
+func newTriggerWatcher(factory metadatainformer.SharedInformerFactory) *triggerWatcher {
+	return &triggerWatcher{
+		factory: factory,
+		watched: map[schema.GroupVersionResource]struct{}{},
+	}
+}
+
+// watchTrigger starts watching the kind of the trigger resource of a generate request, the generated
+// resources are cleaned up from the delete event once the deletion of the trigger is effective
+func (c *controller) watchTrigger(ur *kyvernov1beta1.UpdateRequest) {
+	if c.triggers == nil || c.triggers.stopCh == nil || ur.Spec.Type != kyvernov1beta1.Generate {
+		return
+	}
+	spec := ur.Spec.Resource
+	gvr := c.client.Discovery().GetGVRFromAPIVersionKind(spec.APIVersion, spec.Kind)
+	if gvr.Resource == "" {
+		logger.V(3).Info("failed to find the resource of the trigger kind", "apiVersion", spec.APIVersion, "kind", spec.Kind)
+		return
+	}
+
+	c.triggers.lock.Lock()
+	defer c.triggers.lock.Unlock()
+	if _, ok := c.triggers.watched[gvr]; ok {
+		return
+	}
+	c.triggers.watched[gvr] = struct{}{}
+	c.triggers.factory.ForResource(gvr).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			c.deleteTrigger(spec.Kind, obj)
+		},
+	})
+	// starting the factory only starts the informers which are not running yet
+	c.triggers.factory.Start(c.triggers.stopCh)
+	logger.V(2).Info("watching trigger resources", "gvr", gvr)
+}
+
+// deleteTrigger moves the generate requests of a deleted trigger back to the pending state,
+// processing them cleans up the generated resources based on the deletion policy of the rule
+func (c *controller) deleteTrigger(kind string, obj interface{}) {
+	trigger, err := meta.Accessor(kubeutils.GetObjectWithTombstone(obj))
+	if err != nil {
+		logger.Error(err, "failed to get deleted trigger")
+		return
+	}
+	selector := labels.SelectorFromSet(labels.Set(map[string]string{
+		kyvernov1beta1.URGenerateResourceNameLabel: trigger.GetName(),
+		kyvernov1beta1.URGenerateResourceKindLabel: kind,
+		kyvernov1beta1.URGenerateResourceNSLabel:   trigger.GetNamespace(),
+	}))
+	urs, err := c.urLister.List(selector)
+	if err != nil {
+		logger.Error(err, "failed to list update requests of the trigger", "kind", kind, "namespace", trigger.GetNamespace(), "name", trigger.GetName())
+		return
+	}
+	for _, ur := range urs {
+		if ur.Spec.Type != kyvernov1beta1.Generate || len(ur.Status.GeneratedResources) == 0 || !c.ownsUR(ur) {
+			continue
+		}
+		if ur.Status.State == kyvernov1beta1.Pending {
+			c.enqueueUpdateRequest(ur)
+			continue
+		}
+		if err := c.setPending(ur.GetName()); err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to requeue update request of deleted trigger", "ur", ur.GetName())
+		}
+	}
+}
+
+func (c *controller) setPending(name string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ur, err := c.urLister.Get(name)
+		if err != nil {
+			return err
+		}
+		if ur.Status.State == kyvernov1beta1.Pending {
+			return nil
+		}
+		ur = ur.DeepCopy()
+		ur.Status.State = kyvernov1beta1.Pending
+		ur.Status.Handler = ""
+		ur.Status.RetryCount = 0
+		_, err = c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), ur, metav1.UpdateOptions{})
+		return err
+	})
+}
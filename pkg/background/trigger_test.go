@@ -0,0 +1,57 @@
+package background
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func Test_deleteTrigger(t *testing.T) {
+	newUR := func(name, trigger string, state kyvernov1beta1.UpdateRequestState) *kyvernov1beta1.UpdateRequest {
+		return &kyvernov1beta1.UpdateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: config.KyvernoNamespace(),
+				Labels: map[string]string{
+					kyvernov1beta1.URGenerateResourceNameLabel: trigger,
+					kyvernov1beta1.URGenerateResourceKindLabel: "ConfigMap",
+					kyvernov1beta1.URGenerateResourceNSLabel:   "default",
+				},
+			},
+			Spec: kyvernov1beta1.UpdateRequestSpec{Type: kyvernov1beta1.Generate, Policy: "generate"},
+			Status: kyvernov1beta1.UpdateRequestStatus{
+				State:              state,
+				GeneratedResources: []kyvernov1.ResourceSpec{{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "generated"}},
+			},
+		}
+	}
+	deleted := newUR("ur-deleted", "trigger", kyvernov1beta1.Completed)
+	other := newUR("ur-other", "other", kyvernov1beta1.Completed)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NilError(t, indexer.Add(deleted))
+	assert.NilError(t, indexer.Add(other))
+	c := controller{
+		kyvernoClient: fake.NewSimpleClientset(deleted, other),
+		urLister:      kyvernov1beta1listers.NewUpdateRequestLister(indexer).UpdateRequests(config.KyvernoNamespace()),
+		queue:         workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	trigger := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "trigger", Namespace: "default"}}
+	c.deleteTrigger("ConfigMap", cache.DeletedFinalStateUnknown{Key: "default/trigger", Obj: trigger})
+
+	ur, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), "ur-deleted", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, ur.Status.State, kyvernov1beta1.Pending)
+	ur, err = c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), "ur-other", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, ur.Status.State, kyvernov1beta1.Completed)
+}
@@ -7,6 +7,7 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/autogen"
 	common "github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/background/generate"
 	"github.com/kyverno/kyverno/pkg/background/mutate"
@@ -19,6 +20,7 @@ import (
 	pkgCommon "github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,12 +29,14 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
 )
 
 const (
+	// maxRetries is the number of attempts to process an update request before it is moved to the Failed state
 	maxRetries = 10
 )
 
@@ -61,6 +65,18 @@ type controller struct {
 
 	eventGen      event.Interface
 	configuration config.Configuration
+	metricsConfig metrics.MetricsConfigManager
+
+	// drift detection of synchronized generated resources
+	driftInterval    time.Duration
+	driftAutoHeal    bool
+	admissionReports bool
+
+	// sharding of update requests across replicas, nil when disabled
+	sharder *sharder
+
+	// watches the trigger resources of generate requests to clean up on deletion, nil when disabled
+	triggers *triggerWatcher
 }
 
 // NewController returns an instance of the Generate-Request Controller
@@ -72,27 +88,48 @@ func NewController(
 	urInformer kyvernov1beta1informers.UpdateRequestInformer,
 	namespaceInformer corev1informers.NamespaceInformer,
 	podInformer corev1informers.PodInformer,
+	triggerInformer metadatainformer.SharedInformerFactory,
 	eventGen event.Interface,
 	dynamicConfig config.Configuration,
+	metricsConfig metrics.MetricsConfigManager,
+	driftInterval time.Duration,
+	driftAutoHeal bool,
+	admissionReports bool,
+	sharding bool,
 ) Controller {
 	urLister := urInformer.Lister().UpdateRequests(config.KyvernoNamespace())
 	c := controller{
-		client:        client,
-		kyvernoClient: kyvernoClient,
-		cpolLister:    cpolInformer.Lister(),
-		polLister:     polInformer.Lister(),
-		urLister:      urLister,
-		nsLister:      namespaceInformer.Lister(),
-		podLister:     podInformer.Lister(),
-		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "update-request"),
-		eventGen:      eventGen,
-		configuration: dynamicConfig,
+		client:           client,
+		kyvernoClient:    kyvernoClient,
+		cpolLister:       cpolInformer.Lister(),
+		polLister:        polInformer.Lister(),
+		urLister:         urLister,
+		nsLister:         namespaceInformer.Lister(),
+		podLister:        podInformer.Lister(),
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "update-request"),
+		eventGen:         eventGen,
+		configuration:    dynamicConfig,
+		metricsConfig:    metricsConfig,
+		driftInterval:    driftInterval,
+		driftAutoHeal:    driftAutoHeal,
+		admissionReports: admissionReports,
+	}
+	if triggerInformer != nil {
+		c.triggers = newTriggerWatcher(triggerInformer)
 	}
 	urInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addUR,
 		UpdateFunc: c.updateUR,
 		DeleteFunc: c.deleteUR,
 	})
+	if sharding {
+		c.sharder = newSharder(podInformer.Lister(), config.KyvernoPodName())
+		podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.onPodEvent,
+			UpdateFunc: c.onPodUpdate,
+			DeleteFunc: c.onPodEvent,
+		})
+	}
 	cpolInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		UpdateFunc: c.updatePolicy,
 		DeleteFunc: c.deletePolicy,
@@ -118,13 +155,44 @@ func (c *controller) Run(ctx context.Context, workers int) {
 		return
 	}
 
+	if c.triggers != nil {
+		c.triggers.stopCh = ctx.Done()
+	}
+
 	for i := 0; i < workers; i++ {
 		go wait.UntilWithContext(ctx, c.worker, time.Second)
 	}
 
+	if c.driftInterval > 0 {
+		go wait.UntilWithContext(ctx, c.checkDrift, c.driftInterval)
+	}
+
 	<-ctx.Done()
 }
 
+// checkDrift compares the synchronized resources of completed generate requests with their desired state
+func (c *controller) checkDrift(ctx context.Context) {
+	urs, err := c.urLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list update requests")
+		return
+	}
+	for _, ur := range urs {
+		if ur.Spec.Type != kyvernov1beta1.Generate || ur.Status.State != kyvernov1beta1.Completed || len(ur.Status.GeneratedResources) == 0 {
+			continue
+		}
+		if !c.ownsUR(ur) {
+			continue
+		}
+		// the check only reads the update request, its status is not updated
+		statusControl := common.NewStatusControl(c.kyvernoClient, c.urLister)
+		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger)
+		if err := ctrl.CheckDrift(ur.DeepCopy(), c.driftAutoHeal, c.admissionReports); err != nil {
+			logger.Error(err, "failed to check drift of generated resources", "ur", ur.GetName())
+		}
+	}
+}
+
 // worker runs a worker thread that just dequeues items, processes them, and marks them done.
 // It enforces that the syncHandler is never invoked concurrently with the same key.
 func (c *controller) worker(ctx context.Context) {
@@ -156,6 +224,27 @@ func (c *controller) handleErr(err error, key interface{}) {
 		return
 	}
 
+	ur, recorded, recordErr := c.recordFailedAttempt(key.(string), err)
+	if recordErr != nil {
+		if apierrors.IsNotFound(recordErr) {
+			c.queue.Forget(key)
+			return
+		}
+		logger.Error(recordErr, "failed to record failed attempt in update request status", "key", key)
+	}
+	if recorded {
+		if ur.Status.State != kyvernov1beta1.Failed {
+			logger.V(3).Info("retrying update request", "key", key, "attempts", ur.Status.RetryCount, "error", err.Error())
+			c.queue.AddRateLimited(key)
+			return
+		}
+		logger.Error(err, "failed to process update request, moved to failed state", "key", key, "attempts", ur.Status.RetryCount)
+		c.reportFailure(ur, err)
+		c.queue.Forget(key)
+		return
+	}
+
+	// the attempt could not be recorded in the update request status, rely on the queue to bound the retries
 	if c.queue.NumRequeues(key) < maxRetries {
 		logger.V(3).Info("retrying update request", "key", key, "error", err.Error())
 		c.queue.AddRateLimited(key)
@@ -166,6 +255,58 @@ func (c *controller) handleErr(err error, key interface{}) {
 	c.queue.Forget(key)
 }
 
+// recordFailedAttempt increments the retry count of a pending update request and stores the error,
+// the request is moved to the Failed state and released when it has no retries left
+func (c *controller) recordFailedAttempt(key string, cause error) (*kyvernov1beta1.UpdateRequest, bool, error) {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+	var ur *kyvernov1beta1.UpdateRequest
+	recorded := false
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := c.urLister.Get(name)
+		if err != nil {
+			return err
+		}
+		if current.Status.State != kyvernov1beta1.Pending {
+			return nil
+		}
+		current = current.DeepCopy()
+		current.Status.RetryCount++
+		current.Status.LastError = cause.Error()
+		if current.Status.RetryCount >= maxRetries {
+			current.Status.State = kyvernov1beta1.Failed
+			current.Status.Message = fmt.Sprintf("failed after %d attempts: %v", current.Status.RetryCount, cause)
+			current.Status.Handler = ""
+		}
+		updated, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), current, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		ur, recorded = updated, true
+		return nil
+	})
+	return ur, recorded, err
+}
+
+// reportFailure emits an event on the policy and records a metric for an update request moved to the Failed state
+func (c *controller) reportFailure(ur *kyvernov1beta1.UpdateRequest, cause error) {
+	policyNamespace, policyName, err := cache.SplitMetaNamespaceKey(ur.Spec.Policy)
+	if err != nil {
+		logger.Error(err, "failed to parse policy name", "policy", ur.Spec.Policy)
+		return
+	}
+	source := event.GeneratePolicyController
+	if ur.Spec.Type == kyvernov1beta1.Mutate {
+		source = event.MutateExistingController
+	}
+	c.eventGen.Add(event.NewUpdateRequestFailedEvent(source, policyNamespace, policyName, ur.GetName(), cause))
+	if c.metricsConfig != nil {
+		c.metricsConfig.RecordUpdateRequestFailures(string(ur.Spec.Type), policyNamespace, policyName)
+	}
+}
+
 func (c *controller) syncUpdateRequest(key string) error {
 	startTime := time.Now()
 	logger.V(4).Info("started sync", "key", key, "startTime", startTime)
@@ -180,6 +321,12 @@ func (c *controller) syncUpdateRequest(key string) error {
 	if err != nil {
 		return err
 	}
+	// the update request belongs to the shard of another replica
+	if !c.ownsUR(ur) {
+		logger.V(4).Info("update request is owned by another replica", "key", key, "owner", c.sharder.owner(key))
+		return nil
+	}
+	c.watchTrigger(ur)
 
 	// if not in any state, try to set it to pending
 	if ur.Status.State == "" {
@@ -236,6 +383,9 @@ func (c *controller) syncUpdateRequest(key string) error {
 		if err := c.processUR(ur); err != nil {
 			return fmt.Errorf("failed to process UR %s: %v", key, err)
 		}
+		if c.sharder != nil && c.metricsConfig != nil {
+			c.metricsConfig.RecordUpdateRequestShardProcessed(c.sharder.self, string(ur.Spec.Type))
+		}
 	}
 	ur, err = c.releaseUR(ur)
 	if err != nil {
@@ -262,9 +412,9 @@ func (c *controller) checkIfCleanupRequired(ur *kyvernov1beta1.UpdateRequest) er
 			return err
 		}
 
-		logger.V(4).Info("policy no longer exists, deleting the update request and respective resource based on synchronize", "ur", ur.Name, "policy", ur.Spec.Policy)
+		logger.V(4).Info("policy no longer exists, deleting the update request and respective resource based on the deletion policy", "ur", ur.Name, "policy", ur.Spec.Policy)
 		for _, e := range ur.Status.GeneratedResources {
-			if err := c.cleanupDataResource(e); err != nil {
+			if err := common.CleanupGeneratedResource(logger, c.client, e); err != nil {
 				logger.Error(err, "failed to clean up data resource on policy deletion")
 			}
 		}
@@ -273,31 +423,6 @@ func (c *controller) checkIfCleanupRequired(ur *kyvernov1beta1.UpdateRequest) er
 	return nil
 }
 
-// cleanupDataResource deletes resource if sync is enabled for data policy
-func (c *controller) cleanupDataResource(targetSpec kyvernov1.ResourceSpec) error {
-	target, err := c.client.GetResource(targetSpec.APIVersion, targetSpec.Kind, targetSpec.Namespace, targetSpec.Name)
-	if err != nil {
-		if !apierrors.IsNotFound(err) {
-			return fmt.Errorf("failed to find generated resource %s/%s: %v", targetSpec.Namespace, targetSpec.Name, err)
-		}
-	}
-
-	if target == nil {
-		return nil
-	}
-
-	labels := target.GetLabels()
-	syncEnabled := labels["policy.kyverno.io/synchronize"] == "enable"
-	clone := labels["generate.kyverno.io/clone-policy-name"] != ""
-
-	if syncEnabled && !clone {
-		if err := c.client.DeleteResource(target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target.GetName(), false); err != nil {
-			return fmt.Errorf("failed to delete data resource %s/%s: %v", targetSpec.Namespace, targetSpec.Name, err)
-		}
-	}
-	return nil
-}
-
 func (c *controller) enqueueUpdateRequest(obj interface{}) {
 	key, err := cache.MetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -327,13 +452,13 @@ func (c *controller) updatePolicy(_, obj interface{}) {
 }
 
 func (c *controller) deletePolicy(obj interface{}) {
-	p, ok := kubeutils.GetObjectWithTombstone(obj).(*kyvernov1.ClusterPolicy)
+	p, ok := kubeutils.GetObjectWithTombstone(obj).(kyvernov1.PolicyInterface)
 	if !ok {
 		logger.Info("Failed to get deleted object", "obj", obj)
 		return
 	}
 
-	logger.V(4).Info("deleting policy", "name", p.Name)
+	logger.V(4).Info("deleting policy", "name", p.GetName())
 	key, err := cache.MetaNamespaceKeyFunc(kubeutils.GetObjectWithTombstone(obj))
 	if err != nil {
 		logger.Error(err, "failed to compute policy key")
@@ -345,7 +470,7 @@ func (c *controller) deletePolicy(obj interface{}) {
 
 		// get the generated resource name from update request
 		selector := labels.SelectorFromSet(labels.Set(map[string]string{
-			kyvernov1beta1.URGeneratePolicyLabel: p.Name,
+			kyvernov1beta1.URGeneratePolicyLabel: p.GetName(),
 		}))
 
 		urList, err := c.urLister.List(selector)
@@ -354,7 +479,8 @@ func (c *controller) deletePolicy(obj interface{}) {
 			return
 		}
 
-		if !generatePolicyWithClone {
+		// generated resources of clone policies are retained, unless a deletion policy is declared
+		if !generatePolicyWithClone || hasGenerateDeletionPolicy(p) {
 			// re-evaluate the UR as the policy was updated
 			for _, ur := range urList {
 				logger.V(4).Info("enqueue the ur for cleanup", "ur name", ur.Name)
@@ -370,13 +496,27 @@ func (c *controller) deletePolicy(obj interface{}) {
 	}
 }
 
+func hasGenerateDeletionPolicy(policy kyvernov1.PolicyInterface) bool {
+	for _, rule := range autogen.ComputeRules(policy) {
+		if rule.HasGenerate() && rule.Generation.DeletionPolicy != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *controller) addUR(obj interface{}) {
 	ur := obj.(*kyvernov1beta1.UpdateRequest)
 	c.enqueueUpdateRequest(ur)
 }
 
-func (c *controller) updateUR(_, cur interface{}) {
+func (c *controller) updateUR(old, cur interface{}) {
+	oldUr := old.(*kyvernov1beta1.UpdateRequest)
 	curUr := cur.(*kyvernov1beta1.UpdateRequest)
+	// a failed attempt was recorded, the request was already requeued with backoff
+	if curUr.Status.RetryCount > oldUr.Status.RetryCount {
+		return
+	}
 	c.enqueueUpdateRequest(curUr)
 }
 
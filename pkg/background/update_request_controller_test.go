@@ -0,0 +1,54 @@
+package background
+
+import (
+	"errors"
+	"testing"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_recordFailedAttempt(t *testing.T) {
+	ur := &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "ur-test", Namespace: config.KyvernoNamespace()},
+		Spec:       kyvernov1beta1.UpdateRequestSpec{Type: kyvernov1beta1.Generate, Policy: "add-networkpolicy"},
+		Status:     kyvernov1beta1.UpdateRequestStatus{State: kyvernov1beta1.Pending, Handler: "kyverno-pod"},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NilError(t, indexer.Add(ur))
+	c := controller{
+		kyvernoClient: fake.NewSimpleClientset(ur),
+		urLister:      kyvernov1beta1listers.NewUpdateRequestLister(indexer).UpdateRequests(config.KyvernoNamespace()),
+	}
+	key := config.KyvernoNamespace() + "/ur-test"
+	cause := errors.New("trigger not found")
+
+	for i := 1; i < maxRetries; i++ {
+		updated, recorded, err := c.recordFailedAttempt(key, cause)
+		assert.NilError(t, err)
+		assert.Assert(t, recorded)
+		assert.Equal(t, updated.Status.RetryCount, i)
+		assert.Equal(t, updated.Status.LastError, "trigger not found")
+		assert.Equal(t, updated.Status.State, kyvernov1beta1.Pending)
+		assert.NilError(t, indexer.Update(updated))
+	}
+
+	updated, recorded, err := c.recordFailedAttempt(key, cause)
+	assert.NilError(t, err)
+	assert.Assert(t, recorded)
+	assert.Equal(t, updated.Status.RetryCount, maxRetries)
+	assert.Equal(t, updated.Status.State, kyvernov1beta1.Failed)
+	assert.Equal(t, updated.Status.Handler, "")
+	assert.Equal(t, updated.Status.Message, "failed after 10 attempts: trigger not found")
+	assert.NilError(t, indexer.Update(updated))
+
+	// requests that are not pending anymore are left untouched
+	_, recorded, err = c.recordFailedAttempt(key, cause)
+	assert.NilError(t, err)
+	assert.Assert(t, !recorded)
+}
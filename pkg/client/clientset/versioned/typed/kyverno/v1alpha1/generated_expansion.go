@@ -21,3 +21,5 @@ package v1alpha1
 type CleanupPolicyExpansion interface{}
 
 type ClusterCleanupPolicyExpansion interface{}
+
+type PolicyExceptionExpansion interface{}
@@ -32,11 +32,14 @@ type Interface interface {
 	Discovery() IDiscovery
 	// SetDiscovery sets the discovery client implementation
 	SetDiscovery(discoveryClient IDiscovery)
-	RawAbsPath(path string) ([]byte, error)
+	// RawAbsPath performs a raw GET request on the given absolute path
+	RawAbsPath(ctx context.Context, path string) ([]byte, error)
 	// GetResource returns the resource in unstructured/json format
 	GetResource(apiVersion string, kind string, namespace string, name string, subresources ...string) (*unstructured.Unstructured, error)
 	// PatchResource patches the resource
 	PatchResource(apiVersion string, kind string, namespace string, name string, patch []byte) (*unstructured.Unstructured, error)
+	// ApplyResource applies the object for the specified resource/namespace with server-side apply
+	ApplyResource(apiVersion string, kind string, namespace string, name string, obj interface{}, fieldManager string, force bool, dryRun bool) (*unstructured.Unstructured, error)
 	// ListResource returns the list of resources in unstructured/json format
 	// Access items using []Items
 	ListResource(apiVersion string, kind string, namespace string, lselector *metav1.LabelSelector) (*unstructured.UnstructuredList, error)
@@ -141,11 +144,11 @@ func (c *client) GetResource(apiVersion string, kind string, namespace string, n
 	return c.getResourceInterface(apiVersion, kind, namespace).Get(context.TODO(), name, metav1.GetOptions{}, subresources...)
 }
 
-func (c *client) RawAbsPath(path string) ([]byte, error) {
+func (c *client) RawAbsPath(ctx context.Context, path string) ([]byte, error) {
 	if c.restClient == nil {
 		return nil, errors.New("rest client not supported")
 	}
-	return c.restClient.Get().RequestURI(path).DoRaw(context.TODO())
+	return c.restClient.Get().RequestURI(path).DoRaw(ctx)
 }
 
 // PatchResource patches the resource
@@ -154,6 +157,24 @@ func (c *client) PatchResource(apiVersion string, kind string, namespace string,
 	return c.getResourceInterface(apiVersion, kind, namespace).Patch(context.TODO(), name, types.JSONPatchType, patch, metav1.PatchOptions{})
 }
 
+// ApplyResource applies the object for the specified resource/namespace with server-side apply
+func (c *client) ApplyResource(apiVersion string, kind string, namespace string, name string, obj interface{}, fieldManager string, force bool, dryRun bool) (*unstructured.Unstructured, error) {
+	options := metav1.PatchOptions{FieldManager: fieldManager, Force: &force}
+	if dryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+	unstructuredObj, err := kubeutils.ConvertToUnstructured(obj)
+	if err != nil || unstructuredObj == nil {
+		return nil, fmt.Errorf("unable to apply resource ")
+	}
+	data, err := unstructuredObj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	c.RecordClientQuery(metrics.ClientUpdate, metrics.KubeDynamicClient, kind, namespace)
+	return c.getResourceInterface(apiVersion, kind, namespace).Patch(context.TODO(), name, types.ApplyPatchType, data, options)
+}
+
 // GetDynamicInterface fetches underlying dynamic interface
 func (c *client) GetDynamicInterface() dynamic.Interface {
 	return c.client
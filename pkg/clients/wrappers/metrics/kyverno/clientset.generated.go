@@ -119,6 +119,10 @@ func (c *wrappedKyvernoV1alpha1) ClusterCleanupPolicies() github_com_kyverno_kyv
 	recorder := github_com_kyverno_kyverno_pkg_metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterCleanupPolicy", c.clientType)
 	return newKyvernoV1alpha1ClusterCleanupPolicies(c.inner.ClusterCleanupPolicies(), recorder)
 }
+func (c *wrappedKyvernoV1alpha1) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha1.PolicyExceptionInterface {
+	recorder := github_com_kyverno_kyverno_pkg_metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "PolicyException", c.clientType)
+	return newKyvernoV1alpha1PolicyExceptions(c.inner.PolicyExceptions(namespace), recorder)
+}
 
 // RESTClient is NOT instrumented
 func (c *wrappedKyvernoV1alpha1) RESTClient() k8s_io_client_go_rest.Interface {
@@ -431,6 +435,48 @@ func (c *wrappedKyvernoV1alpha1ClusterCleanupPolicies) Watch(arg0 context.Contex
 	return c.inner.Watch(arg0, arg1)
 }
 
+// wrappedKyvernoV1alpha1PolicyExceptions wrapper
+type wrappedKyvernoV1alpha1PolicyExceptions struct {
+	inner    github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha1.PolicyExceptionInterface
+	recorder github_com_kyverno_kyverno_pkg_metrics.Recorder
+}
+
+func newKyvernoV1alpha1PolicyExceptions(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha1.PolicyExceptionInterface, recorder github_com_kyverno_kyverno_pkg_metrics.Recorder) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha1.PolicyExceptionInterface {
+	return &wrappedKyvernoV1alpha1PolicyExceptions{inner, recorder}
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Create(arg0 context.Context, arg1 *github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, error) {
+	defer c.recorder.Record("create")
+	return c.inner.Create(arg0, arg1, arg2)
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Delete(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.DeleteOptions) error {
+	defer c.recorder.Record("delete")
+	return c.inner.Delete(arg0, arg1, arg2)
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) DeleteCollection(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.DeleteOptions, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) error {
+	defer c.recorder.Record("delete_collection")
+	return c.inner.DeleteCollection(arg0, arg1, arg2)
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Get(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.GetOptions) (*github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, error) {
+	defer c.recorder.Record("get")
+	return c.inner.Get(arg0, arg1, arg2)
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) List(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) (*github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyExceptionList, error) {
+	defer c.recorder.Record("list")
+	return c.inner.List(arg0, arg1)
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Patch(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_types.PatchType, arg3 []uint8, arg4 k8s_io_apimachinery_pkg_apis_meta_v1.PatchOptions, arg5 ...string) (*github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, error) {
+	defer c.recorder.Record("patch")
+	return c.inner.Patch(arg0, arg1, arg2, arg3, arg4, arg5...)
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Update(arg0 context.Context, arg1 *github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.UpdateOptions) (*github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, error) {
+	defer c.recorder.Record("update")
+	return c.inner.Update(arg0, arg1, arg2)
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Watch(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) (k8s_io_apimachinery_pkg_watch.Interface, error) {
+	defer c.recorder.Record("watch")
+	return c.inner.Watch(arg0, arg1)
+}
+
 // wrappedKyvernoV1alpha2AdmissionReports wrapper
 type wrappedKyvernoV1alpha2AdmissionReports struct {
 	inner    github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.AdmissionReportInterface
@@ -112,6 +112,9 @@ func (c *wrappedKyvernoV1alpha1) CleanupPolicies(namespace string) github_com_ky
 func (c *wrappedKyvernoV1alpha1) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha1.ClusterCleanupPolicyInterface {
 	return newKyvernoV1alpha1ClusterCleanupPolicies(c.inner.ClusterCleanupPolicies())
 }
+func (c *wrappedKyvernoV1alpha1) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha1.PolicyExceptionInterface {
+	return newKyvernoV1alpha1PolicyExceptions(c.inner.PolicyExceptions(namespace))
+}
 
 // RESTClient is NOT instrumented
 func (c *wrappedKyvernoV1alpha1) RESTClient() k8s_io_client_go_rest.Interface {
@@ -1036,6 +1039,159 @@ func (c *wrappedKyvernoV1alpha1ClusterCleanupPolicies) Watch(arg0 context.Contex
 	return ret0, ret1
 }
 
+// wrappedKyvernoV1alpha1PolicyExceptions wrapper
+type wrappedKyvernoV1alpha1PolicyExceptions struct {
+	inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha1.PolicyExceptionInterface
+}
+
+func newKyvernoV1alpha1PolicyExceptions(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha1.PolicyExceptionInterface) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha1.PolicyExceptionInterface {
+	return &wrappedKyvernoV1alpha1PolicyExceptions{inner}
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Create(arg0 context.Context, arg1 *github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, error) {
+	ctx, span := github_com_kyverno_kyverno_pkg_tracing.StartSpan(
+		arg0,
+		"pkg/clients/wrappers/traces/kyverno",
+		"KUBE KyvernoV1alpha1/PolicyExceptions/Create",
+		go_opentelemetry_io_otel_attribute.String("client", "KyvernoV1alpha1"),
+		go_opentelemetry_io_otel_attribute.String("resource", "PolicyExceptions"),
+		go_opentelemetry_io_otel_attribute.String("kind", "PolicyException"),
+	)
+	defer span.End()
+	arg0 = ctx
+	ret0, ret1 := c.inner.Create(arg0, arg1, arg2)
+	if ret1 != nil {
+		span.RecordError(ret1)
+		span.SetStatus(go_opentelemetry_io_otel_codes.Ok, ret1.Error())
+	}
+	return ret0, ret1
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Delete(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.DeleteOptions) error {
+	ctx, span := github_com_kyverno_kyverno_pkg_tracing.StartSpan(
+		arg0,
+		"pkg/clients/wrappers/traces/kyverno",
+		"KUBE KyvernoV1alpha1/PolicyExceptions/Delete",
+		go_opentelemetry_io_otel_attribute.String("client", "KyvernoV1alpha1"),
+		go_opentelemetry_io_otel_attribute.String("resource", "PolicyExceptions"),
+		go_opentelemetry_io_otel_attribute.String("kind", "PolicyException"),
+	)
+	defer span.End()
+	arg0 = ctx
+	ret0 := c.inner.Delete(arg0, arg1, arg2)
+	if ret0 != nil {
+		span.RecordError(ret0)
+		span.SetStatus(go_opentelemetry_io_otel_codes.Ok, ret0.Error())
+	}
+	return ret0
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) DeleteCollection(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.DeleteOptions, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) error {
+	ctx, span := github_com_kyverno_kyverno_pkg_tracing.StartSpan(
+		arg0,
+		"pkg/clients/wrappers/traces/kyverno",
+		"KUBE KyvernoV1alpha1/PolicyExceptions/DeleteCollection",
+		go_opentelemetry_io_otel_attribute.String("client", "KyvernoV1alpha1"),
+		go_opentelemetry_io_otel_attribute.String("resource", "PolicyExceptions"),
+		go_opentelemetry_io_otel_attribute.String("kind", "PolicyException"),
+	)
+	defer span.End()
+	arg0 = ctx
+	ret0 := c.inner.DeleteCollection(arg0, arg1, arg2)
+	if ret0 != nil {
+		span.RecordError(ret0)
+		span.SetStatus(go_opentelemetry_io_otel_codes.Ok, ret0.Error())
+	}
+	return ret0
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Get(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.GetOptions) (*github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, error) {
+	ctx, span := github_com_kyverno_kyverno_pkg_tracing.StartSpan(
+		arg0,
+		"pkg/clients/wrappers/traces/kyverno",
+		"KUBE KyvernoV1alpha1/PolicyExceptions/Get",
+		go_opentelemetry_io_otel_attribute.String("client", "KyvernoV1alpha1"),
+		go_opentelemetry_io_otel_attribute.String("resource", "PolicyExceptions"),
+		go_opentelemetry_io_otel_attribute.String("kind", "PolicyException"),
+	)
+	defer span.End()
+	arg0 = ctx
+	ret0, ret1 := c.inner.Get(arg0, arg1, arg2)
+	if ret1 != nil {
+		span.RecordError(ret1)
+		span.SetStatus(go_opentelemetry_io_otel_codes.Ok, ret1.Error())
+	}
+	return ret0, ret1
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) List(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) (*github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyExceptionList, error) {
+	ctx, span := github_com_kyverno_kyverno_pkg_tracing.StartSpan(
+		arg0,
+		"pkg/clients/wrappers/traces/kyverno",
+		"KUBE KyvernoV1alpha1/PolicyExceptions/List",
+		go_opentelemetry_io_otel_attribute.String("client", "KyvernoV1alpha1"),
+		go_opentelemetry_io_otel_attribute.String("resource", "PolicyExceptions"),
+		go_opentelemetry_io_otel_attribute.String("kind", "PolicyException"),
+	)
+	defer span.End()
+	arg0 = ctx
+	ret0, ret1 := c.inner.List(arg0, arg1)
+	if ret1 != nil {
+		span.RecordError(ret1)
+		span.SetStatus(go_opentelemetry_io_otel_codes.Ok, ret1.Error())
+	}
+	return ret0, ret1
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Patch(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_types.PatchType, arg3 []uint8, arg4 k8s_io_apimachinery_pkg_apis_meta_v1.PatchOptions, arg5 ...string) (*github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, error) {
+	ctx, span := github_com_kyverno_kyverno_pkg_tracing.StartSpan(
+		arg0,
+		"pkg/clients/wrappers/traces/kyverno",
+		"KUBE KyvernoV1alpha1/PolicyExceptions/Patch",
+		go_opentelemetry_io_otel_attribute.String("client", "KyvernoV1alpha1"),
+		go_opentelemetry_io_otel_attribute.String("resource", "PolicyExceptions"),
+		go_opentelemetry_io_otel_attribute.String("kind", "PolicyException"),
+	)
+	defer span.End()
+	arg0 = ctx
+	ret0, ret1 := c.inner.Patch(arg0, arg1, arg2, arg3, arg4, arg5...)
+	if ret1 != nil {
+		span.RecordError(ret1)
+		span.SetStatus(go_opentelemetry_io_otel_codes.Ok, ret1.Error())
+	}
+	return ret0, ret1
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Update(arg0 context.Context, arg1 *github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.UpdateOptions) (*github_com_kyverno_kyverno_api_kyverno_v1alpha1.PolicyException, error) {
+	ctx, span := github_com_kyverno_kyverno_pkg_tracing.StartSpan(
+		arg0,
+		"pkg/clients/wrappers/traces/kyverno",
+		"KUBE KyvernoV1alpha1/PolicyExceptions/Update",
+		go_opentelemetry_io_otel_attribute.String("client", "KyvernoV1alpha1"),
+		go_opentelemetry_io_otel_attribute.String("resource", "PolicyExceptions"),
+		go_opentelemetry_io_otel_attribute.String("kind", "PolicyException"),
+	)
+	defer span.End()
+	arg0 = ctx
+	ret0, ret1 := c.inner.Update(arg0, arg1, arg2)
+	if ret1 != nil {
+		span.RecordError(ret1)
+		span.SetStatus(go_opentelemetry_io_otel_codes.Ok, ret1.Error())
+	}
+	return ret0, ret1
+}
+func (c *wrappedKyvernoV1alpha1PolicyExceptions) Watch(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) (k8s_io_apimachinery_pkg_watch.Interface, error) {
+	ctx, span := github_com_kyverno_kyverno_pkg_tracing.StartSpan(
+		arg0,
+		"pkg/clients/wrappers/traces/kyverno",
+		"KUBE KyvernoV1alpha1/PolicyExceptions/Watch",
+		go_opentelemetry_io_otel_attribute.String("client", "KyvernoV1alpha1"),
+		go_opentelemetry_io_otel_attribute.String("resource", "PolicyExceptions"),
+		go_opentelemetry_io_otel_attribute.String("kind", "PolicyException"),
+	)
+	defer span.End()
+	arg0 = ctx
+	ret0, ret1 := c.inner.Watch(arg0, arg1)
+	if ret1 != nil {
+		span.RecordError(ret1)
+		span.SetStatus(go_opentelemetry_io_otel_codes.Ok, ret1.Error())
+	}
+	return ret0, ret1
+}
+
 // wrappedKyvernoV1alpha2AdmissionReports wrapper
 type wrappedKyvernoV1alpha2AdmissionReports struct {
 	inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.AdmissionReportInterface
@@ -31,6 +31,10 @@ const (
 	PolicyValidatingWebhookConfigurationName = "kyverno-policy-validating-webhook-cfg"
 	// PolicyValidatingWebhookName default policy validating webhook name
 	PolicyValidatingWebhookName = "validate-policy.kyverno.svc"
+	// ExceptionValidatingWebhookConfigurationName default policy exception validating webhook configuration name
+	ExceptionValidatingWebhookConfigurationName = "kyverno-exception-validating-webhook-cfg"
+	// ExceptionValidatingWebhookName default policy exception validating webhook name
+	ExceptionValidatingWebhookName = "validate-exception.kyverno.svc"
 	// PolicyMutatingWebhookConfigurationName default policy mutating webhook configuration name
 	PolicyMutatingWebhookConfigurationName = "kyverno-policy-mutating-webhook-cfg"
 	// PolicyMutatingWebhookName default policy mutating webhook name
@@ -48,6 +52,8 @@ const (
 	ValidatingWebhookServicePath = "/validate"
 	// PolicyValidatingWebhookServicePath is the path for policy validation webhook(used to validate policy resource)
 	PolicyValidatingWebhookServicePath = "/policyvalidate"
+	// ExceptionValidatingWebhookServicePath is the path for policy exception validation webhook(used to validate policy exception resource)
+	ExceptionValidatingWebhookServicePath = "/exceptionvalidate"
 	// PolicyMutatingWebhookServicePath is the path for policy mutation webhook(used to default)
 	PolicyMutatingWebhookServicePath = "/policymutate"
 	// VerifyMutatingWebhookServicePath is the path for verify webhook(used to veryfing if admission control is enabled and active)
@@ -0,0 +1,204 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1alpha1"
+	kyvernov1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/common"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/engine"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/robfig/cron"
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 3
+	ControllerName = "cleanup-controller"
+	maxRetries     = 10
+)
+
+type controller struct {
+	// clients
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+
+	// listers
+	cpolLister kyvernov1alpha1listers.ClusterCleanupPolicyLister
+	polLister  kyvernov1alpha1listers.CleanupPolicyLister
+	nsLister   corev1listers.NamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	cpolInformer kyvernov1alpha1informers.ClusterCleanupPolicyInformer,
+	polInformer kyvernov1alpha1informers.CleanupPolicyInformer,
+	nsInformer corev1informers.NamespaceInformer,
+) controllers.Controller {
+	c := controller{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		cpolLister:    cpolInformer.Lister(),
+		polLister:     polInformer.Lister(),
+		nsLister:      nsInformer.Lister(),
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+	}
+	controllerutils.AddDefaultEventHandlers(logger, cpolInformer.Informer(), c.queue)
+	controllerutils.AddDefaultEventHandlers(logger, polInformer.Informer(), c.queue)
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	policy, err := c.getPolicy(namespace, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	schedule, err := cron.ParseStandard(policy.GetSchedule())
+	if err != nil {
+		// the policy is invalid, there's no point in retrying
+		logger.Error(err, "failed to parse schedule", "schedule", policy.GetSchedule())
+		return nil
+	}
+	now := time.Now()
+	next := nextExecutionTime(schedule, policy)
+	if next.After(now) {
+		c.queue.AddAfter(key, next.Sub(now))
+		return nil
+	}
+	count, cleanupErr := c.cleanup(ctx, logger, policy)
+	if cleanupErr != nil {
+		logger.Error(cleanupErr, "failed to cleanup resources")
+	}
+	// updating the status will trigger a new reconciliation that will schedule the next execution
+	return c.updateStatus(ctx, policy, metav1.NewTime(now), count, cleanupErr)
+}
+
+func (c *controller) getPolicy(namespace, name string) (kyvernov1alpha1.CleanupPolicyInterface, error) {
+	if namespace == "" {
+		return c.cpolLister.Get(name)
+	} else {
+		return c.polLister.CleanupPolicies(namespace).Get(name)
+	}
+}
+
+// nextExecutionTime computes the next execution time from the last execution time or,
+// if the policy was never executed, from the policy creation time
+func nextExecutionTime(schedule cron.Schedule, policy kyvernov1alpha1.CleanupPolicyInterface) time.Time {
+	last := policy.GetCreationTimestamp()
+	if executed := policy.GetStatus().GetLastExecutionTime(); executed != nil {
+		last = *executed
+	}
+	return schedule.Next(last.Time)
+}
+
+func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyvernov1alpha1.CleanupPolicyInterface) (int, error) {
+	spec := policy.GetSpec()
+	kinds := sets.NewString(spec.MatchResources.GetKinds()...)
+	// the engine expects a rule to check match and exclude blocks
+	rule := kyvernov1.Rule{
+		Name:             policy.GetName(),
+		MatchResources:   spec.MatchResources,
+		ExcludeResources: spec.ExcludeResources,
+	}
+	var errs []error
+	count := 0
+	for kind := range kinds {
+		logger := logger.WithValues("kind", kind)
+		apiVersion, k := kubeutils.GetKindFromGVK(kind)
+		list, err := c.client.ListResource(apiVersion, k, policy.GetNamespace(), nil)
+		if err != nil {
+			logger.Error(err, "failed to list resources")
+			errs = append(errs, err)
+			continue
+		}
+		for i := range list.Items {
+			resource := list.Items[i]
+			logger := logger.WithValues("namespace", resource.GetNamespace(), "name", resource.GetName())
+			namespaceLabels := common.GetNamespaceSelectorsFromNamespaceLister(resource.GetKind(), resource.GetNamespace(), c.nsLister, logger)
+			if err := engine.MatchesResourceDescription(resource, rule, kyvernov1beta1.RequestInfo{}, nil, namespaceLabels, policy.GetNamespace(), ""); err != nil {
+				continue
+			}
+			if spec.Conditions != nil {
+				passed, err := checkConditions(logger, resource, *spec.Conditions)
+				if err != nil {
+					logger.Error(err, "failed to evaluate conditions")
+					errs = append(errs, err)
+					continue
+				}
+				if !passed {
+					continue
+				}
+			}
+			logger.V(4).Info("deleting resource")
+			if err := c.client.DeleteResource(resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), false); err != nil {
+				if !errors.IsNotFound(err) {
+					logger.Error(err, "failed to delete resource")
+					errs = append(errs, err)
+				}
+				continue
+			}
+			count++
+		}
+	}
+	return count, multierr.Combine(errs...)
+}
+
+func checkConditions(logger logr.Logger, resource unstructured.Unstructured, conditions kyvernov1.AnyAllConditions) (bool, error) {
+	jsonContext := enginecontext.NewContext()
+	if err := jsonContext.AddResource(resource.Object); err != nil {
+		return false, err
+	}
+	substituted, err := variables.SubstituteAllInConditions(logger, jsonContext, []kyvernov1.AnyAllConditions{conditions})
+	if err != nil {
+		return false, err
+	}
+	return variables.EvaluateAnyAllConditions(logger, jsonContext, substituted), nil
+}
+
+func (c *controller) updateStatus(ctx context.Context, policy kyvernov1alpha1.CleanupPolicyInterface, executionTime metav1.Time, count int, cleanupErr error) error {
+	message := fmt.Sprintf("%d resource(s) deleted", count)
+	switch typed := policy.(type) {
+	case *kyvernov1alpha1.ClusterCleanupPolicy:
+		policy := typed.DeepCopy()
+		policy.Status.SetExecuted(executionTime, policy.GetGeneration(), cleanupErr, message)
+		_, err := c.kyvernoClient.KyvernoV1alpha1().ClusterCleanupPolicies().UpdateStatus(ctx, policy, metav1.UpdateOptions{})
+		return err
+	case *kyvernov1alpha1.CleanupPolicy:
+		policy := typed.DeepCopy()
+		policy.Status.SetExecuted(executionTime, policy.GetGeneration(), cleanupErr, message)
+		_, err := c.kyvernoClient.KyvernoV1alpha1().CleanupPolicies(policy.GetNamespace()).UpdateStatus(ctx, policy, metav1.UpdateOptions{})
+		return err
+	}
+	return fmt.Errorf("unsupported cleanup policy type %T", policy)
+}
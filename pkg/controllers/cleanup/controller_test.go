@@ -0,0 +1,139 @@
+package cleanup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	versionedfake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newConfigMap(name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func newCleanupPolicy() *kyvernov1alpha1.CleanupPolicy {
+	return &kyvernov1alpha1.CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "cleanup",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Spec: kyvernov1alpha1.CleanupPolicySpec{
+			Schedule: "*/5 * * * *",
+			MatchResources: kyvernov1.MatchResources{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Kinds:    []string{"ConfigMap"},
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"preview": "true"}},
+				},
+			},
+			ExcludeResources: kyvernov1.MatchResources{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Names: []string{"keep"},
+				},
+			},
+			Conditions: &kyvernov1.AnyAllConditions{
+				AllConditions: []kyvernov1.Condition{{
+					RawKey:   kyvernov1.ToJSON("{{ request.object.metadata.labels.stage }}"),
+					Operator: kyvernov1.ConditionOperators["Equals"],
+					RawValue: kyvernov1.ToJSON("done"),
+				}},
+			},
+		},
+	}
+}
+
+func newController(t *testing.T, policy *kyvernov1alpha1.CleanupPolicy, objects ...runtime.Object) (*controller, dclient.Interface) {
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"}, objects...)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	kyvernoClient := versionedfake.NewSimpleClientset(policy)
+	kyvernoInformers := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, 0)
+	kubeInformers := kubeinformers.NewSharedInformerFactory(kubefake.NewSimpleClientset(), 0)
+	polInformer := kyvernoInformers.Kyverno().V1alpha1().CleanupPolicies()
+	nsInformer := kubeInformers.Core().V1().Namespaces()
+	assert.NilError(t, polInformer.Informer().GetIndexer().Add(policy))
+	assert.NilError(t, nsInformer.Informer().GetIndexer().Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}))
+	c := NewController(
+		client,
+		kyvernoClient,
+		kyvernoInformers.Kyverno().V1alpha1().ClusterCleanupPolicies(),
+		polInformer,
+		nsInformer,
+	).(*controller)
+	return c, client
+}
+
+func Test_Cleanup(t *testing.T) {
+	policy := newCleanupPolicy()
+	c, client := newController(t, policy,
+		newConfigMap("matched", map[string]string{"preview": "true", "stage": "done"}),
+		newConfigMap("running", map[string]string{"preview": "true", "stage": "running"}),
+		newConfigMap("keep", map[string]string{"preview": "true", "stage": "done"}),
+		newConfigMap("unrelated", map[string]string{"stage": "done"}),
+	)
+
+	count, err := c.cleanup(context.TODO(), logr.Discard(), policy)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 1)
+
+	_, err = client.GetResource("v1", "ConfigMap", "default", "matched")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	for _, name := range []string{"running", "keep", "unrelated"} {
+		_, err := client.GetResource("v1", "ConfigMap", "default", name)
+		assert.NilError(t, err, name)
+	}
+}
+
+func Test_Reconcile(t *testing.T) {
+	policy := newCleanupPolicy()
+	c, client := newController(t, policy,
+		newConfigMap("matched", map[string]string{"preview": "true", "stage": "done"}),
+	)
+
+	err := c.reconcile(context.TODO(), logr.Discard(), "default/cleanup", "default", "cleanup")
+	assert.NilError(t, err)
+
+	_, err = client.GetResource("v1", "ConfigMap", "default", "matched")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	updated, err := c.kyvernoClient.KyvernoV1alpha1().CleanupPolicies("default").Get(context.TODO(), "cleanup", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, updated.Status.GetLastExecutionTime() != nil)
+	assert.Equal(t, updated.Status.Conditions[0].Status, metav1.ConditionTrue)
+	assert.Equal(t, updated.Status.Conditions[0].Message, "1 resource(s) deleted")
+}
+
+func Test_Reconcile_NotDue(t *testing.T) {
+	policy := newCleanupPolicy()
+	policy.Status.SetExecuted(metav1.Now(), 0, nil, "")
+	policy.Spec.Schedule = "0 0 1 1 *"
+	c, client := newController(t, policy,
+		newConfigMap("matched", map[string]string{"preview": "true", "stage": "done"}),
+	)
+
+	err := c.reconcile(context.TODO(), logr.Discard(), "default/cleanup", "default", "cleanup")
+	assert.NilError(t, err)
+
+	_, err = client.GetResource("v1", "ConfigMap", "default", "matched")
+	assert.NilError(t, err)
+}
@@ -9,11 +9,15 @@ import (
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	pcache "github.com/kyverno/kyverno/pkg/policycache"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -31,7 +35,8 @@ type Controller interface {
 }
 
 type controller struct {
-	cache pcache.Cache
+	cache            pcache.Cache
+	imageVerifyCache imageverifycache.Cache
 
 	// listers
 	cpolLister kyvernov1listers.ClusterPolicyLister
@@ -41,15 +46,29 @@ type controller struct {
 	queue workqueue.RateLimitingInterface
 }
 
-func NewController(pcache pcache.Cache, cpolInformer kyvernov1informers.ClusterPolicyInformer, polInformer kyvernov1informers.PolicyInformer) Controller {
+// NewController creates the policy cache controller, the secret informer can be nil when the image verification cache is disabled
+func NewController(pcache pcache.Cache, imageVerifyCache imageverifycache.Cache, cpolInformer kyvernov1informers.ClusterPolicyInformer, polInformer kyvernov1informers.PolicyInformer, secretInformer informers.GenericInformer) Controller {
 	c := controller{
-		cache:      pcache,
-		cpolLister: cpolInformer.Lister(),
-		polLister:  polInformer.Lister(),
-		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		cache:            pcache,
+		imageVerifyCache: imageVerifyCache,
+		cpolLister:       cpolInformer.Lister(),
+		polLister:        polInformer.Lister(),
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 	}
 	controllerutils.AddDefaultEventHandlers(logger, cpolInformer.Informer(), c.queue)
 	controllerutils.AddDefaultEventHandlers(logger, polInformer.Informer(), c.queue)
+	if secretInformer != nil {
+		secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, obj interface{}) {
+				if oldMeta, err := meta.Accessor(old); err == nil {
+					if newMeta, err := meta.Accessor(obj); err == nil && oldMeta.GetResourceVersion() != newMeta.GetResourceVersion() {
+						c.invalidateSecret(obj)
+					}
+				}
+			},
+			DeleteFunc: c.invalidateSecret,
+		})
+	}
 	return &c
 }
 
@@ -87,6 +106,8 @@ func (c *controller) Run(ctx context.Context, workers int) {
 }
 
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	// image verification results may no longer be valid for the updated policy
+	c.imageVerifyCache.InvalidatePolicy(key)
 	policy, err := c.loadPolicy(namespace, name)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -99,6 +120,16 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	return nil
 }
 
+// invalidateSecret removes the image verification results using the public keys of an updated or deleted secret
+func (c *controller) invalidateSecret(obj interface{}) {
+	secret, err := meta.Accessor(kubeutils.GetObjectWithTombstone(obj))
+	if err != nil {
+		logger.Error(err, "failed to get secret")
+		return
+	}
+	c.imageVerifyCache.InvalidateSecret(secret.GetNamespace(), secret.GetName())
+}
+
 func (c *controller) loadPolicy(namespace, name string) (kyvernov1.PolicyInterface, error) {
 	if namespace == "" {
 		return c.cpolLister.Get(name)
@@ -3,15 +3,19 @@ package background
 import (
 	"context"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1alpha1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
@@ -50,6 +54,7 @@ type controller struct {
 	bgscanrLister  cache.GenericLister
 	cbgscanrLister cache.GenericLister
 	nsLister       corev1listers.NamespaceLister
+	polexLister    kyvernov1alpha1listers.PolicyExceptionLister
 
 	// queue
 	queue          workqueue.RateLimitingInterface
@@ -58,6 +63,10 @@ type controller struct {
 
 	// cache
 	metadataCache resource.MetadataCache
+
+	// rescan holds the keys of the reports that must be rebuilt even if the resource didn't change,
+	// this is the case when a policy exception referencing one of the report policies changed
+	rescan sync.Map
 }
 
 func NewController(
@@ -67,6 +76,7 @@ func NewController(
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	nsInformer corev1informers.NamespaceInformer,
+	polexInformer kyvernov1alpha1informers.PolicyExceptionInformer,
 	metadataCache resource.MetadataCache,
 ) controllers.Controller {
 	bgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports"))
@@ -80,6 +90,7 @@ func NewController(
 		bgscanrLister:  bgscanr.Lister(),
 		cbgscanrLister: cbgscanr.Lister(),
 		nsLister:       nsInformer.Lister(),
+		polexLister:    polexInformer.Lister(),
 		queue:          queue,
 		bgscanEnqueue:  controllerutils.AddDefaultEventHandlers(logger, bgscanr.Informer(), queue),
 		cbgscanEnqueue: controllerutils.AddDefaultEventHandlers(logger, cbgscanr.Informer(), queue),
@@ -87,6 +98,7 @@ func NewController(
 	}
 	controllerutils.AddEventHandlersT(polInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy)
 	controllerutils.AddEventHandlersT(cpolInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy)
+	controllerutils.AddEventHandlersT(polexInformer.Informer(), c.addException, c.updateException, c.deleteException)
 	c.metadataCache.AddEventHandler(func(uid types.UID, _ schema.GroupVersionKind, resource resource.Resource) {
 		selector, err := reportutils.SelectorResourceUidEquals(uid)
 		if err != nil {
@@ -140,6 +152,75 @@ func (c *controller) deletePolicy(obj kyvernov1.PolicyInterface) {
 	}
 }
 
+func (c *controller) addException(obj *kyvernov1alpha1.PolicyException) {
+	c.enqueueException(obj)
+}
+
+func (c *controller) updateException(old, obj *kyvernov1alpha1.PolicyException) {
+	if old.GetResourceVersion() != obj.GetResourceVersion() {
+		c.enqueueException(old)
+		c.enqueueException(obj)
+	}
+}
+
+func (c *controller) deleteException(obj *kyvernov1alpha1.PolicyException) {
+	c.enqueueException(obj)
+}
+
+// enqueueException enqueues the reports of the policies referenced by the exception
+func (c *controller) enqueueException(obj *kyvernov1alpha1.PolicyException) {
+	for _, exception := range obj.Spec.Exceptions {
+		var policy kyvernov1.PolicyInterface
+		namespace, name, err := cache.SplitMetaNamespaceKey(exception.PolicyName)
+		if err != nil {
+			logger.Error(err, "failed to parse policy name", "policy", exception.PolicyName)
+			continue
+		}
+		if namespace == "" {
+			policy, err = c.cpolLister.Get(name)
+		} else {
+			policy, err = c.polLister.Policies(namespace).Get(name)
+		}
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				logger.Error(err, "failed to get policy", "policy", exception.PolicyName)
+			}
+			continue
+		}
+		selector, err := reportutils.SelectorPolicyExists(policy)
+		if err != nil {
+			logger.Error(err, "failed to create label selector")
+			continue
+		}
+		if err := c.enqueueRescan(selector); err != nil {
+			logger.Error(err, "failed to enqueue")
+		}
+	}
+}
+
+func (c *controller) enqueueRescan(selector labels.Selector) error {
+	bgscans, err := c.bgscanrLister.List(selector)
+	if err != nil {
+		return err
+	}
+	cbgscans, err := c.cbgscanrLister.List(selector)
+	if err != nil {
+		return err
+	}
+	for _, obj := range append(bgscans, cbgscans...) {
+		if meta, ok := obj.(metav1.Object); ok {
+			key, err := cache.MetaNamespaceKeyFunc(meta)
+			if err != nil {
+				logger.Error(err, "failed to compute report key")
+				continue
+			}
+			c.rescan.Store(key, struct{}{})
+			c.queue.Add(key)
+		}
+	}
+	return nil
+}
+
 func (c *controller) enqueue(selector labels.Selector) error {
 	bgscans, err := c.bgscanrLister.List(selector)
 	if err != nil {
@@ -220,7 +301,7 @@ func reportsAreIdentical(before, after kyvernov1alpha2.ReportInterface) bool {
 	return true
 }
 
-func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk schema.GroupVersionKind, resource resource.Resource) error {
+func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk schema.GroupVersionKind, resource resource.Resource, rescan bool) error {
 	namespace := meta.GetNamespace()
 	labels := meta.GetLabels()
 	// load all policies
@@ -240,9 +321,9 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 	if err != nil {
 		return err
 	}
-	//	if the resource changed, we need to rebuild the report
-	if !reportutils.CompareHash(meta, resource.Hash) {
-		scanner := utils.NewScanner(logger, c.client)
+	//	if the resource changed or an exception was updated, we need to rebuild the report
+	if rescan || !reportutils.CompareHash(meta, resource.Hash) {
+		scanner := utils.NewScanner(logger, c.client, c.polexLister)
 		before, err := c.getReport(ctx, meta.GetNamespace(), meta.GetName())
 		if err != nil {
 			return nil
@@ -328,7 +409,7 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 		}
 		// creations
 		if len(toCreate) > 0 {
-			scanner := utils.NewScanner(logger, c.client)
+			scanner := utils.NewScanner(logger, c.client, c.polexLister)
 			resource, err := c.client.GetResource(gvk.GroupVersion().String(), gvk.Kind, resource.Namespace, resource.Name)
 			if err != nil {
 				return err
@@ -415,5 +496,12 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 		}
 		return err
 	}
-	return c.updateReport(ctx, report, gvk, resource)
+	_, rescan := c.rescan.LoadAndDelete(key)
+	if err := c.updateReport(ctx, report, gvk, resource, rescan); err != nil {
+		if rescan {
+			c.rescan.Store(key, struct{}{})
+		}
+		return err
+	}
+	return nil
 }
@@ -3,6 +3,7 @@ package utils
 import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/context"
@@ -14,6 +15,7 @@ import (
 type scanner struct {
 	logger           logr.Logger
 	client           dclient.Interface
+	polexLister      kyvernov1alpha1listers.PolicyExceptionLister
 	excludeGroupRole []string
 }
 
@@ -26,10 +28,11 @@ type Scanner interface {
 	ScanResource(unstructured.Unstructured, map[string]string, ...kyvernov1.PolicyInterface) map[kyvernov1.PolicyInterface]ScanResult
 }
 
-func NewScanner(logger logr.Logger, client dclient.Interface, excludeGroupRole ...string) Scanner {
+func NewScanner(logger logr.Logger, client dclient.Interface, polexLister kyvernov1alpha1listers.PolicyExceptionLister, excludeGroupRole ...string) Scanner {
 	return &scanner{
 		logger:           logger,
 		client:           client,
+		polexLister:      polexLister,
 		excludeGroupRole: excludeGroupRole,
 	}
 }
@@ -82,6 +85,7 @@ func (s *scanner) validateResource(resource unstructured.Unstructured, nsLabels
 		Client:           s.client,
 		NamespaceLabels:  nsLabels,
 		ExcludeGroupRole: s.excludeGroupRole,
+		ExceptionLister:  s.polexLister,
 	}
 	return engine.Validate(policyCtx), nil
 }
@@ -107,6 +111,7 @@ func (s *scanner) validateImages(resource unstructured.Unstructured, nsLabels ma
 		Client:           s.client,
 		NamespaceLabels:  nsLabels,
 		ExcludeGroupRole: s.excludeGroupRole,
+		ExceptionLister:  s.polexLister,
 	}
 	response, _ := engine.VerifyAndPatchImages(policyCtx)
 	if len(response.PolicyResponse.Rules) > 0 {
@@ -3,6 +3,7 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/tls"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
@@ -61,7 +63,16 @@ var (
 		APIGroups:   []string{"kyverno.io"},
 		APIVersions: []string{"v1", "v2beta1"},
 	}
-	verifyRule = admissionregistrationv1.Rule{
+	policyExceptionRule = admissionregistrationv1.Rule{
+		Resources:   []string{"policyexceptions"},
+		APIGroups:   []string{"kyverno.io"},
+		APIVersions: []string{"v1alpha1"},
+	}
+	// mutatingOperations and validatingOperations are the operations of the resource webhooks,
+	// policies can restrict them with the operations of their match blocks
+	mutatingOperations   = []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+	validatingOperations = []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect}
+	verifyRule           = admissionregistrationv1.Rule{
 		Resources:   []string{"leases"},
 		APIGroups:   []string{"coordination.k8s.io"},
 		APIVersions: []string{"v1"},
@@ -94,6 +105,7 @@ type controller struct {
 	defaultTimeout     int32
 	autoUpdateWebhooks bool
 	admissionReports   bool
+	policyWebhooks     bool
 	runtime            runtimeutils.Runtime
 
 	// state
@@ -119,6 +131,7 @@ func NewController(
 	defaultTimeout int32,
 	autoUpdateWebhooks bool,
 	admissionReports bool,
+	policyWebhooks bool,
 	runtime runtimeutils.Runtime,
 ) controllers.Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
@@ -141,6 +154,7 @@ func NewController(
 		defaultTimeout:     defaultTimeout,
 		autoUpdateWebhooks: autoUpdateWebhooks,
 		admissionReports:   admissionReports,
+		policyWebhooks:     policyWebhooks,
 		runtime:            runtime,
 		policyState: map[string]sets.String{
 			config.MutatingWebhookConfigurationName:   sets.NewString(),
@@ -276,6 +290,7 @@ func (c *controller) enqueueAll() {
 func (c *controller) enqueuePolicyWebhooks() {
 	c.queue.Add(config.PolicyValidatingWebhookConfigurationName)
 	c.queue.Add(config.PolicyMutatingWebhookConfigurationName)
+	c.queue.Add(config.ExceptionValidatingWebhookConfigurationName)
 }
 
 func (c *controller) enqueueResourceWebhooks(duration time.Duration) {
@@ -296,6 +311,17 @@ func (c *controller) loadConfig() config.Configuration {
 	return cfg
 }
 
+// globalWebhookConfig returns the webhook configuration of the Kyverno ConfigMap
+func (c *controller) globalWebhookConfig() config.WebhookConfig {
+	cfg := c.loadConfig()
+	webhookCfg := config.WebhookConfig{}
+	webhookCfgs := cfg.GetWebhooks()
+	if len(webhookCfgs) > 0 {
+		webhookCfg = webhookCfgs[0]
+	}
+	return webhookCfg
+}
+
 func (c *controller) recordPolicyState(webhookConfigurationName string, policies ...kyvernov1.PolicyInterface) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -350,6 +376,10 @@ func (c *controller) reconcilePolicyValidatingWebhookConfiguration(ctx context.C
 	return c.reconcileValidatingWebhookConfiguration(ctx, true, c.buildPolicyValidatingWebhookConfiguration)
 }
 
+func (c *controller) reconcileExceptionValidatingWebhookConfiguration(ctx context.Context) error {
+	return c.reconcileValidatingWebhookConfiguration(ctx, true, c.buildExceptionValidatingWebhookConfiguration)
+}
+
 func (c *controller) reconcilePolicyMutatingWebhookConfiguration(ctx context.Context) error {
 	return c.reconcileMutatingWebhookConfiguration(ctx, true, c.buildPolicyMutatingWebhookConfiguration)
 }
@@ -505,6 +535,8 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 		return c.reconcilePolicyValidatingWebhookConfiguration(ctx)
 	case config.PolicyMutatingWebhookConfigurationName:
 		return c.reconcilePolicyMutatingWebhookConfiguration(ctx)
+	case config.ExceptionValidatingWebhookConfigurationName:
+		return c.reconcileExceptionValidatingWebhookConfiguration(ctx)
 	case config.VerifyMutatingWebhookConfigurationName:
 		return c.reconcileVerifyMutatingWebhookConfiguration(ctx)
 	}
@@ -580,6 +612,29 @@ func (c *controller) buildPolicyValidatingWebhookConfiguration(caBundle []byte)
 		nil
 }
 
+// buildExceptionValidatingWebhookConfiguration fails closed, an invalid exception could exempt
+// resources from the policies
+func (c *controller) buildExceptionValidatingWebhookConfiguration(caBundle []byte) (*admissionregistrationv1.ValidatingWebhookConfiguration, error) {
+	return &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: objectMeta(config.ExceptionValidatingWebhookConfigurationName),
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name:         config.ExceptionValidatingWebhookName,
+				ClientConfig: c.clientConfig(caBundle, config.ExceptionValidatingWebhookServicePath),
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Rule: policyExceptionRule,
+					Operations: []admissionregistrationv1.OperationType{
+						admissionregistrationv1.Create,
+						admissionregistrationv1.Update,
+					},
+				}},
+				FailurePolicy:           &fail,
+				SideEffects:             &none,
+				AdmissionReviewVersions: []string{"v1beta1"},
+			}},
+		},
+		nil
+}
+
 func (c *controller) buildDefaultResourceMutatingWebhookConfiguration(caBundle []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
 	return &admissionregistrationv1.MutatingWebhookConfiguration{
 			ObjectMeta: objectMeta(config.MutatingWebhookConfigurationName),
@@ -620,6 +675,30 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(caBundle []byte)
 			return nil, err
 		}
 		c.recordPolicyState(config.MutatingWebhookConfigurationName, policies...)
+		webhookCfg := c.globalWebhookConfig()
+		if c.policyWebhooks {
+			groups := c.groupWebhooks(policies, false, func(spec *kyvernov1.Spec) bool {
+				return spec.HasMutate() || spec.HasVerifyImages()
+			})
+			for _, group := range groups {
+				result.Webhooks = append(
+					result.Webhooks,
+					admissionregistrationv1.MutatingWebhook{
+						Name:                    groupWebhookName(config.MutatingWebhookName, group.key),
+						ClientConfig:            c.clientConfig(caBundle, config.MutatingWebhookServicePath+"/group/"+group.key),
+						Rules:                   group.buildRulesWithOperations(mutatingOperations...),
+						FailurePolicy:           &group.failurePolicy,
+						SideEffects:             &noneOnDryRun,
+						AdmissionReviewVersions: []string{"v1beta1"},
+						NamespaceSelector:       mergeLabelSelectors(webhookCfg.NamespaceSelector, group.namespaceSelector.get()),
+						ObjectSelector:          mergeLabelSelectors(webhookCfg.ObjectSelector, group.objectSelector.get()),
+						TimeoutSeconds:          &group.maxWebhookTimeout,
+						ReinvocationPolicy:      &ifNeeded,
+					},
+				)
+			}
+			return &result, nil
+		}
 		// TODO: shouldn't be per failure policy, depending of the policy/rules that apply ?
 		if hasWildcard(policies...) {
 			ignore.setWildcard()
@@ -636,24 +715,18 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(caBundle []byte)
 				}
 			}
 		}
-		cfg := c.loadConfig()
-		webhookCfg := config.WebhookConfig{}
-		webhookCfgs := cfg.GetWebhooks()
-		if len(webhookCfgs) > 0 {
-			webhookCfg = webhookCfgs[0]
-		}
 		if !ignore.isEmpty() {
 			result.Webhooks = append(
 				result.Webhooks,
 				admissionregistrationv1.MutatingWebhook{
 					Name:                    config.MutatingWebhookName + "-ignore",
 					ClientConfig:            c.clientConfig(caBundle, config.MutatingWebhookServicePath+"/ignore"),
-					Rules:                   ignore.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update),
+					Rules:                   ignore.buildRulesWithOperations(mutatingOperations...),
 					FailurePolicy:           &ignore.failurePolicy,
 					SideEffects:             &noneOnDryRun,
 					AdmissionReviewVersions: []string{"v1beta1"},
-					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
+					NamespaceSelector:       mergeLabelSelectors(webhookCfg.NamespaceSelector, ignore.namespaceSelector.get()),
+					ObjectSelector:          mergeLabelSelectors(webhookCfg.ObjectSelector, ignore.objectSelector.get()),
 					TimeoutSeconds:          &ignore.maxWebhookTimeout,
 					ReinvocationPolicy:      &ifNeeded,
 				},
@@ -665,12 +738,12 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(caBundle []byte)
 				admissionregistrationv1.MutatingWebhook{
 					Name:                    config.MutatingWebhookName + "-fail",
 					ClientConfig:            c.clientConfig(caBundle, config.MutatingWebhookServicePath+"/fail"),
-					Rules:                   fail.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update),
+					Rules:                   fail.buildRulesWithOperations(mutatingOperations...),
 					FailurePolicy:           &fail.failurePolicy,
 					SideEffects:             &noneOnDryRun,
 					AdmissionReviewVersions: []string{"v1beta1"},
-					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
+					NamespaceSelector:       mergeLabelSelectors(webhookCfg.NamespaceSelector, fail.namespaceSelector.get()),
+					ObjectSelector:          mergeLabelSelectors(webhookCfg.ObjectSelector, fail.objectSelector.get()),
 					TimeoutSeconds:          &fail.maxWebhookTimeout,
 					ReinvocationPolicy:      &ifNeeded,
 				},
@@ -727,6 +800,33 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(caBundle []byte
 			return nil, err
 		}
 		c.recordPolicyState(config.ValidatingWebhookConfigurationName, policies...)
+		webhookCfg := c.globalWebhookConfig()
+		sideEffects := &none
+		if c.admissionReports {
+			sideEffects = &noneOnDryRun
+		}
+		if c.policyWebhooks {
+			groups := c.groupWebhooks(policies, true, func(spec *kyvernov1.Spec) bool {
+				return spec.HasValidate() || spec.HasGenerate() || spec.HasMutate() || spec.HasImagesValidationChecks() || spec.HasYAMLSignatureVerify()
+			})
+			for _, group := range groups {
+				result.Webhooks = append(
+					result.Webhooks,
+					admissionregistrationv1.ValidatingWebhook{
+						Name:                    groupWebhookName(config.ValidatingWebhookName, group.key),
+						ClientConfig:            c.clientConfig(caBundle, config.ValidatingWebhookServicePath+"/group/"+group.key),
+						Rules:                   group.buildRulesWithOperations(validatingOperations...),
+						FailurePolicy:           &group.failurePolicy,
+						SideEffects:             sideEffects,
+						AdmissionReviewVersions: []string{"v1beta1"},
+						NamespaceSelector:       mergeLabelSelectors(webhookCfg.NamespaceSelector, group.namespaceSelector.get()),
+						ObjectSelector:          mergeLabelSelectors(webhookCfg.ObjectSelector, group.objectSelector.get()),
+						TimeoutSeconds:          &group.maxWebhookTimeout,
+					},
+				)
+			}
+			return &result, nil
+		}
 		// TODO: shouldn't be per failure policy, depending of the policy/rules that apply ?
 		if hasWildcard(policies...) {
 			ignore.setWildcard()
@@ -743,28 +843,18 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(caBundle []byte
 				}
 			}
 		}
-		cfg := c.loadConfig()
-		webhookCfg := config.WebhookConfig{}
-		webhookCfgs := cfg.GetWebhooks()
-		if len(webhookCfgs) > 0 {
-			webhookCfg = webhookCfgs[0]
-		}
-		sideEffects := &none
-		if c.admissionReports {
-			sideEffects = &noneOnDryRun
-		}
 		if !ignore.isEmpty() {
 			result.Webhooks = append(
 				result.Webhooks,
 				admissionregistrationv1.ValidatingWebhook{
 					Name:                    config.ValidatingWebhookName + "-ignore",
 					ClientConfig:            c.clientConfig(caBundle, config.ValidatingWebhookServicePath+"/ignore"),
-					Rules:                   ignore.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect),
+					Rules:                   ignore.buildRulesWithOperations(validatingOperations...),
 					FailurePolicy:           &ignore.failurePolicy,
 					SideEffects:             sideEffects,
 					AdmissionReviewVersions: []string{"v1beta1"},
-					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
+					NamespaceSelector:       mergeLabelSelectors(webhookCfg.NamespaceSelector, ignore.namespaceSelector.get()),
+					ObjectSelector:          mergeLabelSelectors(webhookCfg.ObjectSelector, ignore.objectSelector.get()),
 					TimeoutSeconds:          &ignore.maxWebhookTimeout,
 				},
 			)
@@ -775,12 +865,12 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(caBundle []byte
 				admissionregistrationv1.ValidatingWebhook{
 					Name:                    config.ValidatingWebhookName + "-fail",
 					ClientConfig:            c.clientConfig(caBundle, config.ValidatingWebhookServicePath+"/fail"),
-					Rules:                   fail.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect),
+					Rules:                   fail.buildRulesWithOperations(validatingOperations...),
 					FailurePolicy:           &fail.failurePolicy,
 					SideEffects:             sideEffects,
 					AdmissionReviewVersions: []string{"v1beta1"},
-					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
+					NamespaceSelector:       mergeLabelSelectors(webhookCfg.NamespaceSelector, fail.namespaceSelector.get()),
+					ObjectSelector:          mergeLabelSelectors(webhookCfg.ObjectSelector, fail.objectSelector.get()),
 					TimeoutSeconds:          &fail.maxWebhookTimeout,
 				},
 			)
@@ -814,64 +904,121 @@ func (c *controller) getLease() (*coordinationv1.Lease, error) {
 	return c.leaseLister.Leases(config.KyvernoNamespace()).Get("kyverno-health")
 }
 
-// mergeWebhook merges the matching kinds of the policy to webhook.rule
+// groupWebhooks merges the policies into one webhook per policy group and failure policy, sorted by key
+func (c *controller) groupWebhooks(policies []kyvernov1.PolicyInterface, updateValidate bool, filter func(*kyvernov1.Spec) bool) []*groupWebhook {
+	groups := map[string]*groupWebhook{}
+	for _, p := range policies {
+		spec := p.GetSpec()
+		if !filter(spec) {
+			continue
+		}
+		failurePolicy, path := fail, "fail"
+		if spec.GetFailurePolicy() == kyvernov1.Ignore {
+			failurePolicy, path = ignore, "ignore"
+		}
+		key := admissionutils.PolicyWebhookGroup(p) + "/" + path
+		group, ok := groups[key]
+		if !ok {
+			group = &groupWebhook{key: key, webhook: newWebhook(c.defaultTimeout, failurePolicy)}
+			groups[key] = group
+		}
+		c.mergeWebhook(group.webhook, p, updateValidate)
+		if hasWildcard(p) {
+			group.wildcard = true
+		}
+	}
+	var result []*groupWebhook
+	for _, group := range groups {
+		if group.wildcard {
+			group.setWildcard()
+		}
+		if !group.isEmpty() {
+			result = append(result, group)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].key < result[j].key
+	})
+	return result
+}
+
+// mergeWebhook merges the matching kinds and admission operations of the policy to webhook.rule,
+// and the selectors of its rules to the webhook selectors
 func (c *controller) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface, updateValidate bool) {
-	matchedGVK := make([]string, 0)
+	defaultOps := mutatingOperations
+	if updateValidate {
+		defaultOps = validatingOperations
+	}
+	matchedGVK := make(map[string]sets.String)
+	addKinds := func(ops sets.String, kinds ...string) {
+		for _, kind := range kinds {
+			if _, ok := matchedGVK[kind]; !ok {
+				matchedGVK[kind] = sets.NewString()
+			}
+			matchedGVK[kind].Insert(ops.List()...)
+		}
+	}
 	for _, rule := range autogen.ComputeRules(policy) {
 		// matching kinds in generate policies need to be added to both webhook
 		if rule.HasGenerate() {
-			matchedGVK = append(matchedGVK, rule.MatchResources.GetKinds()...)
-			matchedGVK = append(matchedGVK, rule.Generation.ResourceSpec.Kind)
-			matchedGVK = append(matchedGVK, rule.Generation.CloneList.Kinds...)
+			// generate rules process all the operations of their triggers and of the generated resources,
+			// which don't match the rule selectors
+			ops := webhookOperations(defaultOps, nil)
+			addKinds(ops, rule.MatchResources.GetKinds()...)
+			addKinds(ops, rule.Generation.ResourceSpec.Kind)
+			addKinds(ops, rule.Generation.CloneList.Kinds...)
+			for _, foreach := range rule.Generation.ForEachGeneration {
+				addKinds(ops, foreach.Kind)
+			}
+			dst.mergeSelectors(nil, nil)
 			continue
 		}
 		if (updateValidate && rule.HasValidate() || rule.HasImagesValidationChecks()) ||
 			(updateValidate && rule.HasMutate() && rule.IsMutateExisting()) ||
 			(!updateValidate && rule.HasMutate()) && !rule.IsMutateExisting() ||
 			(!updateValidate && rule.HasVerifyImages()) || (!updateValidate && rule.HasYAMLSignatureVerify()) {
-			matchedGVK = append(matchedGVK, rule.MatchResources.GetKinds()...)
-		}
-	}
-	gvkMap := make(map[string]int)
-	gvrList := make([]schema.GroupVersionResource, 0)
-	for _, gvk := range matchedGVK {
-		if _, ok := gvkMap[gvk]; !ok {
-			gvkMap[gvk] = 1
-			// NOTE: webhook stores GVR in its rules while policy stores GVK in its rules definition
-			gv, k := kubeutils.GetKindFromGVK(gvk)
-			switch k {
-			case "Binding":
-				gvrList = append(gvrList, schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods/binding"})
-			case "NodeProxyOptions":
-				gvrList = append(gvrList, schema.GroupVersionResource{Group: "", Version: "v1", Resource: "nodes/proxy"})
-			case "PodAttachOptions":
-				gvrList = append(gvrList, schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods/attach"})
-			case "PodExecOptions":
-				gvrList = append(gvrList, schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods/exec"})
-			case "PodPortForwardOptions":
-				gvrList = append(gvrList, schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods/portforward"})
-			case "PodProxyOptions":
-				gvrList = append(gvrList, schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods/proxy"})
-			case "ServiceProxyOptions":
-				gvrList = append(gvrList, schema.GroupVersionResource{Group: "", Version: "v1", Resource: "services/proxy"})
-			default:
-				_, gvr, err := c.discoveryClient.FindResource(gv, k)
-				if err != nil {
-					logger.Error(err, "unable to convert GVK to GVR", "GVK", gvk)
-					continue
-				}
-				if strings.Contains(gvk, "*") {
-					group := kubeutils.GetGroupFromGVK(gvk)
-					gvrList = append(gvrList, schema.GroupVersionResource{Group: group, Version: "*", Resource: gvr.Resource})
-				} else {
-					logger.V(4).Info("configuring webhook", "GVK", gvk, "GVR", gvr)
-					gvrList = append(gvrList, gvr)
-				}
+			ops := webhookOperations(defaultOps, ruleOperations(rule))
+			if ops.Len() == 0 {
+				continue
 			}
+			addKinds(ops, rule.MatchResources.GetKinds()...)
+			dst.mergeSelectors(ruleSelectors(rule))
 		}
 	}
-	for _, gvr := range gvrList {
-		dst.set(gvr)
+	for gvk, ops := range matchedGVK {
+		// NOTE: webhook stores GVR in its rules while policy stores GVK in its rules definition
+		gv, k := kubeutils.GetKindFromGVK(gvk)
+		var gvr schema.GroupVersionResource
+		switch k {
+		case "Binding":
+			gvr = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods/binding"}
+		case "NodeProxyOptions":
+			gvr = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "nodes/proxy"}
+		case "PodAttachOptions":
+			gvr = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods/attach"}
+		case "PodExecOptions":
+			gvr = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods/exec"}
+		case "PodPortForwardOptions":
+			gvr = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods/portforward"}
+		case "PodProxyOptions":
+			gvr = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods/proxy"}
+		case "ServiceProxyOptions":
+			gvr = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "services/proxy"}
+		default:
+			_, found, err := c.discoveryClient.FindResource(gv, k)
+			if err != nil {
+				logger.Error(err, "unable to convert GVK to GVR", "GVK", gvk)
+				continue
+			}
+			if strings.Contains(gvk, "*") {
+				group := kubeutils.GetGroupFromGVK(gvk)
+				gvr = schema.GroupVersionResource{Group: group, Version: "*", Resource: found.Resource}
+			} else {
+				logger.V(4).Info("configuring webhook", "GVK", gvk, "GVR", found)
+				gvr = found
+			}
+		}
+		dst.set(gvr, ops.List()...)
 	}
 	spec := policy.GetSpec()
 	if spec.WebhookTimeoutSeconds != nil {
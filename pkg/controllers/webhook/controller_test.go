@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coordinationv1listers "k8s.io/client-go/listers/coordination/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// fakeDiscovery resolves the kinds used by the tests
+type fakeDiscovery struct {
+	dclient.IDiscovery
+}
+
+func (fakeDiscovery) FindResource(_ string, kind string) (*metav1.APIResource, schema.GroupVersionResource, error) {
+	switch kind {
+	case "ConfigMap":
+		return nil, schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, nil
+	case "Secret":
+		return nil, schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, nil
+	}
+	return nil, schema.GroupVersionResource{}, fmt.Errorf("unknown kind %s", kind)
+}
+
+func newGroupController(t *testing.T, webhooks string, policies ...kyvernov1.PolicyInterface) *controller {
+	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	cpolIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)
+	polIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)
+	for _, policy := range policies {
+		if policy.IsNamespaced() {
+			assert.NilError(t, polIndexer.Add(policy))
+		} else {
+			assert.NilError(t, cpolIndexer.Add(policy))
+		}
+	}
+	cmIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)
+	if webhooks != "" {
+		assert.NilError(t, cmIndexer.Add(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: config.KyvernoNamespace(), Name: config.KyvernoConfigMapName()},
+			Data:       map[string]string{"webhooks": webhooks},
+		}))
+	}
+	leaseIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)
+	assert.NilError(t, leaseIndexer.Add(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   config.KyvernoNamespace(),
+			Name:        "kyverno-health",
+			Annotations: map[string]string{AnnotationLastRequestTime: time.Now().Format(time.RFC3339)},
+		},
+	}))
+	return &controller{
+		discoveryClient: fakeDiscovery{},
+		cpolLister:      kyvernov1listers.NewClusterPolicyLister(cpolIndexer),
+		polLister:       kyvernov1listers.NewPolicyLister(polIndexer),
+		configMapLister: corev1listers.NewConfigMapLister(cmIndexer),
+		leaseLister:     coordinationv1listers.NewLeaseLister(leaseIndexer),
+		defaultTimeout:  DefaultWebhookTimeout,
+		policyWebhooks:  true,
+		policyState: map[string]sets.String{
+			config.MutatingWebhookConfigurationName:   sets.NewString(),
+			config.ValidatingWebhookConfigurationName: sets.NewString(),
+		},
+	}
+}
+
+func newGroupPolicy(namespace, name, group string, failurePolicy kyvernov1.FailurePolicyType, timeout int32, kind string, namespaceSelector *metav1.LabelSelector) kyvernov1.PolicyInterface {
+	meta := metav1.ObjectMeta{Namespace: namespace, Name: name}
+	if group != "" {
+		meta.Labels = map[string]string{kyvernov1.LabelWebhookGroup: group}
+	}
+	spec := kyvernov1.Spec{
+		FailurePolicy: &failurePolicy,
+		Rules: []kyvernov1.Rule{{
+			Name: "check",
+			MatchResources: kyvernov1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds:             []string{kind},
+						NamespaceSelector: namespaceSelector,
+					},
+				}},
+			},
+			Validation: kyvernov1.Validation{
+				Message:    "label team is required",
+				RawPattern: kyvernov1.ToJSON(map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"team": "?*"}}}),
+			},
+		}},
+	}
+	if timeout != 0 {
+		spec.WebhookTimeoutSeconds = &timeout
+	}
+	if namespace != "" {
+		return &kyvernov1.Policy{ObjectMeta: meta, Spec: spec}
+	}
+	return &kyvernov1.ClusterPolicy{ObjectMeta: meta, Spec: spec}
+}
+
+func Test_buildResourceValidatingWebhookConfiguration_PolicyWebhooks(t *testing.T) {
+	teamSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	c := newGroupController(t, "",
+		newGroupPolicy("", "require-labels", "", kyvernov1.Fail, 15, "ConfigMap", teamSelector),
+		newGroupPolicy("", "check-secrets", "security", kyvernov1.Fail, 5, "Secret", nil),
+		newGroupPolicy("", "check-configmaps", "security", kyvernov1.Fail, 12, "ConfigMap", nil),
+		newGroupPolicy("", "audit-secrets", "security", kyvernov1.Ignore, 20, "Secret", nil),
+		newGroupPolicy("team-a", "check-all", "", kyvernov1.Ignore, 0, "*", nil),
+	)
+	result, err := c.buildResourceValidatingWebhookConfiguration(nil)
+	assert.NilError(t, err)
+	webhooks := map[string]admissionregistrationv1.ValidatingWebhook{}
+	for _, webhook := range result.Webhooks {
+		webhooks[webhook.Name] = webhook
+	}
+	assert.Equal(t, len(webhooks), 4)
+
+	// policy without group
+	webhook, ok := webhooks[groupWebhookName(config.ValidatingWebhookName, "cpol-require-labels/fail")]
+	assert.Assert(t, ok)
+	assert.Equal(t, *webhook.ClientConfig.Service.Path, config.ValidatingWebhookServicePath+"/group/cpol-require-labels/fail")
+	assert.Equal(t, *webhook.FailurePolicy, admissionregistrationv1.Fail)
+	assert.Equal(t, *webhook.TimeoutSeconds, int32(15))
+	assert.DeepEqual(t, webhook.NamespaceSelector, teamSelector)
+	assert.Equal(t, len(webhook.Rules), 1)
+	assert.DeepEqual(t, webhook.Rules[0].Resources, []string{"configmaps"})
+
+	// policies of the same group are split by failure policy, the timeout is the highest of the group
+	webhook, ok = webhooks[groupWebhookName(config.ValidatingWebhookName, "group-security/fail")]
+	assert.Assert(t, ok)
+	assert.Equal(t, *webhook.ClientConfig.Service.Path, config.ValidatingWebhookServicePath+"/group/group-security/fail")
+	assert.Equal(t, *webhook.FailurePolicy, admissionregistrationv1.Fail)
+	assert.Equal(t, *webhook.TimeoutSeconds, int32(12))
+	assert.Assert(t, webhook.NamespaceSelector == nil)
+	assert.Equal(t, len(webhook.Rules), 2)
+	webhook, ok = webhooks[groupWebhookName(config.ValidatingWebhookName, "group-security/ignore")]
+	assert.Assert(t, ok)
+	assert.Equal(t, *webhook.FailurePolicy, admissionregistrationv1.Ignore)
+	assert.Equal(t, *webhook.TimeoutSeconds, int32(20))
+	assert.Equal(t, len(webhook.Rules), 1)
+	assert.DeepEqual(t, webhook.Rules[0].Resources, []string{"secrets"})
+
+	// wildcard kinds only widen the webhook of their own group, the default timeout applies
+	webhook, ok = webhooks[groupWebhookName(config.ValidatingWebhookName, "pol-team-a.check-all/ignore")]
+	assert.Assert(t, ok)
+	assert.Equal(t, *webhook.FailurePolicy, admissionregistrationv1.Ignore)
+	assert.Equal(t, *webhook.TimeoutSeconds, int32(DefaultWebhookTimeout))
+	assert.Equal(t, len(webhook.Rules), 1)
+	assert.DeepEqual(t, webhook.Rules[0].APIGroups, []string{"*"})
+	assert.DeepEqual(t, webhook.Rules[0].Resources, []string{"*/*"})
+}
+
+func Test_buildResourceMutatingWebhookConfiguration_PolicyWebhooks(t *testing.T) {
+	policy := newGroupPolicy("", "add-labels", "", kyvernov1.Ignore, 17, "ConfigMap", nil)
+	spec := policy.GetSpec()
+	spec.Rules[0].Validation = kyvernov1.Validation{}
+	spec.Rules[0].Mutation = kyvernov1.Mutation{
+		RawPatchStrategicMerge: kyvernov1.ToJSON(map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"team": "a"}}}),
+	}
+	// validate policies don't register mutating webhooks
+	c := newGroupController(t, `[{"objectSelector":{"matchLabels":{"managed":"true"}}}]`,
+		policy,
+		newGroupPolicy("", "require-labels", "", kyvernov1.Fail, 0, "ConfigMap", nil),
+	)
+	result, err := c.buildResourceMutatingWebhookConfiguration(nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(result.Webhooks), 1)
+	webhook := result.Webhooks[0]
+	assert.Equal(t, webhook.Name, groupWebhookName(config.MutatingWebhookName, "cpol-add-labels/ignore"))
+	assert.Equal(t, *webhook.ClientConfig.Service.Path, config.MutatingWebhookServicePath+"/group/cpol-add-labels/ignore")
+	assert.Equal(t, *webhook.FailurePolicy, admissionregistrationv1.Ignore)
+	assert.Equal(t, *webhook.TimeoutSeconds, int32(17))
+	assert.Equal(t, *webhook.ReinvocationPolicy, admissionregistrationv1.IfNeededReinvocationPolicy)
+	// the selectors of the Kyverno ConfigMap apply to every group
+	assert.DeepEqual(t, webhook.ObjectSelector, &metav1.LabelSelector{MatchLabels: map[string]string{"managed": "true"}})
+	assert.Equal(t, len(webhook.Rules), 1)
+	assert.DeepEqual(t, webhook.Rules[0].Operations, mutatingOperations)
+}
+
+func Test_buildExceptionValidatingWebhookConfiguration(t *testing.T) {
+	c := newGroupController(t, "")
+	result, err := c.buildExceptionValidatingWebhookConfiguration(nil)
+	assert.NilError(t, err)
+	assert.Equal(t, result.Name, config.ExceptionValidatingWebhookConfigurationName)
+	assert.Equal(t, len(result.Webhooks), 1)
+	webhook := result.Webhooks[0]
+	assert.Equal(t, *webhook.ClientConfig.Service.Path, config.ExceptionValidatingWebhookServicePath)
+	assert.Equal(t, *webhook.FailurePolicy, admissionregistrationv1.Fail)
+	assert.DeepEqual(t, webhook.Rules[0].Resources, []string{"policyexceptions"})
+
+	// policy exceptions are not sent to the policy webhook, its failure policy is ignore
+	result, err = c.buildPolicyValidatingWebhookConfiguration(nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(result.Webhooks[0].Rules), 1)
+	assert.DeepEqual(t, result.Webhooks[0].Rules[0].Resources, []string{"clusterpolicies/*", "policies/*"})
+}
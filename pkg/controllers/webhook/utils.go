@@ -1,10 +1,15 @@
 package webhook
 
 import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
 	"strings"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/utils"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"golang.org/x/exp/slices"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,20 +22,58 @@ import (
 type webhook struct {
 	maxWebhookTimeout int32
 	failurePolicy     admissionregistrationv1.FailurePolicyType
-	rules             map[schema.GroupVersionResource]struct{}
+	// rules maps the GVRs to the admission operations of the policies, nil operations mean all operations
+	rules map[schema.GroupVersionResource]sets.String
+	// namespaceSelector and objectSelector are the selectors shared by the policies of the webhook
+	namespaceSelector sharedSelector
+	objectSelector    sharedSelector
+}
+
+// groupWebhook is the webhook of a policy group when resource webhooks are configured per policy,
+// the key is made of the policy group and the failure policy
+type groupWebhook struct {
+	*webhook
+	key      string
+	wildcard bool
+}
+
+// groupWebhookName returns a valid and unique webhook name for the group key, the name starts with a
+// readable prefix of the key followed by a hash of the key
+func groupWebhookName(name string, key string) string {
+	prefix := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(key))
+	if len(prefix) > 50 {
+		prefix = prefix[:50]
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return fmt.Sprintf("%s-%08x.%s", strings.Trim(prefix, "-"), h.Sum32(), name)
 }
 
 func newWebhook(timeout int32, failurePolicy admissionregistrationv1.FailurePolicyType) *webhook {
 	return &webhook{
 		maxWebhookTimeout: timeout,
 		failurePolicy:     failurePolicy,
-		rules:             map[schema.GroupVersionResource]struct{}{},
+		rules:             map[schema.GroupVersionResource]sets.String{},
 	}
 }
 
 func (wh *webhook) buildRulesWithOperations(ops ...admissionregistrationv1.OperationType) []admissionregistrationv1.RuleWithOperations {
 	var rules []admissionregistrationv1.RuleWithOperations
-	for gvr := range wh.rules {
+	for gvr, operations := range wh.rules {
+		var ruleOps []admissionregistrationv1.OperationType
+		for _, op := range ops {
+			if operations == nil || operations.Has(string(op)) {
+				ruleOps = append(ruleOps, op)
+			}
+		}
+		if len(ruleOps) == 0 {
+			continue
+		}
 		resources := sets.NewString(gvr.Resource)
 		if resources.Has("pods") {
 			resources.Insert("pods/ephemeralcontainers")
@@ -44,7 +87,7 @@ func (wh *webhook) buildRulesWithOperations(ops ...admissionregistrationv1.Opera
 				APIVersions: []string{gvr.Version},
 				Resources:   resources.List(),
 			},
-			Operations: ops,
+			Operations: ruleOps,
 		})
 	}
 	less := func(a []string, b []string) (bool, bool) {
@@ -73,8 +116,12 @@ func (wh *webhook) buildRulesWithOperations(ops ...admissionregistrationv1.Opera
 	return rules
 }
 
-func (wh *webhook) set(gvr schema.GroupVersionResource) {
-	wh.rules[gvr] = struct{}{}
+// set adds the GVR to the webhook rules, the operations are merged with the ones already set for the GVR
+func (wh *webhook) set(gvr schema.GroupVersionResource, ops ...string) {
+	if _, ok := wh.rules[gvr]; !ok {
+		wh.rules[gvr] = sets.NewString()
+	}
+	wh.rules[gvr].Insert(ops...)
 }
 
 func (wh *webhook) isEmpty() bool {
@@ -82,9 +129,173 @@ func (wh *webhook) isEmpty() bool {
 }
 
 func (wh *webhook) setWildcard() {
-	wh.rules = map[schema.GroupVersionResource]struct{}{
-		{Group: "*", Version: "*", Resource: "*/*"}: {},
+	wh.rules = map[schema.GroupVersionResource]sets.String{
+		{Group: "*", Version: "*", Resource: "*/*"}: nil,
+	}
+}
+
+// mergeSelectors merges the namespace and object selectors of a policy rule,
+// nil selectors prevent the webhook from using selectors
+func (wh *webhook) mergeSelectors(namespaceSelector, objectSelector *metav1.LabelSelector) {
+	wh.namespaceSelector.merge(namespaceSelector)
+	wh.objectSelector.merge(objectSelector)
+}
+
+// sharedSelector aggregates the selectors of the policy rules, it is only set when all the rules have the same selector
+type sharedSelector struct {
+	merged   bool
+	selector *metav1.LabelSelector
+}
+
+func (s *sharedSelector) merge(selector *metav1.LabelSelector) {
+	if !s.merged {
+		s.merged = true
+		s.selector = selector
+	} else if !reflect.DeepEqual(s.selector, selector) {
+		s.selector = nil
+	}
+}
+
+func (s *sharedSelector) get() *metav1.LabelSelector {
+	return s.selector
+}
+
+// mergeLabelSelectors returns a selector matching the objects selected by both selectors
+func mergeLabelSelectors(a, b *metav1.LabelSelector) *metav1.LabelSelector {
+	if a == nil {
+		if b == nil {
+			return nil
+		}
+		return b.DeepCopy()
+	}
+	if b == nil {
+		return a
+	}
+	merged := a.DeepCopy()
+	keys := make([]string, 0, len(b.MatchLabels))
+	for key := range b.MatchLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := b.MatchLabels[key]
+		if current, ok := merged.MatchLabels[key]; ok && current != value {
+			merged.MatchExpressions = append(merged.MatchExpressions, metav1.LabelSelectorRequirement{
+				Key:      key,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{value},
+			})
+			continue
+		}
+		if merged.MatchLabels == nil {
+			merged.MatchLabels = map[string]string{}
+		}
+		merged.MatchLabels[key] = value
+	}
+	for _, expression := range b.MatchExpressions {
+		merged.MatchExpressions = append(merged.MatchExpressions, *expression.DeepCopy())
+	}
+	return merged
+}
+
+// ruleOperations returns the admission operations matched by the rule, nil means all operations
+func ruleOperations(rule kyvernov1.Rule) []kyvernov1.AdmissionOperation {
+	match := rule.MatchResources
+	if len(match.Any) > 0 {
+		operations := sets.NewString()
+		for _, filter := range match.Any {
+			if len(filter.Operations) == 0 {
+				return nil
+			}
+			for _, op := range filter.Operations {
+				operations.Insert(string(op))
+			}
+		}
+		return toOperations(operations)
+	}
+	if len(match.All) > 0 {
+		var operations sets.String
+		for _, filter := range match.All {
+			if len(filter.Operations) == 0 {
+				continue
+			}
+			filterOps := sets.NewString()
+			for _, op := range filter.Operations {
+				filterOps.Insert(string(op))
+			}
+			if operations == nil {
+				operations = filterOps
+			} else {
+				operations = operations.Intersection(filterOps)
+			}
+		}
+		if operations == nil {
+			return nil
+		}
+		return toOperations(operations)
+	}
+	return match.Operations
+}
+
+// webhookOperations returns the webhook operations matched by the rule operations, nil rule operations match all the webhook operations
+func webhookOperations(webhookOps []admissionregistrationv1.OperationType, ruleOps []kyvernov1.AdmissionOperation) sets.String {
+	result := sets.NewString()
+	for _, op := range webhookOps {
+		if ruleOps == nil || slices.Contains(ruleOps, kyvernov1.AdmissionOperation(op)) {
+			result.Insert(string(op))
+		}
+	}
+	return result
+}
+
+func toOperations(operations sets.String) []kyvernov1.AdmissionOperation {
+	result := []kyvernov1.AdmissionOperation{}
+	for _, op := range operations.List() {
+		result = append(result, kyvernov1.AdmissionOperation(op))
+	}
+	return result
+}
+
+// ruleSelectors returns the namespace and object selectors shared by all the match filters of the rule,
+// selectors with wildcards can't be evaluated by the API server and are ignored
+func ruleSelectors(rule kyvernov1.Rule) (*metav1.LabelSelector, *metav1.LabelSelector) {
+	var descriptions []kyvernov1.ResourceDescription
+	for _, filter := range rule.MatchResources.Any {
+		descriptions = append(descriptions, filter.ResourceDescription)
+	}
+	for _, filter := range rule.MatchResources.All {
+		descriptions = append(descriptions, filter.ResourceDescription)
+	}
+	if len(descriptions) == 0 {
+		descriptions = append(descriptions, rule.MatchResources.ResourceDescription)
+	}
+	namespaceSelector := commonSelector(descriptions, func(description kyvernov1.ResourceDescription) *metav1.LabelSelector {
+		return description.NamespaceSelector
+	})
+	objectSelector := commonSelector(descriptions, func(description kyvernov1.ResourceDescription) *metav1.LabelSelector {
+		return description.Selector
+	})
+	// the API server evaluates the namespace selector against the labels of the namespaces themselves,
+	// while policies don't apply namespace selectors to namespaces
+	for _, kind := range rule.MatchResources.GetKinds() {
+		if _, k := kubeutils.GetKindFromGVK(kind); k == "Namespace" || strings.Contains(k, "*") {
+			namespaceSelector = nil
+		}
+	}
+	return namespaceSelector, objectSelector
+}
+
+func commonSelector(descriptions []kyvernov1.ResourceDescription, get func(kyvernov1.ResourceDescription) *metav1.LabelSelector) *metav1.LabelSelector {
+	selector := get(descriptions[0])
+	if selector == nil || kubeutils.LabelSelectorContainsWildcard(selector) {
+		return nil
+	}
+	for _, description := range descriptions[1:] {
+		if !reflect.DeepEqual(selector, get(description)) {
+			return nil
+		}
 	}
+	return selector
 }
 
 func hasWildcard(policies ...kyvernov1.PolicyInterface) bool {
@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -9,6 +10,8 @@ import (
 
 	"gotest.tools/assert"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func Test_webhook_isEmpty(t *testing.T) {
@@ -157,3 +160,98 @@ func Test_RuleCount(t *testing.T) {
 	assert.Equal(t, status.RuleCount.Mutate, 1)
 	assert.Equal(t, status.RuleCount.VerifyImages, 2)
 }
+
+func Test_webhook_buildRulesWithOperations(t *testing.T) {
+	wh := newWebhook(DefaultWebhookTimeout, admissionregistrationv1.Ignore)
+	wh.set(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}, "CREATE")
+	wh.set(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}, "DELETE")
+	wh.set(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}, "DELETE")
+	rules := wh.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update)
+	assert.Equal(t, len(rules), 1)
+	assert.DeepEqual(t, rules[0].Resources, []string{"configmaps"})
+	assert.DeepEqual(t, rules[0].Operations, []admissionregistrationv1.OperationType{admissionregistrationv1.Create})
+
+	wh.setWildcard()
+	rules = wh.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update)
+	assert.DeepEqual(t, rules[0].Operations, []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update})
+}
+
+func Test_ruleOperations(t *testing.T) {
+	filter := func(ops ...kyverno.AdmissionOperation) kyverno.ResourceFilter {
+		return kyverno.ResourceFilter{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Operations: ops}}
+	}
+	testCases := []struct {
+		name  string
+		match kyverno.MatchResources
+		want  []kyverno.AdmissionOperation
+	}{
+		{"no operations", kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}}}, nil},
+		{"operations", kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Operations: []kyverno.AdmissionOperation{kyverno.Create}}}, []kyverno.AdmissionOperation{kyverno.Create}},
+		{"any union", kyverno.MatchResources{Any: kyverno.ResourceFilters{filter(kyverno.Create), filter(kyverno.Update)}}, []kyverno.AdmissionOperation{kyverno.Create, kyverno.Update}},
+		{"any without operations", kyverno.MatchResources{Any: kyverno.ResourceFilters{filter(kyverno.Create), filter()}}, nil},
+		{"all intersection", kyverno.MatchResources{All: kyverno.ResourceFilters{filter(kyverno.Create, kyverno.Update), filter(kyverno.Update), filter()}}, []kyverno.AdmissionOperation{kyverno.Update}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.DeepEqual(t, ruleOperations(kyverno.Rule{MatchResources: tc.match}), tc.want)
+		})
+	}
+}
+
+func Test_ruleSelectors(t *testing.T) {
+	team := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	filter := func(kind string, namespaceSelector, selector *metav1.LabelSelector) kyverno.ResourceFilter {
+		return kyverno.ResourceFilter{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{kind}, NamespaceSelector: namespaceSelector, Selector: selector}}
+	}
+	testCases := []struct {
+		name              string
+		match             kyverno.MatchResources
+		namespaceSelector *metav1.LabelSelector
+		objectSelector    *metav1.LabelSelector
+	}{
+		{"shared", kyverno.MatchResources{Any: kyverno.ResourceFilters{filter("Pod", team, team), filter("ConfigMap", team, team)}}, team, team},
+		{"not shared", kyverno.MatchResources{Any: kyverno.ResourceFilters{filter("Pod", team, nil), filter("ConfigMap", nil, team)}}, nil, nil},
+		{"wildcard", kyverno.MatchResources{Any: kyverno.ResourceFilters{filter("Pod", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "*"}}, nil)}}, nil, nil},
+		{"namespaces", kyverno.MatchResources{Any: kyverno.ResourceFilters{filter("Namespace", team, team)}}, nil, team},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			namespaceSelector, objectSelector := ruleSelectors(kyverno.Rule{MatchResources: tc.match})
+			assert.DeepEqual(t, namespaceSelector, tc.namespaceSelector)
+			assert.DeepEqual(t, objectSelector, tc.objectSelector)
+		})
+	}
+}
+
+func Test_mergeLabelSelectors(t *testing.T) {
+	config := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"env": "prod"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "kubernetes.io/metadata.name", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kyverno"}},
+		},
+	}
+	policy := &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev", "team": "a"}}
+	assert.Assert(t, mergeLabelSelectors(nil, nil) == nil)
+	assert.DeepEqual(t, mergeLabelSelectors(config, nil), config)
+	assert.DeepEqual(t, mergeLabelSelectors(nil, policy), policy)
+	assert.DeepEqual(t, mergeLabelSelectors(config, policy), &metav1.LabelSelector{
+		MatchLabels: map[string]string{"env": "prod", "team": "a"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "kubernetes.io/metadata.name", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kyverno"}},
+			{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"dev"}},
+		},
+	})
+}
+
+func Test_groupWebhookName(t *testing.T) {
+	name := groupWebhookName("mutate.kyverno.svc", "cpol-require-labels/fail")
+	assert.Assert(t, strings.HasPrefix(name, "cpol-require-labels-fail-"), name)
+	assert.Assert(t, strings.HasSuffix(name, ".mutate.kyverno.svc"), name)
+	assert.Equal(t, name, groupWebhookName("mutate.kyverno.svc", "cpol-require-labels/fail"))
+	// keys differing only by invalid characters don't collide
+	assert.Assert(t, groupWebhookName("mutate.kyverno.svc", "pol-ns.a/fail") != groupWebhookName("mutate.kyverno.svc", "pol-ns-a/fail"))
+	// long keys are truncated
+	long := groupWebhookName("mutate.kyverno.svc", "group-"+strings.Repeat("a", 100)+"/ignore")
+	assert.Equal(t, len(long), 50+1+8+len(".mutate.kyverno.svc"))
+	assert.Assert(t, !strings.Contains(long[:50], "/"))
+}
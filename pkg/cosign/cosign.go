@@ -47,6 +47,8 @@ type Options struct {
 	Repository           string
 	RekorURL             string
 	SignatureAlgorithm   string
+	// TrustedIdentities are the subjects of the certificates trusted to sign the image, used by the notary verifier
+	TrustedIdentities []string
 }
 
 type Response struct {
@@ -0,0 +1,20 @@
+package cosign
+
+import "context"
+
+// Verifier verifies the signatures of an image, it is implemented by the cosign and notary verifiers
+type Verifier interface {
+	// Verify verifies the signatures, or fetches the attestations, of the image and returns its digest
+	Verify(ctx context.Context, opts Options) (*Response, error)
+}
+
+// NewVerifier returns the verifier of cosign signatures and attestations
+func NewVerifier() Verifier {
+	return &verifier{}
+}
+
+type verifier struct{}
+
+func (v *verifier) Verify(_ context.Context, opts Options) (*Response, error) {
+	return Verify(opts)
+}
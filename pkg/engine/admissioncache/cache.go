@@ -0,0 +1,130 @@
+package admissioncache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// DefaultMaxSize is the default maximum number of admission requests stored in the cache
+	DefaultMaxSize = 1000
+	// DefaultTTL is the default duration the data of an admission request is kept, it covers the
+	// mutating and validating webhook calls of the request
+	DefaultTTL = time.Minute
+)
+
+// DefaultCache is the cache shared by the mutating and validating resource webhooks
+var DefaultCache = NewCache(DefaultMaxSize, DefaultTTL)
+
+// Cache stores the data computed for admission requests, keyed by the content of the admission
+// request (see RequestKey), so that the webhook calls of the same request don't compute it again.
+// The key doesn't identify the object of the request, objects created with generateName are identical
+// at admission. Only the data depending on the user and the namespace of the request (RBAC bindings and
+// namespace labels) can be shared, context entries are scoped to a single webhook call (see NewRequestCache).
+type Cache interface {
+	// ForRequest returns the data of the admission request with the given key
+	ForRequest(string) RequestCache
+}
+
+// RequestKey computes the cache key of an admission request. The API server sends a different UID to
+// each webhook call, the key is derived from the resource, the operation, the user and the old object
+// instead, which are the same for the mutating and validating webhook calls of a request.
+func RequestKey(request *admissionv1.AdmissionRequest) string {
+	if request == nil {
+		return ""
+	}
+	oldObject := sha256.Sum256(request.OldObject.Raw)
+	hash := sha256.New()
+	for _, field := range []string{
+		request.Resource.Group,
+		request.Resource.Version,
+		request.Resource.Resource,
+		request.SubResource,
+		request.Namespace,
+		request.Name,
+		string(request.Operation),
+		request.UserInfo.Username,
+		request.UserInfo.UID,
+		strings.Join(request.UserInfo.Groups, ","),
+		hex.EncodeToString(oldObject[:]),
+	} {
+		hash.Write([]byte(field))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// RequestCache stores the data computed for an admission request
+type RequestCache interface {
+	// GetOrCompute returns the data stored for the key or computes and stores it, errors are not stored
+	GetOrCompute(string, func() (interface{}, error)) (interface{}, error)
+}
+
+type admissionCache struct {
+	lock  sync.Mutex
+	store *cache.LRUExpireCache
+	ttl   time.Duration
+}
+
+// NewCache creates a size bounded cache keeping the data of admission requests for the given ttl,
+// the least recently used requests are evicted when the cache is full. A ttl of zero disables
+// sharing the data across webhook calls, it is still computed once per call.
+func NewCache(maxSize int, ttl time.Duration) Cache {
+	return &admissionCache{
+		store: cache.NewLRUExpireCache(maxSize),
+		ttl:   ttl,
+	}
+}
+
+func (c *admissionCache) ForRequest(key string) RequestCache {
+	if c.ttl <= 0 || key == "" {
+		return newRequestCache()
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if data, ok := c.store.Get(key); ok {
+		return data.(*requestCache)
+	}
+	data := newRequestCache()
+	c.store.Add(key, data, c.ttl)
+	return data
+}
+
+type requestCache struct {
+	lock sync.Mutex
+	data map[string]interface{}
+}
+
+// NewRequestCache creates a request cache holding the data computed within a single webhook call
+func NewRequestCache() RequestCache {
+	return newRequestCache()
+}
+
+func newRequestCache() *requestCache {
+	return &requestCache{
+		data: map[string]interface{}{},
+	}
+}
+
+func (c *requestCache) GetOrCompute(key string, compute func() (interface{}, error)) (interface{}, error) {
+	c.lock.Lock()
+	data, ok := c.data[key]
+	c.lock.Unlock()
+	if ok {
+		return data, nil
+	}
+	data, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.data[key] = data
+	return data, nil
+}
@@ -0,0 +1,106 @@
+package admissioncache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newRequest(uid string) *admissionv1.AdmissionRequest {
+	return &admissionv1.AdmissionRequest{
+		UID:       types.UID(uid),
+		Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		Namespace: "default",
+		Name:      "test",
+		Operation: admissionv1.Update,
+		UserInfo:  authenticationv1.UserInfo{Username: "alice", Groups: []string{"dev"}},
+		OldObject: runtime.RawExtension{Raw: []byte(`{"data":{"key":"old"}}`)},
+	}
+}
+
+func Test_RequestKey(t *testing.T) {
+	// the webhook calls of a request have different UIDs
+	assert.Equal(t, RequestKey(newRequest("uid-1")), RequestKey(newRequest("uid-2")))
+
+	user := newRequest("uid-1")
+	user.UserInfo.Username = "bob"
+	operation := newRequest("uid-1")
+	operation.Operation = admissionv1.Delete
+	name := newRequest("uid-1")
+	name.Name = "other"
+	oldObject := newRequest("uid-1")
+	oldObject.OldObject.Raw = []byte(`{"data":{"key":"new"}}`)
+	for _, request := range []*admissionv1.AdmissionRequest{user, operation, name, oldObject} {
+		assert.Assert(t, RequestKey(request) != RequestKey(newRequest("uid-1")))
+	}
+	assert.Equal(t, RequestKey(nil), "")
+}
+
+func Test_Cache(t *testing.T) {
+	cache := NewCache(2, time.Minute)
+	calls := 0
+	compute := func() (interface{}, error) {
+		calls++
+		return "labels", nil
+	}
+	data, err := cache.ForRequest("uid-1").GetOrCompute("namespaceLabels", compute)
+	assert.NilError(t, err)
+	assert.Equal(t, data, "labels")
+	// the webhook calls of the same request share the data
+	data, err = cache.ForRequest("uid-1").GetOrCompute("namespaceLabels", compute)
+	assert.NilError(t, err)
+	assert.Equal(t, data, "labels")
+	assert.Equal(t, calls, 1)
+	// other requests don't
+	_, err = cache.ForRequest("uid-2").GetOrCompute("namespaceLabels", compute)
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 2)
+}
+
+func Test_Cache_Errors(t *testing.T) {
+	request := NewCache(2, time.Minute).ForRequest("uid-1")
+	_, err := request.GetOrCompute("roles", func() (interface{}, error) {
+		return nil, errors.New("failed")
+	})
+	assert.Error(t, err, "failed")
+	data, err := request.GetOrCompute("roles", func() (interface{}, error) {
+		return "roles", nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, data, "roles")
+}
+
+func Test_Cache_Disabled(t *testing.T) {
+	cache := NewCache(2, 0)
+	calls := 0
+	compute := func() (interface{}, error) {
+		calls++
+		return "labels", nil
+	}
+	request := cache.ForRequest("uid-1")
+	_, _ = request.GetOrCompute("namespaceLabels", compute)
+	_, _ = request.GetOrCompute("namespaceLabels", compute)
+	assert.Equal(t, calls, 1)
+	_, _ = cache.ForRequest("uid-1").GetOrCompute("namespaceLabels", compute)
+	assert.Equal(t, calls, 2)
+}
+
+func Test_Cache_Expiration(t *testing.T) {
+	cache := NewCache(2, time.Millisecond)
+	calls := 0
+	compute := func() (interface{}, error) {
+		calls++
+		return "labels", nil
+	}
+	_, _ = cache.ForRequest("uid-1").GetOrCompute("namespaceLabels", compute)
+	time.Sleep(5 * time.Millisecond)
+	_, _ = cache.ForRequest("uid-1").GetOrCompute("namespaceLabels", compute)
+	assert.Equal(t, calls, 2)
+}
@@ -86,10 +86,10 @@ func filterRule(rule kyvernov1.Rule, policyContext *PolicyContext) *response.Rul
 	logger := logging.WithName(string(ruleType)).WithValues("policy", policy.GetName(),
 		"kind", newResource.GetKind(), "namespace", newResource.GetNamespace(), "name", newResource.GetName())
 
-	if err = MatchesResourceDescription(newResource, rule, admissionInfo, excludeGroupRole, namespaceLabels, ""); err != nil {
+	if err = MatchesResourceDescription(newResource, rule, admissionInfo, excludeGroupRole, namespaceLabels, "", policyContext.requestOperation()); err != nil {
 		if ruleType == response.Generation {
 			// if the oldResource matched, return "false" to delete GR for it
-			if err = MatchesResourceDescription(oldResource, rule, admissionInfo, excludeGroupRole, namespaceLabels, ""); err == nil {
+			if err = MatchesResourceDescription(oldResource, rule, admissionInfo, excludeGroupRole, namespaceLabels, "", policyContext.requestOperation()); err == nil {
 				return &response.RuleResponse{
 					Name:   rule.Name,
 					Type:   ruleType,
@@ -105,6 +105,15 @@ func filterRule(rule kyvernov1.Rule, policyContext *PolicyContext) *response.Rul
 		return nil
 	}
 
+	if exception := findException(logger, &rule, policyContext); exception != nil {
+		ruleResp := ruleException(&rule, ruleType, exception)
+		ruleResp.RuleStats = response.RuleStats{
+			ProcessingTime:         time.Since(startTime),
+			RuleExecutionTimestamp: startTime.Unix(),
+		}
+		return ruleResp
+	}
+
 	policyContext.JSONContext.Checkpoint()
 	defer policyContext.JSONContext.Restore()
 
@@ -0,0 +1,57 @@
+package contextcache
+
+import (
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// DefaultMaxSize is the default maximum number of entries stored in the cache
+const DefaultMaxSize = 1000
+
+// DefaultCache is the cache shared by context entries declaring a cache TTL
+var DefaultCache = NewCache(DefaultMaxSize, nil)
+
+// Cache stores the data fetched by context entries so that it can be shared across requests
+type Cache interface {
+	// Get returns the data stored for an entry type and key, if present and not expired
+	Get(metrics.ContextEntryType, string) (interface{}, bool)
+	// Set stores the data for an entry type and key until the ttl expires
+	Set(metrics.ContextEntryType, string, interface{}, time.Duration)
+}
+
+type entryKey struct {
+	entryType metrics.ContextEntryType
+	key       string
+}
+
+type contextCache struct {
+	store         *cache.LRUExpireCache
+	metricsConfig metrics.MetricsConfigManager
+}
+
+// NewCache creates a size bounded cache, the least recently used entries are evicted when
+// the cache is full. Hits and misses are recorded if metricsConfig is not nil.
+func NewCache(maxSize int, metricsConfig metrics.MetricsConfigManager) Cache {
+	return &contextCache{
+		store:         cache.NewLRUExpireCache(maxSize),
+		metricsConfig: metricsConfig,
+	}
+}
+
+func (c *contextCache) Get(entryType metrics.ContextEntryType, key string) (interface{}, bool) {
+	data, ok := c.store.Get(entryKey{entryType: entryType, key: key})
+	if c.metricsConfig != nil {
+		if ok {
+			c.metricsConfig.RecordContextCacheRequests(entryType, metrics.CacheHit)
+		} else {
+			c.metricsConfig.RecordContextCacheRequests(entryType, metrics.CacheMiss)
+		}
+	}
+	return data, ok
+}
+
+func (c *contextCache) Set(entryType metrics.ContextEntryType, key string, data interface{}, ttl time.Duration) {
+	c.store.Add(entryKey{entryType: entryType, key: key}, data, ttl)
+}
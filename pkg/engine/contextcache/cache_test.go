@@ -0,0 +1,39 @@
+package contextcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"gotest.tools/assert"
+)
+
+func Test_Cache(t *testing.T) {
+	cache := NewCache(2, nil)
+	cache.Set(metrics.ContextEntryAPICall, "/api/v1/namespaces", []byte(`{"items":[]}`), time.Minute)
+	data, ok := cache.Get(metrics.ContextEntryAPICall, "/api/v1/namespaces")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, data, []byte(`{"items":[]}`))
+	// entry types don't share keys
+	_, ok = cache.Get(metrics.ContextEntryImageRegistry, "/api/v1/namespaces")
+	assert.Assert(t, !ok)
+}
+
+func Test_Cache_Expiration(t *testing.T) {
+	cache := NewCache(2, nil)
+	cache.Set(metrics.ContextEntryImageRegistry, "nginx:latest", "data", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	_, ok := cache.Get(metrics.ContextEntryImageRegistry, "nginx:latest")
+	assert.Assert(t, !ok)
+}
+
+func Test_Cache_MaxSize(t *testing.T) {
+	cache := NewCache(2, nil)
+	cache.Set(metrics.ContextEntryImageRegistry, "nginx:1", "1", time.Minute)
+	cache.Set(metrics.ContextEntryImageRegistry, "nginx:2", "2", time.Minute)
+	cache.Set(metrics.ContextEntryImageRegistry, "nginx:3", "3", time.Minute)
+	_, ok := cache.Get(metrics.ContextEntryImageRegistry, "nginx:1")
+	assert.Assert(t, !ok)
+	_, ok = cache.Get(metrics.ContextEntryImageRegistry, "nginx:3")
+	assert.Assert(t, ok)
+}
@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ExceptionNamespace is the namespace of the policy exceptions applying to resources of all namespaces,
+// exceptions created in other namespaces only apply to the resources of their own namespace
+var ExceptionNamespace string
+
+// exceptionApplies returns true if the exception is allowed to exempt a resource of the given namespace
+func exceptionApplies(exception *kyvernov1alpha1.PolicyException, resourceNamespace string) bool {
+	if ExceptionNamespace != "" && exception.GetNamespace() == ExceptionNamespace {
+		return true
+	}
+	return resourceNamespace != "" && exception.GetNamespace() == resourceNamespace
+}
+
+// findException returns the first policy exception that applies to the rule and matches the resource, if any
+func findException(logger logr.Logger, rule *kyvernov1.Rule, policyContext *PolicyContext) *kyvernov1alpha1.PolicyException {
+	if policyContext.ExceptionLister == nil {
+		return nil
+	}
+	policyName, err := cache.MetaNamespaceKeyFunc(policyContext.Policy)
+	if err != nil {
+		logger.Error(err, "failed to compute policy key")
+		return nil
+	}
+	exceptions, err := policyContext.ExceptionLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policy exceptions")
+		return nil
+	}
+	resourceNamespace := policyContext.NewResource.GetNamespace()
+	if policyContext.NewResource.Object == nil {
+		resourceNamespace = policyContext.OldResource.GetNamespace()
+	}
+	for _, exception := range exceptions {
+		if !exceptionApplies(exception, resourceNamespace) || !exception.Contains(policyName, rule.Name) {
+			continue
+		}
+		// the match block of the exception is evaluated like the match block of a rule
+		if matches(logger, &kyvernov1.Rule{MatchResources: exception.Spec.Match}, policyContext) {
+			logger.V(3).Info("rule skipped due to policy exception", "exception", exception.GetNamespace()+"/"+exception.GetName())
+			return exception
+		}
+	}
+	return nil
+}
+
+// ruleException builds the response of a rule skipped because of a policy exception
+func ruleException(rule *kyvernov1.Rule, ruleType response.RuleType, exception *kyvernov1alpha1.PolicyException) *response.RuleResponse {
+	key, _ := cache.MetaNamespaceKeyFunc(exception)
+	return ruleResponse(*rule, ruleType, fmt.Sprintf("rule skipped due to policy exception %s", key), response.RuleStatusSkip, nil)
+}
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -13,9 +15,11 @@ import (
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/notary"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	"github.com/kyverno/kyverno/pkg/utils/jsonpointer"
@@ -23,6 +27,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
 )
 
 func getMatchingImages(images map[string]map[string]apiutils.ImageInfo, rule *kyvernov1.Rule) ([]apiutils.ImageInfo, string) {
@@ -71,6 +76,7 @@ func VerifyAndPatchImages(policyContext *PolicyContext) (*response.EngineRespons
 		"kind", patchedResource.GetKind(), "namespace", patchedResource.GetNamespace(), "name", patchedResource.GetName())
 
 	startTime := time.Now()
+	defer policyContext.startExecutionBudget()()
 	defer func() {
 		buildResponse(policyContext, resp, startTime)
 		logger.V(4).Info("processed image verification rules",
@@ -100,7 +106,17 @@ func VerifyAndPatchImages(policyContext *PolicyContext) (*response.EngineRespons
 			continue
 		}
 
+		if exception := findException(logger, rule, policyContext); exception != nil {
+			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleException(rule, response.ImageVerify, exception))
+			continue
+		}
+
 		logger.V(3).Info("processing image verification rule", "ruleSelector", applyRules)
+		if policyContext.executionBudgetExceeded() {
+			logger.V(2).Info("policy execution budget exceeded, skipping rule evaluation")
+			appendResponse(resp, rule, executionBudgetMessage(policyContext), response.RuleStatusTimeout)
+			continue
+		}
 
 		var err error
 		ruleImages, imageRefs, err := extractMatchingImages(policyContext, rule)
@@ -117,7 +133,11 @@ func VerifyAndPatchImages(policyContext *PolicyContext) (*response.EngineRespons
 
 		policyContext.JSONContext.Restore()
 		if err := LoadContext(logger, rule.Context, policyContext, rule.Name); err != nil {
-			appendResponse(resp, rule, fmt.Sprintf("failed to load context: %s", err.Error()), response.RuleStatusError)
+			if policyContext.executionBudgetExceeded() {
+				appendResponse(resp, rule, fmt.Sprintf("%s: failed to load context: %s", executionBudgetMessage(policyContext), err.Error()), response.RuleStatusTimeout)
+			} else {
+				appendResponse(resp, rule, fmt.Sprintf("failed to load context: %s", err.Error()), response.RuleStatusError)
+			}
 			continue
 		}
 
@@ -135,9 +155,13 @@ func VerifyAndPatchImages(policyContext *PolicyContext) (*response.EngineRespons
 			ivm:           ivm,
 		}
 
+		ruleResponses := len(resp.PolicyResponse.Rules)
 		for _, imageVerify := range ruleCopy.VerifyImages {
 			iv.verify(imageVerify, ruleImages)
 		}
+		for i := ruleResponses; i < len(resp.PolicyResponse.Rules); i++ {
+			checkRuleTimeout(policyContext, &resp.PolicyResponse.Rules[i])
+		}
 
 		if applyRules == kyvernov1.ApplyOne && resp.PolicyResponse.RulesAppliedCount > 0 {
 			break
@@ -248,7 +272,7 @@ func (iv *imageVerifier) handleMutateDigest(digest string, imageInfo apiutils.Im
 	}
 
 	if digest == "" {
-		desc, err := registryclient.DefaultClient.FetchImageDescriptor(imageInfo.String())
+		desc, err := registryclient.DefaultClient.FetchImageDescriptor(iv.policyContext.getContext(), imageInfo.String())
 		if err != nil {
 			return nil, "", err
 		}
@@ -356,9 +380,20 @@ func (iv *imageVerifier) verifyAttestorSet(attestorSet kyvernov1.AttestorSet, im
 				attestorPath += ".attestor"
 				cosignResp, entryError = iv.verifyAttestorSet(*nestedAttestorSet, imageVerify, imageInfo, attestorPath)
 			}
+		} else if a.Notary != nil {
+			cosignResp, entryError = iv.verifyNotary(a, imageVerify, image, imageInfo.Digest)
+			if entryError != nil {
+				entryError = errors.Wrapf(entryError, attestorPath+".notary")
+			}
 		} else {
 			opts, subPath := iv.buildOptionsAndPath(a, imageVerify, image)
-			cosignResp, entryError = cosign.Verify(*opts)
+			var secret string
+			if a.Keys != nil && a.Keys.Secret != nil {
+				secret = a.Keys.Secret.Namespace + "/" + a.Keys.Secret.Name
+			}
+			cosignResp, entryError = iv.verifyCached(imageInfo.Digest, secret, opts, func() (*cosign.Response, error) {
+				return cosign.NewVerifier().Verify(iv.policyContext.getContext(), *opts)
+			})
 			if entryError == nil && opts.FetchAttestations {
 				entryError = iv.verifyAttestations(cosignResp.Statements, imageVerify, imageInfo)
 			}
@@ -384,6 +419,77 @@ func (iv *imageVerifier) verifyAttestorSet(attestorSet kyvernov1.AttestorSet, im
 	return nil, err
 }
 
+func (iv *imageVerifier) verifyNotary(attestor kyvernov1.Attestor, imageVerify kyvernov1.ImageVerification, image string, digest string) (*cosign.Response, error) {
+	if len(imageVerify.Attestations) > 0 {
+		return nil, fmt.Errorf("attestations are not supported with notary attestors")
+	}
+
+	opts := cosign.Options{
+		ImageRef:          image,
+		Roots:             attestor.Notary.Certificates,
+		TrustedIdentities: attestor.Notary.TrustedIdentities,
+		Annotations:       imageVerify.Annotations,
+	}
+	if attestor.Annotations != nil {
+		opts.Annotations = attestor.Annotations
+	}
+
+	// notary and cosign attestors share the options type, the cache key tells them apart
+	cacheOpts := struct{ Notary cosign.Options }{opts}
+	return iv.verifyCached(digest, "", cacheOpts, func() (*cosign.Response, error) {
+		return notary.NewVerifier().Verify(iv.policyContext.getContext(), opts)
+	})
+}
+
+// verifyCached returns the verification result stored in the image verification cache for the image digest
+// and attestor options, otherwise it runs the verification and stores the result if it succeeds.
+// Images referenced by tag are always verified, the tag may point to another image since the last verification.
+func (iv *imageVerifier) verifyCached(digest string, secret string, opts interface{}, verify func() (*cosign.Response, error)) (*cosign.Response, error) {
+	if digest == "" || !imageverifycache.DefaultCache.Enabled() {
+		return verify()
+	}
+
+	key, err := iv.cacheKey(digest, secret, opts)
+	if err != nil {
+		iv.logger.V(3).Info("failed to compute image verification cache key", "error", err.Error())
+		return verify()
+	}
+
+	if resp, ok := imageverifycache.DefaultCache.Get(key); ok {
+		iv.logger.V(3).Info("found image verification result in cache", "digest", digest)
+		return resp.(*cosign.Response), nil
+	}
+
+	resp, err := verify()
+	if err != nil {
+		return nil, err
+	}
+
+	imageverifycache.DefaultCache.Set(key, resp)
+	return resp, nil
+}
+
+func (iv *imageVerifier) cacheKey(digest string, secret string, opts interface{}) (imageverifycache.Key, error) {
+	policyKey, err := cache.MetaNamespaceKeyFunc(iv.policyContext.Policy)
+	if err != nil {
+		return imageverifycache.Key{}, err
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return imageverifycache.Key{}, err
+	}
+
+	hash := sha256.Sum256(data)
+	return imageverifycache.Key{
+		Policy:   policyKey,
+		Rule:     iv.rule.Name,
+		Digest:   digest,
+		Attestor: hex.EncodeToString(hash[:]),
+		Secret:   secret,
+	}, nil
+}
+
 func expandStaticKeys(attestorSet kyvernov1.AttestorSet) kyvernov1.AttestorSet {
 	var entries []kyvernov1.Attestor
 	for _, e := range attestorSet.Entries {
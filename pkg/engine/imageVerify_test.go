@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/logging"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -13,6 +14,7 @@ import (
 	client "github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"gotest.tools/assert"
@@ -749,6 +751,53 @@ func Test_MarkImageVerified(t *testing.T) {
 	assert.Equal(t, verified, true)
 }
 
+func Test_ImageVerifyCache(t *testing.T) {
+	defer func(c imageverifycache.Cache) { imageverifycache.DefaultCache = c }(imageverifycache.DefaultCache)
+	imageverifycache.DefaultCache = imageverifycache.NewCache(10, time.Minute)
+
+	image := "ghcr.io/jimbugwadia/pause2@sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105"
+	resource := strings.Replace(testResource, `"ghcr.io/jimbugwadia/pause2"`, `"`+image+`"`, 1)
+	cosign.ClearMock()
+	err := cosign.SetMock(image, attestationPayloads)
+	assert.NilError(t, err)
+
+	engineResponse, _ := VerifyAndPatchImages(buildContext(t, testPolicyGood, resource, ""))
+	assert.Equal(t, len(engineResponse.PolicyResponse.Rules), 1)
+	assert.Equal(t, engineResponse.PolicyResponse.Rules[0].Status, response.RuleStatusPass)
+
+	// signatures are not fetched again for the same digest
+	cosign.ClearMock()
+	engineResponse, verifiedImages := VerifyAndPatchImages(buildContext(t, testPolicyGood, resource, ""))
+	assert.Equal(t, len(engineResponse.PolicyResponse.Rules), 1)
+	assert.Equal(t, engineResponse.PolicyResponse.Rules[0].Status, response.RuleStatusPass)
+	assert.Equal(t, verifiedImages.isVerified(image), true)
+
+	// policy changes invalidate cached results
+	imageverifycache.DefaultCache.InvalidatePolicy("attest")
+	engineResponse, _ = VerifyAndPatchImages(buildContext(t, testPolicyGood, resource, ""))
+	assert.Equal(t, len(engineResponse.PolicyResponse.Rules), 1)
+	assert.Assert(t, engineResponse.PolicyResponse.Rules[0].Status != response.RuleStatusPass)
+}
+
+func Test_ImageVerifyCache_Tag(t *testing.T) {
+	defer func(c imageverifycache.Cache) { imageverifycache.DefaultCache = c }(imageverifycache.DefaultCache)
+	imageverifycache.DefaultCache = imageverifycache.NewCache(10, time.Minute)
+
+	cosign.ClearMock()
+	err := cosign.SetMock("ghcr.io/jimbugwadia/pause2:latest", attestationPayloads)
+	assert.NilError(t, err)
+
+	engineResponse, _ := VerifyAndPatchImages(buildContext(t, testPolicyGood, testResource, ""))
+	assert.Equal(t, len(engineResponse.PolicyResponse.Rules), 1)
+	assert.Equal(t, engineResponse.PolicyResponse.Rules[0].Status, response.RuleStatusPass)
+
+	// images referenced by tag are verified again, the tag may point to another image
+	cosign.ClearMock()
+	engineResponse, _ = VerifyAndPatchImages(buildContext(t, testPolicyGood, testResource, ""))
+	assert.Equal(t, len(engineResponse.PolicyResponse.Rules), 1)
+	assert.Assert(t, engineResponse.PolicyResponse.Rules[0].Status != response.RuleStatusPass)
+}
+
 func applyPatches(t *testing.T, patches [][]byte) unstructured.Unstructured {
 	patchedResource, err := utils.ApplyPatches([]byte(testResource), patches)
 	assert.NilError(t, err)
@@ -0,0 +1,95 @@
+package imageverifycache
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// DefaultMaxSize is the default maximum number of verification results stored in the cache
+	DefaultMaxSize = 1000
+	// DefaultTTL is the default duration verification results are kept in the cache
+	DefaultTTL = time.Minute
+)
+
+// DefaultCache is the cache used by image verification rules, it is disabled until configured
+var DefaultCache = NewCache(DefaultMaxSize, 0)
+
+// Key identifies the result of an attestor verifying an image digest for a policy rule
+type Key struct {
+	// Policy is the key of the policy, as computed by the policy cache
+	Policy string
+	// Rule is the name of the rule
+	Rule string
+	// Digest is the digest of the verified image
+	Digest string
+	// Attestor is a hash of the attestor configuration
+	Attestor string
+	// Secret is the namespace/name of the secret holding the public key of the attestor, if any
+	Secret string
+}
+
+// Cache stores successful image verification results so that admissions of the same
+// image digest don't need to verify signatures again
+type Cache interface {
+	// Enabled returns true if verification results are stored
+	Enabled() bool
+	// Get returns the verification result stored for the key, if present and not expired
+	Get(Key) (interface{}, bool)
+	// Set stores the verification result for the key
+	Set(Key, interface{})
+	// InvalidatePolicy removes the verification results stored for the policy
+	InvalidatePolicy(string)
+	// InvalidateSecret removes the verification results using the keys of the secret with the given namespace and name
+	InvalidateSecret(string, string)
+}
+
+type imageVerifyCache struct {
+	store *cache.LRUExpireCache
+	ttl   time.Duration
+}
+
+// NewCache creates a size bounded cache storing verification results for the given ttl,
+// the least recently used entries are evicted when the cache is full. A ttl of zero disables the cache.
+func NewCache(maxSize int, ttl time.Duration) Cache {
+	return &imageVerifyCache{
+		store: cache.NewLRUExpireCache(maxSize),
+		ttl:   ttl,
+	}
+}
+
+func (c *imageVerifyCache) Enabled() bool {
+	return c.ttl > 0
+}
+
+func (c *imageVerifyCache) Get(key Key) (interface{}, bool) {
+	if !c.Enabled() {
+		return nil, false
+	}
+	return c.store.Get(key)
+}
+
+func (c *imageVerifyCache) Set(key Key, result interface{}) {
+	if !c.Enabled() {
+		return
+	}
+	c.store.Add(key, result, c.ttl)
+}
+
+func (c *imageVerifyCache) InvalidatePolicy(policy string) {
+	for _, k := range c.store.Keys() {
+		if key, ok := k.(Key); ok && key.Policy == policy {
+			c.store.Remove(key)
+		}
+	}
+}
+
+func (c *imageVerifyCache) InvalidateSecret(namespace, name string) {
+	secret := namespace + "/" + name
+	for _, k := range c.store.Keys() {
+		if key, ok := k.(Key); ok && key.Secret == secret {
+			c.store.Remove(key)
+		}
+	}
+}
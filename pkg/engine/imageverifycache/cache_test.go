@@ -0,0 +1,64 @@
+package imageverifycache
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_Cache(t *testing.T) {
+	cache := NewCache(2, time.Minute)
+	key := Key{Policy: "check-images", Rule: "verify", Digest: "sha256:abc", Attestor: "keys"}
+	cache.Set(key, "verified")
+	data, ok := cache.Get(key)
+	assert.Assert(t, ok)
+	assert.Equal(t, data, "verified")
+	// a different attestor configuration doesn't share results
+	_, ok = cache.Get(Key{Policy: "check-images", Rule: "verify", Digest: "sha256:abc", Attestor: "keyless"})
+	assert.Assert(t, !ok)
+}
+
+func Test_Cache_Disabled(t *testing.T) {
+	cache := NewCache(2, 0)
+	assert.Assert(t, !cache.Enabled())
+	key := Key{Policy: "check-images", Rule: "verify", Digest: "sha256:abc", Attestor: "keys"}
+	cache.Set(key, "verified")
+	_, ok := cache.Get(key)
+	assert.Assert(t, !ok)
+}
+
+func Test_Cache_Expiration(t *testing.T) {
+	cache := NewCache(2, time.Millisecond)
+	key := Key{Policy: "check-images", Rule: "verify", Digest: "sha256:abc", Attestor: "keys"}
+	cache.Set(key, "verified")
+	time.Sleep(5 * time.Millisecond)
+	_, ok := cache.Get(key)
+	assert.Assert(t, !ok)
+}
+
+func Test_Cache_InvalidatePolicy(t *testing.T) {
+	cache := NewCache(10, time.Minute)
+	first := Key{Policy: "default/check-images", Rule: "verify", Digest: "sha256:abc", Attestor: "keys"}
+	second := Key{Policy: "check-images", Rule: "verify", Digest: "sha256:abc", Attestor: "keys"}
+	cache.Set(first, "verified")
+	cache.Set(second, "verified")
+	cache.InvalidatePolicy("default/check-images")
+	_, ok := cache.Get(first)
+	assert.Assert(t, !ok)
+	_, ok = cache.Get(second)
+	assert.Assert(t, ok)
+}
+
+func Test_Cache_InvalidateSecret(t *testing.T) {
+	cache := NewCache(10, time.Minute)
+	first := Key{Policy: "check-images", Rule: "verify", Digest: "sha256:abc", Attestor: "keys", Secret: "default/cosign-key"}
+	second := Key{Policy: "check-images", Rule: "verify", Digest: "sha256:abc", Attestor: "keyless"}
+	cache.Set(first, "verified")
+	cache.Set(second, "verified")
+	cache.InvalidateSecret("default", "cosign-key")
+	_, ok := cache.Get(first)
+	assert.Assert(t, !ok)
+	_, ok = cache.Get(second)
+	assert.Assert(t, ok)
+}
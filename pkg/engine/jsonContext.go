@@ -1,17 +1,37 @@
 package engine
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/engine/contextcache"
 	jmespath "github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 )
 
+// defaultServiceCallTimeout is the timeout applied to service API calls that don't declare one
+const defaultServiceCallTimeout = 10 * time.Second
+
+// maxServiceResponseSize is the maximum size of the response body of service API calls
+const maxServiceResponseSize = 10 << 20
+
+// serviceClients stores the HTTP clients of service API calls by CA bundle, so that the
+// connections of their transport are reused across calls
+var serviceClients sync.Map
+
 // LoadContext - Fetches and adds external data to the Context.
 func LoadContext(logger logr.Logger, contextEntries []kyvernov1.ContextEntry, ctx *PolicyContext, ruleName string) error {
 	if len(contextEntries) == 0 {
@@ -170,7 +190,9 @@ func fetchImageData(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *Polic
 	if err != nil {
 		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.ImageRegistry.JMESPath, err)
 	}
-	imageData, err := fetchImageDataMap(refString)
+	imageData, err := cachedContextData(ctx, entry, metrics.ContextEntryImageRegistry, refString, func() (interface{}, error) {
+		return fetchImageDataMap(ctx, refString)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -184,8 +206,8 @@ func fetchImageData(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *Polic
 }
 
 // FetchImageDataMap fetches image information from the remote registry.
-func fetchImageDataMap(ref string) (interface{}, error) {
-	desc, err := registryclient.DefaultClient.FetchImageDescriptor(ref)
+func fetchImageDataMap(ctx *PolicyContext, ref string) (interface{}, error) {
+	desc, err := registryclient.DefaultClient.FetchImageDescriptor(ctx.getContext(), ref)
 	if err != nil {
 		return nil, err
 	}
@@ -300,6 +322,10 @@ func fetchAPIData(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyCont
 		return nil, fmt.Errorf("missing APICall in context entry %s %v", entry.Name, entry.APICall)
 	}
 
+	if entry.APICall.Service != nil {
+		return callService(log, entry, ctx)
+	}
+
 	path, err := variables.SubstituteAll(log, ctx.JSONContext, entry.APICall.URLPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.APICall.URLPath, err)
@@ -307,16 +333,160 @@ func fetchAPIData(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyCont
 
 	pathStr := path.(string)
 
-	jsonData, err := getResource(ctx, pathStr)
+	jsonData, err := cachedContextData(ctx, entry, metrics.ContextEntryAPICall, pathStr, func() (interface{}, error) {
+		return getResource(ctx, pathStr)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resource with raw url\n: %s: %v", pathStr, err)
 	}
 
-	return jsonData, nil
+	return jsonData.([]byte), nil
+}
+
+// cachedContextData returns the data cached for a context entry key if the entry declares a
+// cache TTL, otherwise the data is fetched and cached for subsequent requests. The data is also
+// shared by the webhook calls of the admission request being processed.
+func cachedContextData(ctx *PolicyContext, entry kyvernov1.ContextEntry, entryType metrics.ContextEntryType, key string, fetch func() (interface{}, error)) (interface{}, error) {
+	return requestData(ctx, string(entryType)+" "+key, func() (interface{}, error) {
+		if entry.CacheTTL == nil || entry.CacheTTL.Duration <= 0 {
+			return fetch()
+		}
+		if data, ok := contextcache.DefaultCache.Get(entryType, key); ok {
+			return data, nil
+		}
+		data, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		contextcache.DefaultCache.Set(entryType, key, data, entry.CacheTTL.Duration)
+		return data, nil
+	})
+}
+
+// requestData returns the data computed for the key while processing the admission request, or computes it
+func requestData(ctx *PolicyContext, key string, compute func() (interface{}, error)) (interface{}, error) {
+	if ctx.RequestCache == nil {
+		return compute()
+	}
+	return ctx.RequestCache.GetOrCompute(key, compute)
 }
 
 func getResource(ctx *PolicyContext, p string) ([]byte, error) {
-	return ctx.Client.RawAbsPath(p)
+	return ctx.Client.RawAbsPath(ctx.getContext(), p)
+}
+
+func callService(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) ([]byte, error) {
+	service := entry.APICall.Service
+	url, err := variables.SubstituteAll(log, ctx.JSONContext, service.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, service.URL, err)
+	}
+	urlStr, ok := url.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid service URL %v in context entry %s, service URL must be a string", url, entry.Name)
+	}
+
+	var requestData []byte
+	if service.GetMethod() == kyvernov1.MethodPost {
+		requestData, err = buildRequestData(log, entry, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key := fmt.Sprintf("%s %s %s", service.GetMethod(), urlStr, string(requestData))
+	jsonData, err := cachedContextData(ctx, entry, metrics.ContextEntryAPICall, key, func() (interface{}, error) {
+		return doServiceCall(ctx.getContext(), log, entry, urlStr, requestData)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonData.([]byte), nil
+}
+
+func doServiceCall(ctx context.Context, log logr.Logger, entry kyvernov1.ContextEntry, urlStr string, requestData []byte) ([]byte, error) {
+	service := entry.APICall.Service
+	var body io.Reader
+	if requestData != nil {
+		body = bytes.NewBuffer(requestData)
+	}
+
+	timeout := defaultServiceCallTimeout
+	if service.Timeout != nil {
+		timeout = service.Timeout.Duration
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, string(service.GetMethod()), urlStr, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build HTTP request for context entry %s: %v", entry.Name, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client, err := buildHTTPClient(service.CABundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build HTTP client for context entry %s: %v", entry.Name, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call service %s for context entry %s: %v", urlStr, entry.Name, err)
+	}
+	defer resp.Body.Close()
+
+	jsonData, err := io.ReadAll(io.LimitReader(resp.Body, maxServiceResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from service %s for context entry %s: %v", urlStr, entry.Name, err)
+	}
+	if len(jsonData) > maxServiceResponseSize {
+		return nil, fmt.Errorf("response from service %s for context entry %s exceeds the maximum size of %d bytes", urlStr, entry.Name, maxServiceResponseSize)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("service %s returned HTTP %d for context entry %s: %s", urlStr, resp.StatusCode, entry.Name, string(jsonData))
+	}
+
+	log.V(4).Info("executed service APICall", "url", urlStr, "method", service.GetMethod(), "len", len(jsonData))
+	return jsonData, nil
+}
+
+func buildRequestData(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) ([]byte, error) {
+	data := make(map[string]interface{}, len(entry.APICall.Service.Data))
+	for _, d := range entry.APICall.Service.Data {
+		value, err := variables.DocumentToUntyped(d.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for key %s in context entry %s: %v", d.Key, entry.Name, err)
+		}
+		value, err = variables.SubstituteAll(log, ctx.JSONContext, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to substitute variables in context entry %s data key %s: %v", entry.Name, d.Key, err)
+		}
+		data[d.Key] = value
+	}
+	return json.Marshal(data)
+}
+
+// buildHTTPClient returns the HTTP client trusting the CA bundle, clients are built once per CA bundle
+func buildHTTPClient(caBundle string) (*http.Client, error) {
+	if caBundle == "" {
+		return http.DefaultClient, nil
+	}
+	if client, ok := serviceClients.Load(caBundle); ok {
+		return client.(*http.Client), nil
+	}
+	caCertPool := x509.NewCertPool()
+	if ok := caCertPool.AppendCertsFromPEM([]byte(caBundle)); !ok {
+		return nil, fmt.Errorf("failed to parse PEM CA bundle")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    caCertPool,
+		MinVersion: tls.VersionTLS12,
+	}
+	client, _ := serviceClients.LoadOrStore(caBundle, &http.Client{Transport: transport})
+	return client.(*http.Client), nil
 }
 
 func loadConfigMap(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) error {
@@ -350,20 +520,26 @@ func fetchConfigMap(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *Polic
 		namespace = "default"
 	}
 
-	obj, err := ctx.Client.GetResource("v1", "ConfigMap", namespace.(string), name.(string))
-	if err != nil {
-		return nil, fmt.Errorf("failed to get configmap %s/%s : %v", namespace, name, err)
-	}
+	data, err := requestData(ctx, fmt.Sprintf("configMap %s/%s", namespace, name), func() (interface{}, error) {
+		obj, err := ctx.Client.GetResource("v1", "ConfigMap", namespace.(string), name.(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get configmap %s/%s : %v", namespace, name, err)
+		}
 
-	unstructuredObj := obj.DeepCopy().Object
+		unstructuredObj := obj.DeepCopy().Object
 
-	// extract configmap data
-	contextData["data"] = unstructuredObj["data"]
-	contextData["metadata"] = unstructuredObj["metadata"]
-	data, err := json.Marshal(contextData)
+		// extract configmap data
+		contextData["data"] = unstructuredObj["data"]
+		contextData["metadata"] = unstructuredObj["metadata"]
+		data, err := json.Marshal(contextData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal configmap %s/%s: %v", namespace, name, err)
+		}
+		return data, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal configmap %s/%s: %v", namespace, name, err)
+		return nil, err
 	}
 
-	return data, nil
+	return data.([]byte), nil
 }
@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/admissioncache"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_LoadContext_ServiceCall(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPost)
+		assert.Equal(t, r.Header.Get("Content-Type"), "application/json")
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		var data map[string]interface{}
+		assert.NilError(t, json.Unmarshal(body, &data))
+		approved := data["team"] == "platform"
+		assert.NilError(t, json.NewEncoder(w).Encode(map[string]interface{}{"approved": approved}))
+	}))
+	defer server.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	jsonContext := context.NewContext()
+	assert.NilError(t, jsonContext.AddVariable("team", "platform"))
+	policyContext := &PolicyContext{
+		Policy:      &kyvernov1.ClusterPolicy{},
+		JSONContext: jsonContext,
+	}
+	entries := []kyvernov1.ContextEntry{{
+		Name: "inventory",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL:      server.URL + "/approved",
+				Method:   kyvernov1.MethodPost,
+				CABundle: string(caBundle),
+				Data: []kyvernov1.RequestData{{
+					Key:   "team",
+					Value: &apiextv1.JSON{Raw: []byte(`"{{ team }}"`)},
+				}},
+			},
+			JMESPath: "approved",
+		},
+	}}
+	assert.NilError(t, LoadContext(logging.GlobalLogger(), entries, policyContext, "rule"))
+	approved, err := jsonContext.Query("inventory")
+	assert.NilError(t, err)
+	assert.Equal(t, approved, true)
+}
+
+func Test_LoadContext_ServiceCallError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodGet)
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	policyContext := &PolicyContext{
+		Policy:      &kyvernov1.ClusterPolicy{},
+		JSONContext: context.NewContext(),
+	}
+	entries := []kyvernov1.ContextEntry{{
+		Name: "inventory",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL: server.URL,
+			},
+		},
+	}}
+	err := LoadContext(logging.GlobalLogger(), entries, policyContext, "rule")
+	assert.ErrorContains(t, err, "returned HTTP 404")
+}
+
+func Test_LoadContext_ServiceCallResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write(bytes.Repeat([]byte(" "), maxServiceResponseSize+1))
+		assert.NilError(t, err)
+	}))
+	defer server.Close()
+
+	policyContext := &PolicyContext{
+		Policy:      &kyvernov1.ClusterPolicy{},
+		JSONContext: context.NewContext(),
+	}
+	entries := []kyvernov1.ContextEntry{{
+		Name: "inventory",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL: server.URL,
+			},
+		},
+	}}
+	err := LoadContext(logging.GlobalLogger(), entries, policyContext, "rule")
+	assert.ErrorContains(t, err, "exceeds the maximum size")
+}
+
+func Test_buildHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	client, err := buildHTTPClient(caBundle)
+	assert.NilError(t, err)
+	// clients and their transport are shared by the calls using the same CA bundle
+	shared, err := buildHTTPClient(caBundle)
+	assert.NilError(t, err)
+	assert.Assert(t, client == shared)
+	_, err = buildHTTPClient("invalid")
+	assert.ErrorContains(t, err, "failed to parse PEM CA bundle")
+}
+
+func Test_LoadContext_RequestCache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.NilError(t, json.NewEncoder(w).Encode(map[string]interface{}{"approved": true}))
+	}))
+	defer server.Close()
+
+	entries := []kyvernov1.ContextEntry{{
+		Name: "inventory",
+		APICall: &kyvernov1.APICall{
+			Service:  &kyvernov1.ServiceCall{URL: server.URL},
+			JMESPath: "approved",
+		},
+	}}
+	// the policies evaluated by a webhook call share the service call, webhook calls don't
+	for i := 0; i < 2; i++ {
+		requestCache := admissioncache.NewRequestCache()
+		for _, policy := range []string{"first", "second"} {
+			jsonContext := context.NewContext()
+			policyContext := &PolicyContext{
+				Policy:       &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: policy}},
+				JSONContext:  jsonContext,
+				RequestCache: requestCache,
+			}
+			assert.NilError(t, LoadContext(logging.GlobalLogger(), entries, policyContext, "rule"))
+			approved, err := jsonContext.Query("inventory")
+			assert.NilError(t, err)
+			assert.Equal(t, approved, true)
+		}
+		assert.Equal(t, calls, i+1)
+	}
+}
@@ -34,6 +34,7 @@ func Mutate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 
 	startMutateResultResponse(resp, policy, matchedResource)
 	defer endMutateResultResponse(logger, resp, startTime)
+	defer policyContext.startExecutionBudget()()
 
 	policyContext.JSONContext.Checkpoint()
 	defer policyContext.JSONContext.Restore()
@@ -52,13 +53,25 @@ func Mutate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 			excludeResource = policyContext.ExcludeGroupRole
 		}
 
-		if err = MatchesResourceDescription(matchedResource, rule, policyContext.AdmissionInfo, excludeResource, policyContext.NamespaceLabels, policyContext.Policy.GetNamespace()); err != nil {
+		if err = MatchesResourceDescription(matchedResource, rule, policyContext.AdmissionInfo, excludeResource, policyContext.NamespaceLabels, policyContext.Policy.GetNamespace(), policyContext.requestOperation()); err != nil {
 			logger.V(4).Info("rule not matched", "reason", err.Error())
 			skippedRules = append(skippedRules, rule.Name)
 			continue
 		}
 
+		if exception := findException(logger, &rule, policyContext); exception != nil {
+			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleException(&rule, response.Mutation, exception))
+			continue
+		}
+
 		logger.V(3).Info("processing mutate rule", "applyRules", applyRules)
+		if policyContext.executionBudgetExceeded() {
+			logger.V(2).Info("policy execution budget exceeded, skipping rule evaluation")
+			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleTimeout(&rule, response.Mutation, policyContext))
+			incrementErrorCount(resp)
+			continue
+		}
+
 		resource, err := policyContext.JSONContext.Query("request.object")
 		policyContext.JSONContext.Reset()
 		if err == nil && resource != nil {
@@ -75,6 +88,10 @@ func Mutate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 			} else {
 				logger.Error(err, "failed to load context")
 			}
+			if policyContext.executionBudgetExceeded() {
+				resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleTimeout(&rule, response.Mutation, policyContext))
+				incrementErrorCount(resp)
+			}
 			continue
 		}
 
@@ -113,11 +130,20 @@ func Mutate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 				ruleResp, patchedResource = mutateResource(ruleCopy, policyContext, patchedResource, logger)
 			}
 
-			matchedResource = patchedResource
+			// patches of rules in audit mode are reported but must not be seen by the next rules
+			audit := policyContext.AdmissionOperation && policy.GetSpec().GetMutationAction(ruleCopy).Audit()
+			if !audit {
+				matchedResource = patchedResource
+			}
 
 			if ruleResp != nil {
+				ruleResp.Audit = audit
+				if audit && len(ruleResp.Patches) > 0 {
+					ruleResp.Message = fmt.Sprintf("%s (audit, %d patches not applied)", ruleResp.Message, len(ruleResp.Patches))
+				}
+				checkRuleTimeout(policyContext, ruleResp)
 				resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
-				if ruleResp.Status == response.RuleStatusError {
+				if ruleResp.Status == response.RuleStatusError || ruleResp.Status == response.RuleStatusTimeout {
 					incrementErrorCount(resp)
 				} else {
 					incrementAppliedCount(resp)
@@ -1953,3 +1953,76 @@ func Test_SpecialCharacters(t *testing.T) {
 		})
 	}
 }
+
+func Test_MutationActionAudit(t *testing.T) {
+	policyRaw := []byte(`{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "add-labels"
+  },
+  "spec": {
+    "mutationAction": "Audit",
+    "rules": [
+      {
+        "name": "add-team",
+        "match": {"resources": {"kinds": ["Pod"]}},
+        "mutate": {"patchStrategicMerge": {"metadata": {"labels": {"team": "kyverno"}}}}
+      },
+      {
+        "name": "add-app",
+        "match": {"resources": {"kinds": ["Pod"]}},
+        "mutate": {
+          "action": "Enforce",
+          "patchStrategicMerge": {"metadata": {"labels": {"app": "nginx"}}}
+        }
+      }
+    ]
+  }
+}`)
+	resourceRaw := []byte(`{
+  "apiVersion": "v1",
+  "kind": "Pod",
+  "metadata": {
+    "name": "test",
+    "labels": {"env": "dev"}
+  },
+  "spec": {
+    "containers": [{"name": "test", "image": "nginx"}]
+  }
+}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+
+	resource, err := utils.ConvertToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+
+	for _, admission := range []bool{true, false} {
+		ctx := context.NewContext()
+		assert.NilError(t, ctx.AddResource(resource.Object))
+
+		er := Mutate(&PolicyContext{
+			Policy:             &policy,
+			JSONContext:        ctx,
+			NewResource:        *resource,
+			AdmissionOperation: admission,
+		})
+		assert.Equal(t, len(er.PolicyResponse.Rules), 2)
+		assert.Equal(t, er.PolicyResponse.Rules[0].Audit, admission)
+		assert.Equal(t, er.PolicyResponse.Rules[1].Audit, false)
+
+		labels := er.PatchedResource.GetLabels()
+		assert.Equal(t, labels["app"], "nginx")
+		if admission {
+			assert.Equal(t, labels["team"], "")
+			assert.Equal(t, len(er.GetPatches()), 1)
+			assert.Equal(t, len(er.GetAuditPatches()), 1)
+			assert.Equal(t, string(er.GetAuditPatches()[0]), `{"op":"add","path":"/metadata/labels/team","value":"kyverno"}`)
+			assert.Assert(t, strings.Contains(er.PolicyResponse.Rules[0].Message, "audit"))
+		} else {
+			assert.Equal(t, labels["team"], "kyverno")
+			assert.Equal(t, len(er.GetPatches()), 2)
+			assert.Equal(t, len(er.GetAuditPatches()), 0)
+		}
+	}
+}
@@ -1,10 +1,17 @@
 package engine
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
-	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/admissioncache"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -34,13 +41,36 @@ type PolicyContext struct {
 	ExcludeResourceFunc func(kind, namespace, name string) bool
 
 	// JSONContext is the variable context
-	JSONContext context.Interface
+	JSONContext enginecontext.Interface
 
 	// NamespaceLabels stores the label of namespace to be processed by namespace selector
 	NamespaceLabels map[string]string
 
 	// AdmissionOperation represents if the caller is from the webhook server
 	AdmissionOperation bool
+
+	// Context carries the deadline for processing the policy, API calls and registry lookups
+	// made while loading rule contexts are cancelled when it expires
+	Context context.Context
+
+	// Budget is the execution budget of the webhook call processing the admission request, each policy
+	// is given a share of the remaining time. Policies are not bound to a deadline when it is not set
+	Budget *ExecutionBudget
+
+	// policyBudget is the share of the execution budget given to the policy being processed
+	policyBudget time.Duration
+
+	// ExceptionLister lists the policy exceptions consulted before evaluating the rules,
+	// no exception is applied when it is not set
+	ExceptionLister kyvernov1alpha1listers.PolicyExceptionLister
+
+	// RequestCache stores the context entries resolved for the webhook call, they are shared by the
+	// policies evaluated for the call. Data is not shared when it is not set
+	RequestCache admissioncache.RequestCache
+
+	// AdmissionCache stores the namespace labels and RBAC bindings resolved for the admission request,
+	// it is shared by the webhook calls of the request. Data is not shared when it is not set
+	AdmissionCache admissioncache.RequestCache
 }
 
 func (pc *PolicyContext) Copy() *PolicyContext {
@@ -54,5 +84,98 @@ func (pc *PolicyContext) Copy() *PolicyContext {
 		ExcludeResourceFunc: pc.ExcludeResourceFunc,
 		JSONContext:         pc.JSONContext,
 		NamespaceLabels:     pc.NamespaceLabels,
+		Context:             pc.Context,
+		Budget:              pc.Budget,
+		ExceptionLister:     pc.ExceptionLister,
+		RequestCache:        pc.RequestCache,
+		AdmissionCache:      pc.AdmissionCache,
+	}
+}
+
+// getContext returns the context carrying the policy deadline, or a background context if none was set
+func (pc *PolicyContext) getContext() context.Context {
+	if pc.Context == nil {
+		return context.Background()
+	}
+	return pc.Context
+}
+
+// startExecutionBudget derives the deadline of the policy from the execution budget of the webhook call for
+// admission requests, the returned function releases the deadline and restores the previous context
+func (pc *PolicyContext) startExecutionBudget() func() {
+	parent, parentBudget := pc.Context, pc.policyBudget
+	if !pc.AdmissionOperation || pc.Budget == nil {
+		return func() {}
+	}
+	ctx, cancel, budget := pc.Budget.next()
+	pc.Context, pc.policyBudget = ctx, budget
+	return func() {
+		cancel()
+		pc.Context, pc.policyBudget = parent, parentBudget
+	}
+}
+
+// executionBudgetExceeded checks if the deadline for processing the policy has expired
+func (pc *PolicyContext) executionBudgetExceeded() bool {
+	return errors.Is(pc.getContext().Err(), context.DeadlineExceeded)
+}
+
+// requestOperation returns the operation of the admission request added to the JSON context,
+// it is empty when the policy is processed in background
+func (pc *PolicyContext) requestOperation() kyvernov1.AdmissionOperation {
+	if pc.JSONContext == nil {
+		return ""
+	}
+	operation, err := pc.JSONContext.Query("request.operation")
+	if err != nil {
+		return ""
+	}
+	if operation, ok := operation.(string); ok {
+		return kyvernov1.AdmissionOperation(operation)
+	}
+	return ""
+}
+
+// ExecutionBudget splits the timeout of a webhook call across the policies evaluated during the call,
+// the time left is shared evenly by the policies which are not evaluated yet so that a policy finishing
+// early leaves more time to the next ones
+type ExecutionBudget struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lock    sync.Mutex
+	pending int
+}
+
+// NewExecutionBudget returns the execution budget of a webhook call evaluating the given number of policies
+// before the timeout expires, Release must be called once the policies are evaluated
+func NewExecutionBudget(ctx context.Context, timeout time.Duration, policies int) *ExecutionBudget {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return &ExecutionBudget{
+		ctx:     ctx,
+		cancel:  cancel,
+		pending: policies,
+	}
+}
+
+// Release releases the deadline of the webhook call
+func (b *ExecutionBudget) Release() {
+	b.cancel()
+}
+
+// next returns the deadline of the next policy and its share of the time left
+func (b *ExecutionBudget) next() (context.Context, context.CancelFunc, time.Duration) {
+	b.lock.Lock()
+	pending := b.pending
+	if pending < 1 {
+		pending = 1
+	}
+	b.pending = pending - 1
+	b.lock.Unlock()
+	var budget time.Duration
+	if deadline, ok := b.ctx.Deadline(); ok {
+		budget = time.Until(deadline) / time.Duration(pending)
 	}
+	ctx, cancel := context.WithTimeout(b.ctx, budget)
+	return ctx, cancel, budget
 }
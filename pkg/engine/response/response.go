@@ -114,6 +114,9 @@ type RuleResponse struct {
 
 	// PatchedTarget is the patched resource for mutate.targets
 	PatchedTarget *unstructured.Unstructured
+
+	// Audit is true for mutation rules in audit mode, their patches are reported but not applied
+	Audit bool `json:"audit,omitempty"`
 }
 
 // ToString ...
@@ -129,10 +132,10 @@ type RuleStats struct {
 	RuleExecutionTimestamp int64 `json:"ruleExecutionTimestamp"`
 }
 
-// IsSuccessful checks if any rule has failed or produced an error during execution
+// IsSuccessful checks if any rule has failed, produced an error or timed out during execution
 func (er EngineResponse) IsSuccessful() bool {
 	for _, r := range er.PolicyResponse.Rules {
-		if r.Status == RuleStatusFail || r.Status == RuleStatusError {
+		if r.Status == RuleStatusFail || r.Status == RuleStatusError || r.Status == RuleStatusTimeout {
 			return false
 		}
 	}
@@ -172,6 +175,17 @@ func (er EngineResponse) IsError() bool {
 	return false
 }
 
+// IsTimeout checks if any rule could not be evaluated within the policy execution budget
+func (er EngineResponse) IsTimeout() bool {
+	for _, r := range er.PolicyResponse.Rules {
+		if r.Status == RuleStatusTimeout {
+			return true
+		}
+	}
+
+	return false
+}
+
 // IsEmpty checks if any rule results are present
 func (er EngineResponse) IsEmpty() bool {
 	return len(er.PolicyResponse.Rules) == 0
@@ -186,7 +200,19 @@ func (er EngineResponse) IsNil() bool {
 func (er EngineResponse) GetPatches() [][]byte {
 	var patches [][]byte
 	for _, r := range er.PolicyResponse.Rules {
-		if r.Patches != nil {
+		if r.Patches != nil && !r.Audit {
+			patches = append(patches, r.Patches...)
+		}
+	}
+
+	return patches
+}
+
+// GetAuditPatches returns the patches of the mutation rules in audit mode
+func (er EngineResponse) GetAuditPatches() [][]byte {
+	var patches [][]byte
+	for _, r := range er.PolicyResponse.Rules {
+		if r.Patches != nil && r.Audit {
 			patches = append(patches, r.Patches...)
 		}
 	}
@@ -196,7 +222,9 @@ func (er EngineResponse) GetPatches() [][]byte {
 
 // GetFailedRules returns failed rules
 func (er EngineResponse) GetFailedRules() []string {
-	return er.getRules(func(status RuleStatus) bool { return status == RuleStatusFail || status == RuleStatusError })
+	return er.getRules(func(status RuleStatus) bool {
+		return status == RuleStatusFail || status == RuleStatusError || status == RuleStatusTimeout
+	})
 }
 
 // GetSuccessRules returns success rules
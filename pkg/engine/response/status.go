@@ -25,6 +25,9 @@ const (
 	// RuleStatusSkip indicates that the policy rule was not selected based on user inputs or applicability, for example
 	// when preconditions are not met, or when conditional or global anchors are not satistied.
 	RuleStatusSkip
+	// RuleStatusTimeout indicates that the policy rule could not be evaluated within the execution budget of the
+	// policy, for example when an API call or a registry lookup stalls.
+	RuleStatusTimeout
 )
 
 func (s *RuleStatus) String() string {
@@ -32,11 +35,12 @@ func (s *RuleStatus) String() string {
 }
 
 var toString = map[RuleStatus]string{
-	RuleStatusPass:  "pass",
-	RuleStatusFail:  "fail",
-	RuleStatusWarn:  "warning",
-	RuleStatusError: "error",
-	RuleStatusSkip:  "skip",
+	RuleStatusPass:    "pass",
+	RuleStatusFail:    "fail",
+	RuleStatusWarn:    "warning",
+	RuleStatusError:   "error",
+	RuleStatusSkip:    "skip",
+	RuleStatusTimeout: "timeout",
 }
 
 var toID = map[string]RuleStatus{
@@ -45,6 +49,7 @@ var toID = map[string]RuleStatus{
 	"warning": RuleStatusWarn,
 	"error":   RuleStatusError,
 	"skip":    RuleStatusSkip,
+	"timeout": RuleStatusTimeout,
 }
 
 // MarshalJSON marshals the enum as a quoted json string
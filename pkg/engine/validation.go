@@ -36,6 +36,7 @@ func Validate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 
 	logger := buildLogger(policyContext)
 	logger.V(4).Info("start validate policy processing", "startTime", startTime)
+	defer policyContext.startExecutionBudget()()
 	defer func() {
 		buildResponse(policyContext, resp, startTime)
 		logger.V(4).Info("finished policy processing", "processingTime", resp.PolicyResponse.ProcessingTime.String(), "validationRulesApplied", resp.PolicyResponse.RulesAppliedCount)
@@ -126,6 +127,17 @@ func validateResource(log logr.Logger, ctx *PolicyContext) *response.EngineRespo
 		ctx.JSONContext.Reset()
 		startTime := time.Now()
 
+		if exception := findException(log, rule, ctx); exception != nil {
+			addRuleResponse(log, resp, ruleException(rule, response.Validation, exception), startTime)
+			continue
+		}
+
+		if ctx.executionBudgetExceeded() {
+			log.V(2).Info("policy execution budget exceeded, skipping rule evaluation")
+			addRuleResponse(log, resp, ruleTimeout(rule, response.Validation, ctx), startTime)
+			continue
+		}
+
 		var ruleResp *response.RuleResponse
 		if hasValidate && !hasYAMLSignatureVerify {
 			ruleResp = processValidationRule(log, ctx, rule)
@@ -136,6 +148,7 @@ func validateResource(log logr.Logger, ctx *PolicyContext) *response.EngineRespo
 		}
 
 		if ruleResp != nil {
+			checkRuleTimeout(ctx, ruleResp)
 			addRuleResponse(log, resp, ruleResp, startTime)
 			if applyRules == kyvernov1.ApplyOne && resp.PolicyResponse.RulesAppliedCount > 0 {
 				break
@@ -178,7 +191,7 @@ func addRuleResponse(log logr.Logger, resp *response.EngineResponse, ruleResp *r
 
 	if ruleResp.Status == response.RuleStatusPass || ruleResp.Status == response.RuleStatusFail {
 		incrementAppliedCount(resp)
-	} else if ruleResp.Status == response.RuleStatusError {
+	} else if ruleResp.Status == response.RuleStatusError || ruleResp.Status == response.RuleStatusTimeout {
 		incrementErrorCount(resp)
 	}
 
@@ -568,13 +581,13 @@ func isEmptyUnstructured(u *unstructured.Unstructured) bool {
 
 // matches checks if either the new or old resource satisfies the filter conditions defined in the rule
 func matches(logger logr.Logger, rule *kyvernov1.Rule, ctx *PolicyContext) bool {
-	err := MatchesResourceDescription(ctx.NewResource, *rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels, "")
+	err := MatchesResourceDescription(ctx.NewResource, *rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels, "", ctx.requestOperation())
 	if err == nil {
 		return true
 	}
 
 	if !reflect.DeepEqual(ctx.OldResource, unstructured.Unstructured{}) {
-		err := MatchesResourceDescription(ctx.OldResource, *rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels, "")
+		err := MatchesResourceDescription(ctx.OldResource, *rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels, "", ctx.requestOperation())
 		if err == nil {
 			return true
 		}
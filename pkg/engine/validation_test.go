@@ -1,19 +1,28 @@
 package engine
 
 import (
+	stdcontext "context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
 	urkyverno "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	kyvernov1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	utils2 "github.com/kyverno/kyverno/pkg/utils"
 	"gotest.tools/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 func TestGetAnchorsFromMap_ThereAreAnchors(t *testing.T) {
@@ -3095,3 +3104,206 @@ func Test_block_bypass(t *testing.T) {
 		executeTest(t, testcase)
 	}
 }
+
+func Test_Validate_ExecutionBudget(t *testing.T) {
+	store.SetMock(false)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	resourceRaw := []byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test"}, "spec": {"containers": [{"name": "nginx", "image": "nginx"}]}}`)
+	policyRaw := []byte(fmt.Sprintf(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-inventory"},
+		"spec": {
+			"validationFailureAction": "Enforce",
+			"rules": [
+				{
+					"name": "check-approved",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"context": [{"name": "inventory", "apiCall": {"service": {"url": "%s"}}}],
+					"validate": {"message": "image not approved", "deny": {"conditions": {"any": [{"key": "{{ inventory.approved }}", "operator": "Equals", "value": false}]}}}
+				},
+				{
+					"name": "check-name",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {"message": "name is required", "pattern": {"metadata": {"name": "?*"}}}
+				}
+			]
+		}
+	}`, server.URL))
+
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+	resourceUnstructured, err := utils.ConvertToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+	ctx := context.NewContext()
+	assert.NilError(t, context.AddResource(ctx, resourceRaw))
+
+	budget := NewExecutionBudget(stdcontext.TODO(), time.Second, 1)
+	defer budget.Release()
+	policyContext := &PolicyContext{
+		Policy:             &policy,
+		JSONContext:        ctx,
+		NewResource:        *resourceUnstructured,
+		AdmissionOperation: true,
+		Budget:             budget,
+	}
+	er := Validate(policyContext)
+
+	assert.Equal(t, len(er.PolicyResponse.Rules), 2)
+	for _, rule := range er.PolicyResponse.Rules {
+		assert.Equal(t, rule.Status, response.RuleStatusTimeout)
+		assert.Assert(t, strings.HasPrefix(rule.Message, "policy execution budget of 1s exceeded"))
+	}
+	assert.Equal(t, er.PolicyResponse.RulesErrorCount, 2)
+	assert.Assert(t, !er.IsSuccessful())
+	assert.Assert(t, policyContext.Context == nil)
+}
+
+func Test_ValidateWithPolicyException(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "require-team"
+		},
+		"spec": {
+			"rules": [
+				{
+					"name": "check-team",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {
+						"message": "label 'team' is required",
+						"pattern": {"metadata": {"labels": {"team": "?*"}}}
+					}
+				}
+			]
+		}
+	}`)
+
+	rawResource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "nginx",
+			"namespace": "infra"
+		},
+		"spec": {
+			"containers": [{"name": "nginx", "image": "nginx"}]
+		}
+	}`)
+
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	resourceUnstructured, err := utils.ConvertToUnstructured(rawResource)
+	assert.NilError(t, err)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NilError(t, indexer.Add(&kyvernov1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "infra-pods", Namespace: "kyverno"},
+		Spec: kyvernov1alpha1.PolicyExceptionSpec{
+			Match: kyverno.MatchResources{
+				Any: kyverno.ResourceFilters{{
+					ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Namespaces: []string{"infra"}},
+				}},
+			},
+			Exceptions: []kyvernov1alpha1.Exception{{PolicyName: "require-team", RuleNames: []string{"check-team"}}},
+		},
+	}))
+
+	policyContext := &PolicyContext{
+		Policy:          &policy,
+		NewResource:     *resourceUnstructured,
+		JSONContext:     context.NewContext(),
+		ExceptionLister: kyvernov1alpha1listers.NewPolicyExceptionLister(indexer),
+	}
+	// exceptions of other namespaces don't apply unless they are created in the exception namespace
+	er := Validate(policyContext)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusFail)
+
+	ExceptionNamespace = "kyverno"
+	defer func() { ExceptionNamespace = "" }()
+	er = Validate(policyContext)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusSkip)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Message, "rule skipped due to policy exception kyverno/infra-pods")
+
+	resourceUnstructured.SetNamespace("default")
+	policyContext.NewResource = *resourceUnstructured
+	er = Validate(policyContext)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusFail)
+}
+
+func Test_ValidateWithNamespacedPolicyException(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-team"},
+		"spec": {
+			"rules": [
+				{
+					"name": "check-team",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {
+						"message": "label 'team' is required",
+						"pattern": {"metadata": {"labels": {"team": "?*"}}}
+					}
+				}
+			]
+		}
+	}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	resourceUnstructured, err := utils.ConvertToUnstructured([]byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "nginx", "namespace": "team-a"}}`))
+	assert.NilError(t, err)
+
+	// an exception matching all pods only exempts the pods of its own namespace
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NilError(t, indexer.Add(&kyvernov1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "all-pods", Namespace: "team-a"},
+		Spec: kyvernov1alpha1.PolicyExceptionSpec{
+			Match: kyverno.MatchResources{
+				Any: kyverno.ResourceFilters{{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}}}},
+			},
+			Exceptions: []kyvernov1alpha1.Exception{{PolicyName: "require-team", RuleNames: []string{"check-team"}}},
+		},
+	}))
+	policyContext := &PolicyContext{
+		Policy:          &policy,
+		NewResource:     *resourceUnstructured,
+		JSONContext:     context.NewContext(),
+		ExceptionLister: kyvernov1alpha1listers.NewPolicyExceptionLister(indexer),
+	}
+	er := Validate(policyContext)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusSkip)
+
+	resourceUnstructured.SetNamespace("team-b")
+	policyContext.NewResource = *resourceUnstructured
+	er = Validate(policyContext)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusFail)
+}
+
+func Test_ExecutionBudget(t *testing.T) {
+	budget := NewExecutionBudget(stdcontext.TODO(), 2*time.Second, 2)
+	defer budget.Release()
+	// the time left is split across the pending policies
+	ctx, cancel, share := budget.next()
+	assert.Assert(t, share > 900*time.Millisecond && share <= time.Second, share)
+	deadline, ok := ctx.Deadline()
+	assert.Assert(t, ok)
+	assert.Assert(t, time.Until(deadline) <= time.Second)
+	cancel()
+	// the time not used by a policy is given to the next ones
+	_, cancel, share = budget.next()
+	assert.Assert(t, share > 1900*time.Millisecond, share)
+	cancel()
+	// policies evaluated beyond the expected count share the time left
+	_, cancel, share = budget.next()
+	assert.Assert(t, share > 1900*time.Millisecond, share)
+	cancel()
+}
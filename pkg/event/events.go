@@ -35,7 +35,7 @@ func buildPolicyEventMessage(resp *response.RuleResponse, resource response.Reso
 		fmt.Fprintf(&b, " (blocked)")
 	}
 
-	if resp.Status == response.RuleStatusError && resp.Message != "" {
+	if (resp.Status == response.RuleStatusError || resp.Status == response.RuleStatusTimeout) && resp.Message != "" {
 		fmt.Fprintf(&b, "; %s", resp.Message)
 	}
 
@@ -71,6 +71,29 @@ func NewPolicyAppliedEvent(source Source, engineResponse *response.EngineRespons
 	}
 }
 
+// NewPolicyAuditMutationEvent reports the patch of a mutation rule in audit mode, the patch is not applied to the resource
+func NewPolicyAuditMutationEvent(source Source, engineResponse *response.EngineResponse, ruleResp *response.RuleResponse, patch []byte) Info {
+	resource := engineResponse.GetResourceSpec()
+	var bldr strings.Builder
+	defer bldr.Reset()
+
+	if resource.Namespace != "" {
+		fmt.Fprintf(&bldr, "%s %s/%s", resource.Kind, resource.Namespace, resource.Name)
+	} else {
+		fmt.Fprintf(&bldr, "%s %s", resource.Kind, resource.Name)
+	}
+	fmt.Fprintf(&bldr, ": [%s] %s; patch: %s", ruleResp.Name, ruleResp.Message, patch)
+
+	return Info{
+		Kind:      getPolicyKind(engineResponse.Policy),
+		Name:      engineResponse.PolicyResponse.Policy.Name,
+		Namespace: engineResponse.PolicyResponse.Policy.Namespace,
+		Reason:    PolicyApplied.String(),
+		Source:    source,
+		Message:   bldr.String(),
+	}
+}
+
 func NewResourceViolationEvent(source Source, reason Reason, engineResponse *response.EngineResponse, ruleResp *response.RuleResponse) Info {
 	var bldr strings.Builder
 	defer bldr.Reset()
@@ -107,13 +130,16 @@ func NewBackgroundFailedEvent(err error, policy, rule string, source Source, r *
 	return events
 }
 
-func NewBackgroundSuccessEvent(policy, rule string, source Source, r *unstructured.Unstructured) []Info {
+func NewBackgroundSuccessEvent(policy, rule string, source Source, r *unstructured.Unstructured, patchedPaths []string) []Info {
 	if r == nil {
 		return nil
 	}
 
 	var events []Info
 	msg := fmt.Sprintf("policy %s/%s applied", policy, rule)
+	if len(patchedPaths) > 0 {
+		msg = fmt.Sprintf("%s, patched %s", msg, strings.Join(patchedPaths, ", "))
+	}
 	events = append(events, Info{
 		Kind:      r.GetKind(),
 		Namespace: r.GetNamespace(),
@@ -125,3 +151,47 @@ func NewBackgroundSuccessEvent(policy, rule string, source Source, r *unstructur
 
 	return events
 }
+
+// NewUpdateRequestFailedEvent reports an update request that failed after exhausting its retries, the event is attached to the policy
+func NewUpdateRequestFailedEvent(source Source, policyNamespace, policyName, urName string, err error) Info {
+	kind := "ClusterPolicy"
+	if policyNamespace != "" {
+		kind = "Policy"
+	}
+	return Info{
+		Kind:      kind,
+		Namespace: policyNamespace,
+		Name:      policyName,
+		Source:    source,
+		Reason:    PolicyError.String(),
+		Message:   fmt.Sprintf("update request %s failed: %v", urName, err),
+	}
+}
+
+// NewUpdateRequestsDroppedEvent reports pending update requests deleted because the policy exceeded the cap, the event is attached to the policy
+func NewUpdateRequestsDroppedEvent(source Source, policyNamespace, policyName string, count, limit int) Info {
+	kind := "ClusterPolicy"
+	if policyNamespace != "" {
+		kind = "Policy"
+	}
+	return Info{
+		Kind:      kind,
+		Namespace: policyNamespace,
+		Name:      policyName,
+		Source:    source,
+		Reason:    PolicyError.String(),
+		Message:   fmt.Sprintf("%d pending update request(s) dropped, the policy exceeded the limit of %d pending update requests", count, limit),
+	}
+}
+
+// NewGeneratedResourceDriftEvent reports a generated resource that no longer matches the state declared by its generate rule
+func NewGeneratedResourceDriftEvent(policy, rule string, r *unstructured.Unstructured, message string) Info {
+	return Info{
+		Kind:      r.GetKind(),
+		Namespace: r.GetNamespace(),
+		Name:      r.GetName(),
+		Source:    GeneratePolicyController,
+		Reason:    PolicyViolation.String(),
+		Message:   fmt.Sprintf("policy %s/%s: %s", policy, rule, message),
+	}
+}
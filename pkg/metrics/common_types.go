@@ -34,11 +34,12 @@ const (
 type RuleResult string
 
 const (
-	Pass  RuleResult = "pass"
-	Fail  RuleResult = "fail"
-	Warn  RuleResult = "warn"
-	Error RuleResult = "error"
-	Skip  RuleResult = "skip"
+	Pass    RuleResult = "pass"
+	Fail    RuleResult = "fail"
+	Warn    RuleResult = "warn"
+	Error   RuleResult = "error"
+	Skip    RuleResult = "skip"
+	Timeout RuleResult = "timeout"
 )
 
 type RuleExecutionCause string
@@ -79,3 +80,24 @@ const (
 	KyvernoClient      ClientType = "kyverno"
 	PolicyReportClient ClientType = "policyreport"
 )
+
+type ContextEntryType string
+
+const (
+	ContextEntryAPICall       ContextEntryType = "apiCall"
+	ContextEntryImageRegistry ContextEntryType = "imageRegistry"
+)
+
+type CacheResult string
+
+const (
+	CacheHit  CacheResult = "hit"
+	CacheMiss CacheResult = "miss"
+)
+
+type SheddingReason string
+
+const (
+	SheddingInFlight  SheddingReason = "in_flight"
+	SheddingRateLimit SheddingReason = "rate_limit"
+)
@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -16,6 +17,7 @@ import (
 	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/metric/instrument/asyncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/asyncint64"
 	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
 	"go.opentelemetry.io/otel/metric/instrument/syncint64"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
@@ -40,6 +42,20 @@ type MetricsConfig struct {
 	admissionRequestsMetric       syncint64.Counter
 	admissionReviewDurationMetric syncfloat64.Histogram
 	clientQueriesMetric           syncint64.Counter
+	contextCacheRequestsMetric    syncint64.Counter
+	updateRequestFailuresMetric   syncint64.Counter
+	updateRequestDropsMetric      syncint64.Counter
+	updateRequestsMetric          asyncint64.Gauge
+	shardProcessedMetric          syncint64.Counter
+	shardPendingMetric            asyncint64.Gauge
+	admissionRequestsShedMetric   syncint64.Counter
+
+	// last observed number of update requests by type and state
+	updateRequestsLock    sync.Mutex
+	updateRequestsBacklog map[updateRequestsKey]int64
+	// pending update requests owned by the current replica, other replicas report their own shard
+	shard        string
+	shardPending int64
 
 	// config
 	Config *kconfig.MetricsConfigData
@@ -54,6 +70,18 @@ type MetricsConfigManager interface {
 	RecordPolicyExecutionDuration(policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, ruleName string, ruleResult RuleResult, ruleType RuleType, ruleExecutionCause RuleExecutionCause, ruleExecutionLatency float64)
 	RecordAdmissionReviewDuration(resourceKind string, resourceNamespace string, resourceRequestOperation string, admissionRequestLatency float64)
 	RecordClientQueries(clientQueryOperation ClientQueryOperation, clientType ClientType, resourceKind string, resourceNamespace string)
+	RecordContextCacheRequests(contextEntryType ContextEntryType, cacheResult CacheResult)
+	RecordUpdateRequestFailures(requestType string, policyNamespace string, policyName string)
+	RecordUpdateRequestDrops(requestType string, policyNamespace string, policyName string)
+	RecordUpdateRequests(requestType string, state string, count int64)
+	RecordUpdateRequestShardProcessed(shard string, requestType string)
+	RecordUpdateRequestShardPending(shard string, count int64)
+	RecordAdmissionRequestsShed(resourceKind string, resourceNamespace string, reason SheddingReason, allowed bool)
+}
+
+type updateRequestsKey struct {
+	requestType string
+	state       string
 }
 
 func initializeMetrics(m *MetricsConfig) (*MetricsConfig, error) {
@@ -103,6 +131,55 @@ func initializeMetrics(m *MetricsConfig) (*MetricsConfig, error) {
 		return nil, err
 	}
 
+	m.contextCacheRequestsMetric, err = meter.SyncInt64().Counter("kyverno_context_cache_requests_total", instrument.WithDescription("can be used to track the number of hits and misses of the cache shared by apiCall and imageRegistry context entries"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_context_cache_requests_total")
+		return nil, err
+	}
+
+	m.updateRequestFailuresMetric, err = meter.SyncInt64().Counter("kyverno_update_request_failures_total", instrument.WithDescription("can be used to track the number of update requests moved to the Failed state after exhausting their retries"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_update_request_failures_total")
+		return nil, err
+	}
+
+	m.updateRequestDropsMetric, err = meter.SyncInt64().Counter("kyverno_update_request_drops_total", instrument.WithDescription("can be used to track the number of pending update requests deleted because their policy exceeded the maximum number of pending update requests"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_update_request_drops_total")
+		return nil, err
+	}
+
+	m.updateRequestsMetric, err = meter.AsyncInt64().Gauge("kyverno_update_requests", instrument.WithDescription("can be used to track the number of update requests present in the cluster by type and state"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_update_requests")
+		return nil, err
+	}
+
+	m.shardProcessedMetric, err = meter.SyncInt64().Counter("kyverno_update_request_shard_processed_total", instrument.WithDescription("can be used to track the number of update requests processed by each shard, a shard is a Kyverno replica"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_update_request_shard_processed_total")
+		return nil, err
+	}
+
+	m.shardPendingMetric, err = meter.AsyncInt64().Gauge("kyverno_update_request_shard_pending", instrument.WithDescription("can be used to track the number of pending update requests owned by the shard of the replica when the replicas change"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_update_request_shard_pending")
+		return nil, err
+	}
+
+	m.admissionRequestsShedMetric, err = meter.SyncInt64().Counter("kyverno_admission_requests_shed_total", instrument.WithDescription("can be used to track the number of admission requests shed because the admission server reached its in-flight or rate limits"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_admission_requests_shed_total")
+		return nil, err
+	}
+
+	m.updateRequestsBacklog = map[updateRequestsKey]int64{}
+	err = meter.RegisterCallback([]instrument.Asynchronous{m.updateRequestsMetric, m.shardPendingMetric}, m.observeUpdateRequests)
+	if err != nil {
+		m.Log.Error(err, "Failed to register callback, kyverno_update_requests")
+		return nil, err
+	}
+
 	return m, nil
 }
 
@@ -331,3 +408,93 @@ func (m *MetricsConfig) RecordClientQueries(clientQueryOperation ClientQueryOper
 
 	m.clientQueriesMetric.Add(ctx, 1, commonLabels...)
 }
+
+func (m *MetricsConfig) RecordContextCacheRequests(contextEntryType ContextEntryType, cacheResult CacheResult) {
+	ctx := context.Background()
+
+	commonLabels := []attribute.KeyValue{
+		attribute.String("context_entry_type", string(contextEntryType)),
+		attribute.String("cache_result", string(cacheResult)),
+	}
+
+	m.contextCacheRequestsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordUpdateRequestFailures(requestType string, policyNamespace string, policyName string) {
+	ctx := context.Background()
+
+	commonLabels := []attribute.KeyValue{
+		attribute.String("request_type", requestType),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+	}
+
+	m.updateRequestFailuresMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordUpdateRequestDrops(requestType string, policyNamespace string, policyName string) {
+	ctx := context.Background()
+
+	commonLabels := []attribute.KeyValue{
+		attribute.String("request_type", requestType),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+	}
+
+	m.updateRequestDropsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordUpdateRequests(requestType string, state string, count int64) {
+	m.updateRequestsLock.Lock()
+	defer m.updateRequestsLock.Unlock()
+
+	m.updateRequestsBacklog[updateRequestsKey{requestType: requestType, state: state}] = count
+}
+
+func (m *MetricsConfig) observeUpdateRequests(ctx context.Context) {
+	m.updateRequestsLock.Lock()
+	defer m.updateRequestsLock.Unlock()
+
+	for key, count := range m.updateRequestsBacklog {
+		commonLabels := []attribute.KeyValue{
+			attribute.String("request_type", key.requestType),
+			attribute.String("state", key.state),
+		}
+		m.updateRequestsMetric.Observe(ctx, count, commonLabels...)
+	}
+	if m.shard != "" {
+		m.shardPendingMetric.Observe(ctx, m.shardPending, attribute.String("shard", m.shard))
+	}
+}
+
+func (m *MetricsConfig) RecordUpdateRequestShardProcessed(shard string, requestType string) {
+	ctx := context.Background()
+
+	commonLabels := []attribute.KeyValue{
+		attribute.String("shard", shard),
+		attribute.String("request_type", requestType),
+	}
+
+	m.shardProcessedMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordUpdateRequestShardPending(shard string, count int64) {
+	m.updateRequestsLock.Lock()
+	defer m.updateRequestsLock.Unlock()
+
+	m.shard = shard
+	m.shardPending = count
+}
+
+func (m *MetricsConfig) RecordAdmissionRequestsShed(resourceKind string, resourceNamespace string, reason SheddingReason, allowed bool) {
+	ctx := context.Background()
+
+	commonLabels := []attribute.KeyValue{
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+		attribute.String("reason", string(reason)),
+		attribute.Bool("allowed", allowed),
+	}
+
+	m.admissionRequestsShedMetric.Add(ctx, 1, commonLabels...)
+}
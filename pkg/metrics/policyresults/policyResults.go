@@ -66,6 +66,8 @@ func ProcessEngineResponse(m *metrics.MetricsConfig, policy kyvernov1.PolicyInte
 			ruleResult = metrics.Error
 		case response.RuleStatusSkip:
 			ruleResult = metrics.Skip
+		case response.RuleStatusTimeout:
+			ruleResult = metrics.Timeout
 		default:
 			ruleResult = metrics.Fail
 		}
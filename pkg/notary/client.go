@@ -0,0 +1,187 @@
+package notary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	godigest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// maxEnvelopeSize is the maximum size of a signature envelope fetched from the registry
+const maxEnvelopeSize = 4 * 1024 * 1024
+
+var client Notary = &driver{}
+
+type Notary interface {
+	// FetchSignatures resolves the manifest descriptor of the image and returns the signature envelopes referring to it
+	FetchSignatures(ctx context.Context, ref name.Reference) (ocispec.Descriptor, []Envelope, error)
+}
+
+// Envelope is a signature envelope stored in the registry
+type Envelope struct {
+	MediaType string
+	Content   []byte
+}
+
+type descriptor struct {
+	MediaType    string `json:"mediaType"`
+	ArtifactType string `json:"artifactType,omitempty"`
+	Digest       string `json:"digest"`
+}
+
+type manifest struct {
+	ArtifactType string       `json:"artifactType,omitempty"`
+	Config       descriptor   `json:"config"`
+	Layers       []descriptor `json:"layers,omitempty"`
+	Blobs        []descriptor `json:"blobs,omitempty"`
+	Manifests    []descriptor `json:"manifests,omitempty"`
+}
+
+type driver struct{}
+
+func (d *driver) FetchSignatures(ctx context.Context, ref name.Reference) (ocispec.Descriptor, []Envelope, error) {
+	rc := registryclient.DefaultClient
+	desc, err := gcrremote.Head(ref, gcrremote.WithAuthFromKeychain(rc.Keychain()), gcrremote.WithTransport(rc.Transport()), gcrremote.WithContext(ctx))
+	if err != nil {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("failed to resolve digest of %s: %v", ref.String(), err)
+	}
+	digest := desc.Digest.String()
+	target := ocispec.Descriptor{
+		MediaType: string(desc.MediaType),
+		Digest:    godigest.Digest(digest),
+		Size:      desc.Size,
+	}
+
+	repo := ref.Context()
+	auth, err := rc.Keychain().Resolve(repo)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("failed to resolve credentials for %s: %v", repo.String(), err)
+	}
+	rt, err := transport.NewWithContext(ctx, repo.Registry, auth, rc.Transport(), []string{repo.Scope(transport.PullScope)})
+	if err != nil {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("failed to create transport for %s: %v", repo.String(), err)
+	}
+	r := &registry{ctx: ctx, client: &http.Client{Transport: rt}, repo: repo}
+
+	referrers, err := r.referrers(digest)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+
+	var envelopes []Envelope
+	for _, referrer := range referrers {
+		envelope, err := r.envelope(referrer.Digest)
+		if err != nil {
+			return ocispec.Descriptor{}, nil, err
+		}
+		if envelope != nil {
+			envelopes = append(envelopes, *envelope)
+		}
+	}
+
+	logger.V(4).Info("fetched notary signatures", "image", ref.String(), "digest", digest, "count", len(envelopes))
+	return target, envelopes, nil
+}
+
+type registry struct {
+	ctx    context.Context
+	client *http.Client
+	repo   name.Repository
+}
+
+// referrers lists the signature manifests referring to the digest, using the referrers API
+// and falling back to the referrers tag schema for registries that don't support it
+func (r *registry) referrers(digest string) ([]descriptor, error) {
+	index, err := r.getJSON(fmt.Sprintf("referrers/%s?artifactType=%s", digest, ArtifactTypeSignature), "application/vnd.oci.image.index.v1+json")
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		tag := strings.Replace(digest, ":", "-", 1)
+		index, err = r.getJSON("manifests/"+tag, "application/vnd.oci.image.index.v1+json")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if index == nil {
+		return nil, nil
+	}
+
+	var referrers []descriptor
+	for _, m := range index.Manifests {
+		if m.ArtifactType == ArtifactTypeSignature {
+			referrers = append(referrers, m)
+		}
+	}
+	return referrers, nil
+}
+
+// envelope fetches the signature envelope of a signature manifest
+func (r *registry) envelope(digest string) (*Envelope, error) {
+	m, err := r.getJSON("manifests/"+digest, "application/vnd.oci.image.manifest.v1+json", "application/vnd.oci.artifact.manifest.v1+json")
+	if err != nil {
+		return nil, err
+	}
+	if m == nil || (m.ArtifactType != ArtifactTypeSignature && m.Config.MediaType != ArtifactTypeSignature) {
+		return nil, nil
+	}
+
+	for _, blob := range append(m.Layers, m.Blobs...) {
+		if blob.MediaType != MediaTypeJWSEnvelope && blob.MediaType != MediaTypeCOSEEnvelope {
+			continue
+		}
+		content, err := r.get("blobs/"+blob.Digest, blob.MediaType)
+		if err != nil {
+			return nil, err
+		}
+		return &Envelope{MediaType: blob.MediaType, Content: content}, nil
+	}
+
+	logger.V(3).Info("skipping signature without a supported envelope", "digest", digest)
+	return nil, nil
+}
+
+func (r *registry) getJSON(path string, accept ...string) (*manifest, error) {
+	content, err := r.get(path, accept...)
+	if err != nil || content == nil {
+		return nil, err
+	}
+	var m manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("failed to decode %s from %s: %v", path, r.repo.String(), err)
+	}
+	return &m, nil
+}
+
+// get returns the content found at the path of the repository, or nil if it doesn't exist
+func (r *registry) get(path string, accept ...string) ([]byte, error) {
+	url := fmt.Sprintf("%s://%s/v2/%s/%s", r.repo.Registry.Scheme(), r.repo.RegistryStr(), r.repo.RepositoryStr(), path)
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(accept, ","))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s from %s: %v", path, r.repo.String(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s from %s: HTTP %d", path, r.repo.String(), resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxEnvelopeSize))
+}
@@ -0,0 +1,161 @@
+package notary
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	_ "github.com/notaryproject/notation-core-go/signature/cose"
+	_ "github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/verifier"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+const (
+	// ArtifactTypeSignature is the artifact type of Notary v2 signature manifests
+	ArtifactTypeSignature = "application/vnd.cncf.notary.signature"
+	// MediaTypeJWSEnvelope is the media type of JWS signature envelopes
+	MediaTypeJWSEnvelope = "application/jose+json"
+	// MediaTypeCOSEEnvelope is the media type of COSE signature envelopes
+	MediaTypeCOSEEnvelope = "application/cose"
+
+	// trustStoreName is the name of the trust store built from the certificates of the attestor
+	trustStoreName = "kyverno"
+	// identityPrefix is the prefix of the trusted identities matched against the subject of the signing certificate
+	identityPrefix = "x509.subject:"
+	// wildcard trusts any identity or registry scope
+	wildcard = "*"
+)
+
+// NewVerifier returns the verifier of Notary v2 signatures, the signatures are verified by notation
+// against a trust store made of the root certificates of the options and their trusted identities
+func NewVerifier() cosign.Verifier {
+	return &notaryVerifier{}
+}
+
+type notaryVerifier struct{}
+
+// Verify verifies that the image has at least one Notary v2 signature issued by a certificate
+// chaining to the trust store and matching the trusted identities
+func (v *notaryVerifier) Verify(ctx context.Context, opts cosign.Options) (*cosign.Response, error) {
+	ref, err := name.ParseReference(opts.ImageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image %s", opts.ImageRef)
+	}
+
+	certs, err := loadCertificates(opts.Roots)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load trust store")
+	}
+
+	notationVerifier, err := verifier.New(trustPolicy(opts.TrustedIdentities), &trustStore{certs: certs}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create notary verifier")
+	}
+
+	var (
+		target    ocispec.Descriptor
+		envelopes []Envelope
+	)
+	tracing.DoInSpan(ctx, "notary", "fetch_signatures", func(ctx context.Context) {
+		target, envelopes, err = client.FetchSignatures(ctx, ref)
+	})
+	if err != nil {
+		logger.Info("image verification failed", "error", err.Error())
+		return nil, err
+	}
+
+	if len(envelopes) == 0 {
+		return nil, fmt.Errorf("no notary signatures found for %s", opts.ImageRef)
+	}
+
+	digest := target.Digest.String()
+	verifyOpts := notation.VerifierVerifyOptions{
+		ArtifactReference: ref.Context().Name() + "@" + digest,
+		UserMetadata:      opts.Annotations,
+	}
+	var errs []error
+	for _, envelope := range envelopes {
+		verifyOpts.SignatureMediaType = envelope.MediaType
+		if _, err := notationVerifier.Verify(ctx, target, envelope.Content, verifyOpts); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		logger.V(3).Info("verified image", "image", opts.ImageRef, "digest", digest)
+		return &cosign.Response{Digest: digest}, nil
+	}
+
+	return nil, errors.Wrapf(multierr.Combine(errs...), "failed to verify notary signatures for %s", opts.ImageRef)
+}
+
+// trustPolicy returns the notation trust policy verifying the signatures against the trust store of
+// the attestor, any identity is trusted if the trusted identities are empty or contain "*"
+func trustPolicy(identities []string) *trustpolicy.Document {
+	var trusted []string
+	for _, identity := range identities {
+		identity = strings.TrimSpace(identity)
+		if identity == wildcard {
+			trusted = nil
+			break
+		}
+		trusted = append(trusted, identityPrefix+strings.TrimSpace(strings.TrimPrefix(identity, identityPrefix)))
+	}
+	if len(trusted) == 0 {
+		trusted = []string{wildcard}
+	}
+
+	return &trustpolicy.Document{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.TrustPolicy{{
+			Name:                  trustStoreName,
+			RegistryScopes:        []string{wildcard},
+			SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelStrict.Name},
+			TrustStores:           []string{string(truststore.TypeCA) + ":" + trustStoreName},
+			TrustedIdentities:     trusted,
+		}},
+	}
+}
+
+// trustStore is the notation trust store holding the certificates of the attestor
+type trustStore struct {
+	certs []*x509.Certificate
+}
+
+func (s *trustStore) GetCertificates(_ context.Context, _ truststore.Type, _ string) ([]*x509.Certificate, error) {
+	return s.certs, nil
+}
+
+func loadCertificates(certificates string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(certificates)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no valid certificates found")
+	}
+	return certs, nil
+}
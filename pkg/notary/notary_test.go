@@ -0,0 +1,192 @@
+package notary
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	godigest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/assert"
+)
+
+const (
+	testImage  = "ghcr.io/kyverno/test-verify-image:signed"
+	testDigest = "sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105"
+)
+
+var testTarget = ocispec.Descriptor{
+	MediaType: ocispec.MediaTypeImageManifest,
+	Digest:    godigest.Digest(testDigest),
+	Size:      528,
+}
+
+type testSigner struct {
+	rootPEM string
+	signer  signature.Signer
+}
+
+func newTestSigner(t *testing.T, subject pkix.Name, notAfter time.Time) *testSigner {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test root", Organization: []string{"kyverno"}, Country: []string{"US"}, Province: []string{"WA"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, &rootKey.PublicKey, rootKey)
+	assert.NilError(t, err)
+	root, err := x509.ParseCertificate(rootDER)
+	assert.NilError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	leafTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, root, &key.PublicKey, rootKey)
+	assert.NilError(t, err)
+	leaf, err := x509.ParseCertificate(leafDER)
+	assert.NilError(t, err)
+
+	signer, err := signature.NewLocalSigner([]*x509.Certificate{leaf, root}, key)
+	assert.NilError(t, err)
+	return &testSigner{
+		rootPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rootDER})),
+		signer:  signer,
+	}
+}
+
+func (s *testSigner) sign(t *testing.T, target ocispec.Descriptor, annotations map[string]string, signingTime time.Time) []byte {
+	target.Annotations = annotations
+	payload, err := json.Marshal(map[string]interface{}{"targetArtifact": target})
+	assert.NilError(t, err)
+	envelope, err := signature.NewEnvelope(jws.MediaTypeEnvelope)
+	assert.NilError(t, err)
+	sig, err := envelope.Sign(&signature.SignRequest{
+		Payload:       signature.Payload{ContentType: "application/vnd.cncf.notary.payload.v1+json", Content: payload},
+		Signer:        s.signer,
+		SigningTime:   signingTime,
+		SigningScheme: signature.SigningSchemeX509,
+		SigningAgent:  "kyverno-test",
+	})
+	assert.NilError(t, err)
+	return sig
+}
+
+func Test_Verify(t *testing.T) {
+	defer ClearMock()
+	subject := pkix.Name{CommonName: "signer", Organization: []string{"wabbit-network.io"}, Country: []string{"US"}, Province: []string{"WA"}}
+	signer := newTestSigner(t, subject, time.Now().Add(time.Hour))
+	untrusted := newTestSigner(t, subject, time.Now().Add(time.Hour))
+	shortLived := newTestSigner(t, subject, time.Now().Add(-time.Minute))
+	now := time.Now()
+	otherTarget := testTarget
+	otherTarget.Digest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+
+	testCases := []struct {
+		name      string
+		envelopes [][]byte
+		opts      cosign.Options
+		err       string
+	}{{
+		name:      "valid signature",
+		envelopes: [][]byte{signer.sign(t, testTarget, nil, now)},
+		opts:      cosign.Options{Roots: signer.rootPEM},
+	}, {
+		name:      "trusted identity",
+		envelopes: [][]byte{signer.sign(t, testTarget, nil, now)},
+		opts:      cosign.Options{Roots: signer.rootPEM, TrustedIdentities: []string{"x509.subject: C=US, ST=WA, O=wabbit-network.io"}},
+	}, {
+		name:      "trusted identity without prefix",
+		envelopes: [][]byte{signer.sign(t, testTarget, nil, now)},
+		opts:      cosign.Options{Roots: signer.rootPEM, TrustedIdentities: []string{"C=US, ST=WA, O=wabbit-network.io"}},
+	}, {
+		name:      "one valid signature",
+		envelopes: [][]byte{untrusted.sign(t, testTarget, nil, now), signer.sign(t, testTarget, nil, now)},
+		opts:      cosign.Options{Roots: signer.rootPEM},
+	}, {
+		name:      "untrusted identity",
+		envelopes: [][]byte{signer.sign(t, testTarget, nil, now)},
+		opts:      cosign.Options{Roots: signer.rootPEM, TrustedIdentities: []string{"C=US, ST=WA, O=acme-rockets.io"}},
+		err:       "signing certificate from the digital signature does not match the X.509 trusted identities",
+	}, {
+		name:      "invalid identity",
+		envelopes: [][]byte{signer.sign(t, testTarget, nil, now)},
+		opts:      cosign.Options{Roots: signer.rootPEM, TrustedIdentities: []string{"O=acme-rockets.io"}},
+		err:       "must contain 'C', 'ST', and 'O' RDN attributes",
+	}, {
+		name:      "untrusted root",
+		envelopes: [][]byte{untrusted.sign(t, testTarget, nil, now)},
+		opts:      cosign.Options{Roots: signer.rootPEM},
+		err:       "signature is not produced by a trusted signer",
+	}, {
+		name:      "expired certificate",
+		envelopes: [][]byte{shortLived.sign(t, testTarget, nil, now.Add(-2*time.Minute))},
+		opts:      cosign.Options{Roots: shortLived.rootPEM},
+		err:       "is not valid anymore",
+	}, {
+		name:      "digest mismatch",
+		envelopes: [][]byte{signer.sign(t, otherTarget, nil, now)},
+		opts:      cosign.Options{Roots: signer.rootPEM},
+		err:       "content descriptor mismatch",
+	}, {
+		name:      "annotations",
+		envelopes: [][]byte{signer.sign(t, testTarget, map[string]string{"env": "prod"}, now)},
+		opts:      cosign.Options{Roots: signer.rootPEM, Annotations: map[string]string{"env": "prod"}},
+	}, {
+		name:      "annotations mismatch",
+		envelopes: [][]byte{signer.sign(t, testTarget, map[string]string{"env": "dev"}, now)},
+		opts:      cosign.Options{Roots: signer.rootPEM, Annotations: map[string]string{"env": "prod"}},
+		err:       "unable to find specified metadata in the signature",
+	}, {
+		name: "no signatures",
+		opts: cosign.Options{Roots: signer.rootPEM},
+		err:  "no notary signatures found",
+	}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.NilError(t, SetMock(testImage, testTarget, test.envelopes))
+			test.opts.ImageRef = testImage
+			resp, err := NewVerifier().Verify(context.TODO(), test.opts)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, resp.Digest, testDigest)
+			}
+		})
+	}
+}
+
+func Test_trustPolicy(t *testing.T) {
+	policy := trustPolicy(nil)
+	assert.DeepEqual(t, policy.TrustPolicies[0].TrustedIdentities, []string{"*"})
+	policy = trustPolicy([]string{"C=US, ST=WA, O=wabbit-network.io", "*"})
+	assert.DeepEqual(t, policy.TrustPolicies[0].TrustedIdentities, []string{"*"})
+	policy = trustPolicy([]string{" x509.subject: C=US, ST=WA, O=wabbit-network.io"})
+	assert.DeepEqual(t, policy.TrustPolicies[0].TrustedIdentities, []string{"x509.subject:C=US, ST=WA, O=wabbit-network.io"})
+	assert.NilError(t, policy.Validate())
+}
@@ -441,3 +441,75 @@ spec:
 	err = hasInvalidVariables(policy[0], false)
 	assert.NilError(t, err)
 }
+
+func TestAllowedVars_CloneListName(t *testing.T) {
+	var policyYAML = []byte(`
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: sync-secrets
+spec:
+  rules:
+    - name: sync-shared-secrets
+      match:
+        resources:
+          kinds:
+            - Namespace
+      generate:
+        namespace: "{{request.object.metadata.name}}"
+        synchronize: true
+        cloneList:
+          kinds:
+            - v1/Secret
+          namespaceSelector:
+            matchLabels:
+              shared: "true"
+          name: "{{ source.namespace }}-{{ source.name }}"
+`)
+
+	policyJSON, err := yaml.ToJSON(policyYAML)
+	assert.NilError(t, err)
+
+	policy, err := yamlutils.GetPolicy(policyJSON)
+	assert.NilError(t, err)
+
+	err = hasInvalidVariables(policy[0], false)
+	assert.NilError(t, err)
+}
+
+func TestNotAllowedVars_CloneListName(t *testing.T) {
+	var policyYAML = []byte(`
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: sync-secrets
+spec:
+  rules:
+    - name: sync-shared-secrets
+      match:
+        resources:
+          kinds:
+            - Namespace
+      generate:
+        namespace: "{{request.object.metadata.name}}"
+        synchronize: true
+        cloneList:
+          kinds:
+            - v1/Secret
+          namespaceSelector:
+            matchLabels:
+              shared: "true"
+          name: "{{ source.namespace }}-{{ source.uid }}"
+`)
+
+	policyJSON, err := yaml.ToJSON(policyYAML)
+	assert.NilError(t, err)
+
+	policy, err := yamlutils.GetPolicy(policyJSON)
+	assert.NilError(t, err)
+
+	err = hasInvalidVariables(policy[0], false)
+	assert.ErrorContains(t, err, "generate.cloneList.name")
+}
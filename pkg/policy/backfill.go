@@ -0,0 +1,142 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// backfillStatusInterval is the period at which the backfill progress is refreshed while update requests are pending
+const backfillStatusInterval = 10 * time.Second
+
+// backfillRequested returns true if the backfill annotation of a generate existing policy was set to a new value
+func backfillRequested(old, cur kyvernov1.PolicyInterface) bool {
+	if !cur.GetSpec().IsGenerateExistingOnPolicyUpdate() {
+		return false
+	}
+	value := cur.GetAnnotations()[kyvernov1.AnnotationGenerateExistingBackfill]
+	return value != "" && value != old.GetAnnotations()[kyvernov1.AnnotationGenerateExistingBackfill]
+}
+
+// listBackfillURs returns the generate URs created by the backfills of the policy, the URs created
+// by admission requests are not part of the backfill progress
+func (pc *PolicyController) listBackfillURs(policyKey string) []*kyvernov1beta1.UpdateRequest {
+	set := common.GenerateLabelsSet(policyKey, nil)
+	set[kyvernov1beta1.URGenerateBackfillLabel] = "true"
+	urs, err := pc.urLister.List(labels.SelectorFromSet(set))
+	if err != nil {
+		pc.log.Error(err, "failed to list backfill update requests")
+	}
+	return urs
+}
+
+// countBackfill sets the update request counts of the backfill status
+func countBackfill(status *kyvernov1.GenerateExistingStatus, urs []*kyvernov1beta1.UpdateRequest) {
+	status.Processed, status.Failed, status.Pending = 0, 0, 0
+	for _, ur := range urs {
+		switch ur.Status.State {
+		case kyvernov1beta1.Completed, kyvernov1beta1.Skip:
+			status.Processed++
+		case kyvernov1beta1.Failed:
+			status.Failed++
+		default:
+			status.Pending++
+		}
+	}
+}
+
+// startBackfillStatus records a new backfill run in the policy status and schedules the refresh of its progress
+func (pc *PolicyController) startBackfillStatus(policyKey string, policy kyvernov1.PolicyInterface, triggers int) {
+	now := metav1.Now()
+	err := pc.updateBackfillStatus(policyKey, policy, func(status *kyvernov1.GenerateExistingStatus) {
+		status.TriggersDiscovered = triggers
+		status.LastRunTime = &now
+		status.Trigger = policy.GetAnnotations()[kyvernov1.AnnotationGenerateExistingBackfill]
+	})
+	if err != nil {
+		pc.log.Error(err, "failed to update generate existing status", "policy", policyKey)
+	}
+	pc.backfillStatusQueue.AddAfter(policyKey, backfillStatusInterval)
+}
+
+// updateBackfillStatus recomputes the update request counts of the backfill, applies the changes of the
+// build function and updates the policy status
+func (pc *PolicyController) updateBackfillStatus(policyKey string, policy kyvernov1.PolicyInterface, build func(*kyvernov1.GenerateExistingStatus)) error {
+	urs := pc.listBackfillURs(policyKey)
+	setStatus := func(policy kyvernov1.PolicyInterface) error {
+		status := policy.GetStatus()
+		if status.GenerateExisting == nil {
+			status.GenerateExisting = &kyvernov1.GenerateExistingStatus{}
+		}
+		countBackfill(status.GenerateExisting, urs)
+		if build != nil {
+			build(status.GenerateExisting)
+		}
+		return nil
+	}
+	var err error
+	if policy.GetNamespace() == "" {
+		_, err = controllerutils.UpdateStatus(
+			context.TODO(),
+			policy.(*kyvernov1.ClusterPolicy),
+			pc.kyvernoClient.KyvernoV1().ClusterPolicies(),
+			func(policy *kyvernov1.ClusterPolicy) error {
+				return setStatus(policy)
+			},
+		)
+	} else {
+		_, err = controllerutils.UpdateStatus(
+			context.TODO(),
+			policy.(*kyvernov1.Policy),
+			pc.kyvernoClient.KyvernoV1().Policies(policy.GetNamespace()),
+			func(policy *kyvernov1.Policy) error {
+				return setStatus(policy)
+			},
+		)
+	}
+	return err
+}
+
+// backfillStatusWorker refreshes the progress of the generate existing backfills
+// until their update requests are processed.
+func (pc *PolicyController) backfillStatusWorker(ctx context.Context) {
+	for pc.processNextBackfillStatus() {
+	}
+}
+
+func (pc *PolicyController) processNextBackfillStatus() bool {
+	key, quit := pc.backfillStatusQueue.Get()
+	if quit {
+		return false
+	}
+	defer pc.backfillStatusQueue.Done(key)
+	policyKey := key.(string)
+	logger := pc.log.WithName("backfillStatus").WithValues("key", policyKey)
+	policy, err := pc.getPolicy(policyKey)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "failed to get policy")
+		}
+		return true
+	}
+	if !policy.GetSpec().IsGenerateExistingOnPolicyUpdate() {
+		return true
+	}
+	err = pc.updateBackfillStatus(policyKey, policy, nil)
+	if err != nil {
+		logger.Error(err, "failed to update generate existing status")
+	}
+	var progress kyvernov1.GenerateExistingStatus
+	countBackfill(&progress, pc.listBackfillURs(policyKey))
+	if err != nil || progress.Pending > 0 {
+		pc.backfillStatusQueue.AddAfter(policyKey, backfillStatusInterval)
+	}
+	return true
+}
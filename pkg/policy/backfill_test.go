@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newBackfillPolicy(generateExisting bool, backfill string) *kyvernov1.ClusterPolicy {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "add-networkpolicy"},
+		Spec:       kyvernov1.Spec{GenerateExistingOnPolicyUpdate: generateExisting},
+	}
+	if backfill != "" {
+		policy.SetAnnotations(map[string]string{kyvernov1.AnnotationGenerateExistingBackfill: backfill})
+	}
+	return policy
+}
+
+func Test_backfillRequested(t *testing.T) {
+	testCases := []struct {
+		name string
+		old  *kyvernov1.ClusterPolicy
+		cur  *kyvernov1.ClusterPolicy
+		want bool
+	}{
+		{"annotation added", newBackfillPolicy(true, ""), newBackfillPolicy(true, "1"), true},
+		{"annotation changed", newBackfillPolicy(true, "1"), newBackfillPolicy(true, "2"), true},
+		{"annotation unchanged", newBackfillPolicy(true, "1"), newBackfillPolicy(true, "1"), false},
+		{"annotation removed", newBackfillPolicy(true, "1"), newBackfillPolicy(true, ""), false},
+		{"generate existing disabled", newBackfillPolicy(false, ""), newBackfillPolicy(false, "1"), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, backfillRequested(tc.old, tc.cur), tc.want)
+		})
+	}
+}
+
+func Test_updateBackfillStatus(t *testing.T) {
+	policy := newBackfillPolicy(true, "1")
+	newUR := func(name, policyName string, state kyvernov1beta1.UpdateRequestState) *kyvernov1beta1.UpdateRequest {
+		return &kyvernov1beta1.UpdateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: config.KyvernoNamespace(),
+				Labels: map[string]string{
+					kyvernov1beta1.URGeneratePolicyLabel:   policyName,
+					kyvernov1beta1.URGenerateBackfillLabel: "true",
+				},
+			},
+			Spec:   kyvernov1beta1.UpdateRequestSpec{Type: kyvernov1beta1.Generate, Policy: policyName},
+			Status: kyvernov1beta1.UpdateRequestStatus{State: state},
+		}
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, ur := range []*kyvernov1beta1.UpdateRequest{
+		newUR("ur-1", policy.GetName(), kyvernov1beta1.Completed),
+		newUR("ur-2", policy.GetName(), kyvernov1beta1.Skip),
+		newUR("ur-3", policy.GetName(), kyvernov1beta1.Failed),
+		newUR("ur-4", policy.GetName(), kyvernov1beta1.Pending),
+		newUR("ur-5", policy.GetName(), ""),
+		newUR("ur-6", "other-policy", kyvernov1beta1.Failed),
+	} {
+		assert.NilError(t, indexer.Add(ur))
+	}
+	// URs created by admission requests are not counted
+	admissionUR := newUR("ur-7", policy.GetName(), kyvernov1beta1.Failed)
+	delete(admissionUR.Labels, kyvernov1beta1.URGenerateBackfillLabel)
+	assert.NilError(t, indexer.Add(admissionUR))
+	client := fake.NewSimpleClientset(policy)
+	pc := &PolicyController{
+		kyvernoClient: client,
+		urLister:      kyvernov1beta1listers.NewUpdateRequestLister(indexer),
+		log:           logging.GlobalLogger(),
+	}
+
+	err := pc.updateBackfillStatus(policy.GetName(), policy, func(status *kyvernov1.GenerateExistingStatus) {
+		status.TriggersDiscovered = 5
+		status.Trigger = "1"
+	})
+	assert.NilError(t, err)
+
+	updated, err := client.KyvernoV1().ClusterPolicies().Get(context.TODO(), policy.GetName(), metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, updated.Status.GenerateExisting, &kyvernov1.GenerateExistingStatus{
+		TriggersDiscovered: 5,
+		Processed:          2,
+		Failed:             1,
+		Pending:            2,
+		Trigger:            "1",
+	})
+}
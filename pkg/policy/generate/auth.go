@@ -16,6 +16,8 @@ type Operations interface {
 	CanIDelete(kind, namespace string) (bool, error)
 	// CanIGet returns 'true' if self can 'get' resource
 	CanIGet(kind, namespace string) (bool, error)
+	// CanIPatch returns 'true' if self can 'patch' resource
+	CanIPatch(kind, namespace string) (bool, error)
 }
 
 // Auth provides implementation to check if caller/self/kyverno has access to perofrm operations
@@ -72,3 +74,13 @@ func (a *Auth) CanIGet(kind, namespace string) (bool, error) {
 	}
 	return ok, nil
 }
+
+// CanIPatch returns 'true' if self can 'patch' resource
+func (a *Auth) CanIPatch(kind, namespace string) (bool, error) {
+	canI := auth.NewCanI(a.client, kind, namespace, "patch")
+	ok, err := canI.RunAccessCheck()
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
@@ -28,3 +28,8 @@ func (a *FakeAuth) CanIDelete(kind, namespace string) (bool, error) {
 func (a *FakeAuth) CanIGet(kind, namespace string) (bool, error) {
 	return true, nil
 }
+
+// CanIPatch returns 'true'
+func (a *FakeAuth) CanIPatch(kind, namespace string) (bool, error) {
+	return true, nil
+}
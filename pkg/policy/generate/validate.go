@@ -3,6 +3,7 @@ package generate
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -14,6 +15,16 @@ import (
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
 )
 
+// referencesSourceNamespace checks if a cloneList name template references the namespace of the source resources
+func referencesSourceNamespace(template string) bool {
+	for _, variable := range variables.RegexVariables.FindAllString(template, -1) {
+		if strings.Contains(variable, "source.namespace") {
+			return true
+		}
+	}
+	return false
+}
+
 // Generate provides implementation to validate 'generate' rule
 type Generate struct {
 	// rule to hold 'generate' rule specifications
@@ -38,6 +49,10 @@ func NewGenerateFactory(client dclient.Interface, rule kyvernov1.Generation, log
 // Validate validates the 'generate' rule
 func (g *Generate) Validate() (string, error) {
 	rule := g.rule
+	if len(rule.ForEachGeneration) != 0 {
+		return g.validateForEach()
+	}
+
 	if rule.GetData() != nil && rule.Clone != (kyvernov1.CloneFrom{}) {
 		return "", fmt.Errorf("only one of data or clone can be specified")
 	}
@@ -46,6 +61,10 @@ func (g *Generate) Validate() (string, error) {
 		return "", fmt.Errorf("only one of clone or cloneList can be specified")
 	}
 
+	if rule.IsServerSideApply() && (rule.Clone != (kyvernov1.CloneFrom{}) || len(rule.CloneList.Kinds) != 0) {
+		return "applyMode", fmt.Errorf("applyMode %s is only supported with data", kyvernov1.GenerateApplyModeServerSideApply)
+	}
+
 	kind, name, namespace := rule.Kind, rule.Name, rule.Namespace
 
 	if len(rule.CloneList.Kinds) == 0 {
@@ -70,6 +89,19 @@ func (g *Generate) Validate() (string, error) {
 		}
 	}
 
+	if rule.CloneList.NamespaceSelector != nil {
+		if rule.CloneList.Namespace != "" {
+			return "cloneList.namespaceSelector", fmt.Errorf("only one of namespace or namespaceSelector can be specified")
+		}
+		if wildcard.ContainsWildcard(rule.CloneList.NamespaceSelector.String()) {
+			return "cloneList.namespaceSelector", fmt.Errorf("wildcard characters `*/?` not supported")
+		}
+		// resources with the same name in different source namespaces would be cloned to the same resource
+		if !referencesSourceNamespace(rule.CloneList.Name) {
+			return "cloneList.name", fmt.Errorf("the name template must reference {{ source.namespace }} when namespaceSelector is specified")
+		}
+	}
+
 	if !reflect.DeepEqual(rule.Clone, kyvernov1.CloneFrom{}) {
 		if path, err := g.validateClone(rule.Clone, rule.CloneList, kind); err != nil {
 			return fmt.Sprintf("clone.%s", path), err
@@ -103,6 +135,47 @@ func (g *Generate) Validate() (string, error) {
 	return "", nil
 }
 
+// validateForEach validates the resources generated for each element of a list
+func (g *Generate) validateForEach() (string, error) {
+	rule := g.rule
+	if rule.Kind != "" || rule.Name != "" || rule.GetData() != nil || rule.Clone != (kyvernov1.CloneFrom{}) || len(rule.CloneList.Kinds) != 0 {
+		return "foreach", fmt.Errorf("with foreach, generate.kind, generate.name, generate.data, generate.clone and generate.cloneList should not be specified")
+	}
+
+	for i, foreach := range rule.ForEachGeneration {
+		path := fmt.Sprintf("foreach[%d]", i)
+		if foreach.List == "" {
+			return path + ".list", fmt.Errorf("list cannot be empty")
+		}
+		if foreach.Name == "" {
+			return path + ".name", fmt.Errorf("name cannot be empty")
+		}
+		if foreach.Kind == "" {
+			return path + ".kind", fmt.Errorf("kind cannot be empty")
+		}
+		if foreach.GetData() != nil && foreach.Clone != (kyvernov1.CloneFrom{}) {
+			return path, fmt.Errorf("only one of data or clone can be specified")
+		}
+		if rule.IsServerSideApply() && foreach.Clone != (kyvernov1.CloneFrom{}) {
+			return path + ".clone", fmt.Errorf("applyMode %s is only supported with data", kyvernov1.GenerateApplyModeServerSideApply)
+		}
+		if foreach.Clone != (kyvernov1.CloneFrom{}) {
+			if clonePath, err := g.validateClone(foreach.Clone, kyvernov1.CloneList{}, foreach.Kind); err != nil {
+				return fmt.Sprintf("%s.clone.%s", path, clonePath), err
+			}
+		}
+		if target := foreach.GetData(); target != nil {
+			if dataPath, err := common.ValidatePattern(target, "/", []commonAnchors.IsAnchor{}); err != nil {
+				return fmt.Sprintf("%s.data.%s", path, dataPath), fmt.Errorf("anchors not supported on generate resources: %v", err)
+			}
+		}
+		if err := g.canIGenerate(foreach.Kind, foreach.Namespace); err != nil {
+			return path, err
+		}
+	}
+	return "", nil
+}
+
 func (g *Generate) validateClone(c kyvernov1.CloneFrom, cl kyvernov1.CloneList, kind string) (string, error) {
 	if len(cl.Kinds) == 0 {
 		if c.Name == "" {
@@ -169,6 +242,18 @@ func (g *Generate) canIGenerate(kind, namespace string) error {
 		if !ok {
 			return fmt.Errorf("kyverno does not have permissions to 'delete' resource %s/%s. Update permissions in ClusterRole 'kyverno:generate'", kind, namespace)
 		}
+
+		// PATCH
+		if g.rule.IsServerSideApply() {
+			ok, err = authCheck.CanIPatch(kind, namespace)
+			if err != nil {
+				// machinery error
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("kyverno does not have permissions to 'patch' resource %s/%s. Update permissions in ClusterRole 'kyverno:generate'", kind, namespace)
+			}
+		}
 	} else {
 		g.log.V(4).Info("name & namespace uses variables, so cannot be resolved. Skipping Auth Checks.")
 	}
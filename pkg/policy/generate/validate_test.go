@@ -87,3 +87,127 @@ func Test_Validate_Generate_HasAnchors(t *testing.T) {
 		assert.Assert(t, err != nil)
 	}
 }
+
+func Test_Validate_Generate_ForEach(t *testing.T) {
+	rawGenerate := []byte(`
+	{
+		"foreach": [
+			{
+				"list": "request.object.spec.containers",
+				"kind": "ConfigMap",
+				"name": "{{ element.name }}-config",
+				"namespace": "{{ request.object.metadata.namespace }}",
+				"data": {
+					"data": {
+						"image": "{{ element.image }}"
+					}
+				}
+			}
+		]
+	}`)
+
+	var genRule kyverno.Generation
+	err := json.Unmarshal(rawGenerate, &genRule)
+	assert.NilError(t, err)
+	checker := NewFakeGenerate(genRule)
+	_, err = checker.Validate()
+	assert.NilError(t, err)
+}
+
+func Test_Validate_Generate_ForEach_Errors(t *testing.T) {
+	testcases := []struct {
+		name string
+		raw  []byte
+		path string
+	}{
+		{
+			name: "top-level-kind",
+			raw:  []byte(`{"kind": "ConfigMap", "foreach": [{"list": "request.object.spec.containers", "kind": "ConfigMap", "name": "{{ element.name }}"}]}`),
+			path: "foreach",
+		},
+		{
+			name: "missing-list",
+			raw:  []byte(`{"foreach": [{"kind": "ConfigMap", "name": "{{ element.name }}"}]}`),
+			path: "foreach[0].list",
+		},
+		{
+			name: "missing-name",
+			raw:  []byte(`{"foreach": [{"list": "request.object.spec.containers", "kind": "ConfigMap"}]}`),
+			path: "foreach[0].name",
+		},
+		{
+			name: "data-and-clone",
+			raw:  []byte(`{"foreach": [{"list": "request.object.spec.containers", "kind": "ConfigMap", "name": "{{ element.name }}", "data": {}, "clone": {"name": "source", "namespace": "default"}}]}`),
+			path: "foreach[0]",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var genRule kyverno.Generation
+			err := json.Unmarshal(tc.raw, &genRule)
+			assert.NilError(t, err)
+			checker := NewFakeGenerate(genRule)
+			path, err := checker.Validate()
+			assert.Assert(t, err != nil)
+			assert.Equal(t, path, tc.path)
+		})
+	}
+}
+
+func Test_Validate_Generate_ApplyMode(t *testing.T) {
+	testcases := []struct {
+		name string
+		raw  []byte
+		path string
+		err  bool
+	}{
+		{
+			name: "server-side-apply-data",
+			raw:  []byte(`{"kind": "ConfigMap", "name": "cm", "namespace": "default", "applyMode": "ServerSideApply", "data": {"data": {"key": "value"}}}`),
+		},
+		{
+			name: "server-side-apply-clone",
+			raw:  []byte(`{"kind": "ConfigMap", "name": "cm", "namespace": "default", "applyMode": "ServerSideApply", "clone": {"name": "source", "namespace": "default"}}`),
+			path: "applyMode",
+			err:  true,
+		},
+		{
+			name: "server-side-apply-foreach-clone",
+			raw:  []byte(`{"applyMode": "ServerSideApply", "foreach": [{"list": "request.object.spec.containers", "kind": "ConfigMap", "name": "{{ element.name }}", "clone": {"name": "source", "namespace": "default"}}]}`),
+			path: "foreach[0].clone",
+			err:  true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var genRule kyverno.Generation
+			err := json.Unmarshal(tc.raw, &genRule)
+			assert.NilError(t, err)
+			checker := NewFakeGenerate(genRule)
+			path, err := checker.Validate()
+			assert.Equal(t, err != nil, tc.err)
+			assert.Equal(t, path, tc.path)
+		})
+	}
+}
+
+func Test_Validate_Generate_CloneList_NamespaceSelector(t *testing.T) {
+	var genRule kyverno.Generation
+	err := json.Unmarshal([]byte(`{"namespace": "default", "cloneList": {"kinds": ["v1/Secret"], "namespaceSelector": {"matchLabels": {"shared": "true"}}, "name": "{{ source.namespace }}-{{ source.name }}"}}`), &genRule)
+	assert.NilError(t, err)
+	_, err = NewFakeGenerate(genRule).Validate()
+	assert.NilError(t, err)
+
+	genRule.CloneList.Namespace = "default"
+	path, err := NewFakeGenerate(genRule).Validate()
+	assert.Error(t, err, "only one of namespace or namespaceSelector can be specified")
+	assert.Equal(t, path, "cloneList.namespaceSelector")
+
+	genRule.CloneList.Namespace = ""
+	for _, name := range []string{"", "{{ source.name }}", "source.namespace-{{ source.name }}"} {
+		genRule.CloneList.Name = name
+		path, err = NewFakeGenerate(genRule).Validate()
+		assert.Error(t, err, "the name template must reference {{ source.namespace }} when namespaceSelector is specified", name)
+		assert.Equal(t, path, "cloneList.name")
+	}
+}
@@ -62,6 +62,12 @@ type PolicyController struct {
 	// Policies that need to be synced
 	queue workqueue.RateLimitingInterface
 
+	// Policies whose mutateExisting rules are reconciled on a schedule
+	mutateExistingQueue workqueue.DelayingInterface
+
+	// Policies whose generate existing backfill progress is refreshed in their status
+	backfillStatusQueue workqueue.DelayingInterface
+
 	// pLister can list/get policy from the shared informer's store
 	pLister kyvernov1listers.ClusterPolicyLister
 
@@ -113,17 +119,19 @@ func NewPolicyController(
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: eventInterface})
 
 	pc := PolicyController{
-		client:          client,
-		kyvernoClient:   kyvernoClient,
-		pInformer:       pInformer,
-		npInformer:      npInformer,
-		eventGen:        eventGen,
-		eventRecorder:   eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "policy_controller"}),
-		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "policy"),
-		configHandler:   configHandler,
-		reconcilePeriod: reconcilePeriod,
-		metricsConfig:   metricsConfig,
-		log:             log,
+		client:              client,
+		kyvernoClient:       kyvernoClient,
+		pInformer:           pInformer,
+		npInformer:          npInformer,
+		eventGen:            eventGen,
+		eventRecorder:       eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "policy_controller"}),
+		queue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "policy"),
+		mutateExistingQueue: workqueue.NewNamedDelayingQueue("mutate-existing"),
+		backfillStatusQueue: workqueue.NewNamedDelayingQueue("generate-existing-status"),
+		configHandler:       configHandler,
+		reconcilePeriod:     reconcilePeriod,
+		metricsConfig:       metricsConfig,
+		log:                 log,
 	}
 
 	pc.pLister = pInformer.Lister()
@@ -177,7 +185,7 @@ func (pc *PolicyController) updatePolicy(old, cur interface{}) {
 		return
 	}
 
-	if reflect.DeepEqual(oldP.Spec, curP.Spec) {
+	if reflect.DeepEqual(oldP.Spec, curP.Spec) && !backfillRequested(oldP, curP) {
 		return
 	}
 
@@ -196,11 +204,11 @@ func (pc *PolicyController) deletePolicy(obj interface{}) {
 
 	logger.Info("policy deleted", "uid", p.UID, "kind", "ClusterPolicy", "name", p.Name)
 
-	// do not clean up UR on generate clone (sync=true) policy deletion
+	// do not clean up UR on generate clone (sync=true) policy deletion, unless a deletion policy is declared
 	rules := autogen.ComputeRules(p)
 	for _, r := range rules {
 		clone, sync := r.GetCloneSyncForGenerate()
-		if clone && sync {
+		if clone && sync && r.Generation.DeletionPolicy == "" {
 			return
 		}
 	}
@@ -229,7 +237,7 @@ func (pc *PolicyController) updateNsPolicy(old, cur interface{}) {
 		return
 	}
 
-	if reflect.DeepEqual(oldP.Spec, curP.Spec) {
+	if reflect.DeepEqual(oldP.Spec, curP.Spec) && !backfillRequested(oldP, curP) {
 		return
 	}
 
@@ -250,11 +258,11 @@ func (pc *PolicyController) deleteNsPolicy(obj interface{}) {
 
 	pol := p
 
-	// do not clean up UR on generate clone (sync=true) policy deletion
+	// do not clean up UR on generate clone (sync=true) policy deletion, unless a deletion policy is declared
 	rules := autogen.ComputeRules(pol)
 	for _, r := range rules {
 		clone, sync := r.GetCloneSyncForGenerate()
-		if clone && sync {
+		if clone && sync && r.Generation.DeletionPolicy == "" {
 			return
 		}
 	}
@@ -277,6 +285,8 @@ func (pc *PolicyController) Run(ctx context.Context, workers int) {
 
 	defer utilruntime.HandleCrash()
 	defer pc.queue.ShutDown()
+	defer pc.mutateExistingQueue.ShutDown()
+	defer pc.backfillStatusQueue.ShutDown()
 
 	logger.Info("starting")
 	defer logger.Info("shutting down")
@@ -301,6 +311,8 @@ func (pc *PolicyController) Run(ctx context.Context, workers int) {
 		go wait.UntilWithContext(ctx, pc.worker, time.Second)
 	}
 
+	go wait.UntilWithContext(ctx, pc.mutateExistingWorker, time.Second)
+	go wait.UntilWithContext(ctx, pc.backfillStatusWorker, time.Second)
 	go pc.forceReconciliation(ctx)
 
 	<-ctx.Done()
@@ -364,9 +376,54 @@ func (pc *PolicyController) syncPolicy(key string) error {
 		}
 	}
 	pc.processExistingResources(policy)
+	pc.scheduleMutateExisting(key, policy)
 	return nil
 }
 
+// scheduleMutateExisting queues the next reconciliation of the policy mutateExisting rules,
+// items already waiting in the queue are not added twice
+func (pc *PolicyController) scheduleMutateExisting(key string, policy kyvernov1.PolicyInterface) {
+	spec := policy.GetSpec()
+	if interval := spec.GetMutateExistingInterval(); interval > 0 && spec.IsMutateExisting() {
+		pc.mutateExistingQueue.AddAfter(key, interval)
+	}
+}
+
+// mutateExistingWorker re-applies the mutateExisting rules of the policies
+// configured with mutateExistingInterval, and schedules the next run.
+func (pc *PolicyController) mutateExistingWorker(ctx context.Context) {
+	for pc.processNextMutateExisting() {
+	}
+}
+
+func (pc *PolicyController) processNextMutateExisting() bool {
+	key, quit := pc.mutateExistingQueue.Get()
+	if quit {
+		return false
+	}
+	defer pc.mutateExistingQueue.Done(key)
+	policyKey := key.(string)
+	logger := pc.log.WithName("mutateExisting").WithValues("key", policyKey)
+	policy, err := pc.getPolicy(policyKey)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "failed to get policy")
+		}
+		return true
+	}
+	if policy.GetSpec().GetMutateExistingInterval() <= 0 {
+		return true
+	}
+	logger.V(2).Info("reconciling mutate existing rules")
+	for _, rule := range policy.GetSpec().Rules {
+		if rule.IsMutateExisting() {
+			pc.createMutateExistingURs(policyKey, policy, rule)
+		}
+	}
+	pc.scheduleMutateExisting(policyKey, policy)
+	return true
+}
+
 func (pc *PolicyController) getPolicy(key string) (kyvernov1.PolicyInterface, error) {
 	namespace, key, isNamespacedPolicy := ParseNamespacedPolicy(key)
 	if !isNamespacedPolicy {
@@ -32,40 +32,16 @@ func (pc *PolicyController) updateUR(policyKey string, policy kyvernov1.PolicyIn
 	generateURs := pc.listGenerateURs(policyKey, nil)
 	updateUR(pc.kyvernoClient, pc.urLister.UpdateRequests(config.KyvernoNamespace()), policyKey, append(mutateURs, generateURs...), pc.log.WithName("updateUR"))
 
+	var triggerCount int
 	for _, rule := range policy.GetSpec().Rules {
-		var ruleType kyvernov1beta1.RequestType
-
 		if rule.IsMutateExisting() {
-			ruleType = kyvernov1beta1.Mutate
-
-			triggers := generateTriggers(pc.client, rule, pc.log)
-			for _, trigger := range triggers {
-				murs := pc.listMutateURs(policyKey, trigger)
-
-				if murs != nil {
-					logger.V(4).Info("UR was created", "rule", rule.Name, "rule type", ruleType, "trigger", trigger.GetNamespace()+trigger.GetName())
-					continue
-				}
-
-				logger.Info("creating new UR for mutate")
-				ur := newUR(policy, trigger, ruleType)
-				skip, err := pc.handleUpdateRequest(ur, trigger, rule, policy)
-				if err != nil {
-					pc.log.Error(err, "failed to create new UR on policy update", "policy", policy.GetName(), "rule", rule.Name, "rule type", ruleType,
-						"target", fmt.Sprintf("%s/%s/%s/%s", trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName()))
-					continue
-				}
-				if skip {
-					continue
-				}
-				pc.log.V(2).Info("successfully created UR on policy update", "policy", policy.GetName(), "rule", rule.Name, "rule type", ruleType,
-					"target", fmt.Sprintf("%s/%s/%s/%s", trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName()))
-			}
+			pc.createMutateExistingURs(policyKey, policy, rule)
 		}
 
-		if policy.GetSpec().IsGenerateExistingOnPolicyUpdate() {
-			ruleType = kyvernov1beta1.Generate
+		if policy.GetSpec().IsGenerateExistingOnPolicyUpdate() && rule.HasGenerate() {
+			ruleType := kyvernov1beta1.Generate
 			triggers := generateTriggers(pc.client, rule, pc.log)
+			triggerCount += len(triggers)
 			for _, trigger := range triggers {
 				gurs := pc.listGenerateURs(policyKey, trigger)
 
@@ -75,6 +51,7 @@ func (pc *PolicyController) updateUR(policyKey string, policy kyvernov1.PolicyIn
 				}
 
 				ur := newUR(policy, trigger, ruleType)
+				ur.Labels[kyvernov1beta1.URGenerateBackfillLabel] = "true"
 				skip, err := pc.handleUpdateRequest(ur, trigger, rule, policy)
 				if err != nil {
 					pc.log.Error(err, "failed to create new UR on policy update", "policy", policy.GetName(), "rule", rule.Name, "rule type", ruleType,
@@ -90,13 +67,43 @@ func (pc *PolicyController) updateUR(policyKey string, policy kyvernov1.PolicyIn
 				pc.log.V(4).Info("successfully created UR on policy update", "policy", policy.GetName(), "rule", rule.Name, "rule type", ruleType,
 					"target", fmt.Sprintf("%s/%s/%s/%s", trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName()))
 			}
-
-			err := multierr.Combine(errors...)
-			return err
 		}
 	}
 
-	return nil
+	if policy.GetSpec().IsGenerateExistingOnPolicyUpdate() {
+		pc.startBackfillStatus(policyKey, policy, triggerCount)
+	}
+
+	return multierr.Combine(errors...)
+}
+
+// createMutateExistingURs creates a mutate UR for every trigger of the rule that does not have one yet
+func (pc *PolicyController) createMutateExistingURs(policyKey string, policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) {
+	logger := pc.log.WithName("createMutateExistingURs").WithName(policyKey)
+	ruleType := kyvernov1beta1.Mutate
+	triggers := generateTriggers(pc.client, rule, pc.log)
+	for _, trigger := range triggers {
+		murs := pc.listMutateURs(policyKey, trigger)
+
+		if murs != nil {
+			logger.V(4).Info("UR was created", "rule", rule.Name, "rule type", ruleType, "trigger", trigger.GetNamespace()+trigger.GetName())
+			continue
+		}
+
+		logger.Info("creating new UR for mutate")
+		ur := newUR(policy, trigger, ruleType)
+		skip, err := pc.handleUpdateRequest(ur, trigger, rule, policy)
+		if err != nil {
+			pc.log.Error(err, "failed to create new UR on policy update", "policy", policy.GetName(), "rule", rule.Name, "rule type", ruleType,
+				"target", fmt.Sprintf("%s/%s/%s/%s", trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName()))
+			continue
+		}
+		if skip {
+			continue
+		}
+		pc.log.V(2).Info("successfully created UR on policy update", "policy", policy.GetName(), "rule", rule.Name, "rule type", ruleType,
+			"target", fmt.Sprintf("%s/%s/%s/%s", trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName()))
+	}
 }
 
 func (pc *PolicyController) handleUpdateRequest(ur *kyvernov1beta1.UpdateRequest, triggerResource *unstructured.Unstructured, rule kyvernov1.Rule, policy kyvernov1.PolicyInterface) (skip bool, err error) {
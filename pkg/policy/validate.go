@@ -16,6 +16,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	backgroundcommon "github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
@@ -361,23 +362,30 @@ func Validate(policy kyvernov1.PolicyInterface, client dclient.Interface, mock b
 			logging.V(4).Info("updated source", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
 		}
 		if !mock && len(rule.Generation.CloneList.Kinds) != 0 {
+			namespaces, err := backgroundcommon.CloneListNamespaces(client, rule.Generation.CloneList)
+			if err != nil {
+				logging.Error(err, "failed to list source namespaces")
+				continue
+			}
 			for _, kind := range rule.Generation.CloneList.Kinds {
 				apiVersion, kind := kubeutils.GetKindFromGVK(kind)
-				resources, err := client.ListResource(apiVersion, kind, rule.Generation.CloneList.Namespace, rule.Generation.CloneList.Selector)
-				if err != nil {
-					logging.Error(err, fmt.Sprintf("failed to list resources %s/%s.", kind, rule.Generation.CloneList.Namespace))
-					continue
-				}
-				for _, rName := range resources.Items {
-					obj, err := client.GetResource(apiVersion, kind, rule.Generation.CloneList.Namespace, rName.GetName())
+				for _, namespace := range namespaces {
+					resources, err := client.ListResource(apiVersion, kind, namespace, rule.Generation.CloneList.Selector)
 					if err != nil {
-						logging.Error(err, fmt.Sprintf("source resource %s/%s/%s not found.", kind, rule.Generation.Clone.Namespace, rule.Generation.Clone.Name))
+						logging.Error(err, fmt.Sprintf("failed to list resources %s/%s.", kind, namespace))
 						continue
 					}
-					err = UpdateSourceResource(client, kind, rule.Generation.CloneList.Namespace, policy.GetName(), obj)
-					if err != nil {
-						logging.Error(err, "failed to update source", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
-						continue
+					for _, rName := range resources.Items {
+						obj, err := client.GetResource(apiVersion, kind, namespace, rName.GetName())
+						if err != nil {
+							logging.Error(err, fmt.Sprintf("source resource %s/%s/%s not found.", kind, namespace, rName.GetName()))
+							continue
+						}
+						err = UpdateSourceResource(client, kind, namespace, policy.GetName(), obj)
+						if err != nil {
+							logging.Error(err, "failed to update source", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+							continue
+						}
 					}
 				}
 			}
@@ -440,6 +448,9 @@ func ValidateVariables(p kyvernov1.PolicyInterface, backgroundMode bool) error {
 	return nil
 }
 
+// cloneListSourceVariables are the variables of the source resource available in generate.cloneList.name
+var cloneListSourceVariables = []string{"source.name", "source.namespace", "source.kind"}
+
 // hasInvalidVariables - checks for unexpected variables in the policy
 func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) error {
 	for _, r := range autogen.ComputeRules(policy) {
@@ -456,10 +467,23 @@ func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) erro
 			}
 		}
 
+		// generate.cloneList.name is checked separately, it can also reference the source resources
+		cloneListName := ruleCopy.Generation.CloneList.Name
+		ruleCopy.Generation.CloneList.Name = ""
+
 		ctx := buildContext(ruleCopy, background)
 		if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *ruleCopy); !checkNotFoundErr(err) {
 			return fmt.Errorf("variable substitution failed for rule %s: %s", ruleCopy.Name, err.Error())
 		}
+
+		if cloneListName != "" {
+			for _, variable := range cloneListSourceVariables {
+				ctx.AddVariable(variable)
+			}
+			if _, err := variables.SubstituteAll(logging.GlobalLogger(), ctx, cloneListName); !checkNotFoundErr(err) {
+				return fmt.Errorf("variable substitution failed for rule %s in generate.cloneList.name: %s", ruleCopy.Name, err.Error())
+			}
+		}
 	}
 
 	return nil
@@ -583,6 +607,10 @@ func buildContext(rule *kyvernov1.Rule, background bool) *enginecontext.MockCont
 		addContextVariables(fe.Context, ctx)
 	}
 
+	for _, fe := range rule.Generation.ForEachGeneration {
+		addContextVariables(fe.Context, ctx)
+	}
+
 	return ctx
 }
 
@@ -967,6 +995,15 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 			}
 		}
 
+		if entry.CacheTTL != nil {
+			if entry.APICall == nil && entry.ImageRegistry == nil {
+				return fmt.Errorf("cacheTTL is only supported for apiCall and imageRegistry context entries")
+			}
+			if entry.CacheTTL.Duration < 0 {
+				return fmt.Errorf("cacheTTL must not be negative for context entry %s", entry.Name)
+			}
+		}
+
 		var err error
 		if entry.ConfigMap != nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil {
 			err = validateConfigMap(entry)
@@ -1019,6 +1056,21 @@ func validateConfigMap(entry kyvernov1.ContextEntry) error {
 }
 
 func validateAPICall(entry kyvernov1.ContextEntry) error {
+	if entry.APICall.URLPath != "" && entry.APICall.Service != nil {
+		return fmt.Errorf("a single one of urlPath or service is required for apiCall context entry")
+	}
+	if entry.APICall.URLPath == "" && entry.APICall.Service == nil {
+		return fmt.Errorf("one of urlPath or service is required for apiCall context entry")
+	}
+	if entry.APICall.Service != nil {
+		if entry.APICall.Service.URL == "" {
+			return fmt.Errorf("a url is required for apiCall service context entry")
+		}
+		if len(entry.APICall.Service.Data) > 0 && entry.APICall.Service.GetMethod() != kyvernov1.MethodPost {
+			return fmt.Errorf("data is only supported with the %s method for apiCall service context entry", kyvernov1.MethodPost)
+		}
+	}
+
 	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
 	// will be inserted by the variable
 	// Skip validation if a variable is detected
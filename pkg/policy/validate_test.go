@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -1024,6 +1025,53 @@ func Test_Validate_ApiCall(t *testing.T) {
 			},
 			expectedResult: nil,
 		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					Service: &kyverno.ServiceCall{
+						URL:    "https://inventory.tools:8443/approved",
+						Method: kyverno.MethodPost,
+						Data: []kyverno.RequestData{{
+							Key:   "image",
+							Value: &apiextv1.JSON{Raw: []byte(`"{{images.containers.*.name}}"`)},
+						}},
+					},
+					JMESPath: "approved",
+				},
+			},
+			expectedResult: nil,
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					Service: &kyverno.ServiceCall{
+						URL: "https://inventory.tools:8443/approved",
+						Data: []kyverno.RequestData{{
+							Key:   "image",
+							Value: &apiextv1.JSON{Raw: []byte(`"nginx"`)},
+						}},
+					},
+				},
+			},
+			expectedResult: "data is only supported with the POST method for apiCall service context entry",
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					URLPath: "/api/v1/namespaces",
+					Service: &kyverno.ServiceCall{
+						URL: "https://inventory.tools:8443/approved",
+					},
+				},
+			},
+			expectedResult: "a single one of urlPath or service is required for apiCall context entry",
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{},
+			},
+			expectedResult: "one of urlPath or service is required for apiCall context entry",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1037,6 +1085,56 @@ func Test_Validate_ApiCall(t *testing.T) {
 	}
 }
 
+func Test_Validate_RuleContext_CacheTTL(t *testing.T) {
+	testCases := []struct {
+		entry          kyverno.ContextEntry
+		expectedResult interface{}
+	}{
+		{
+			entry: kyverno.ContextEntry{
+				Name:     "namespaces",
+				APICall:  &kyverno.APICall{URLPath: "/api/v1/namespaces"},
+				CacheTTL: &metav1.Duration{Duration: time.Minute},
+			},
+			expectedResult: nil,
+		},
+		{
+			entry: kyverno.ContextEntry{
+				Name:          "imageData",
+				ImageRegistry: &kyverno.ImageRegistry{Reference: "ghcr.io/kyverno/kyverno"},
+				CacheTTL:      &metav1.Duration{Duration: time.Minute},
+			},
+			expectedResult: nil,
+		},
+		{
+			entry: kyverno.ContextEntry{
+				Name:      "dictionary",
+				ConfigMap: &kyverno.ConfigMapReference{Name: "dictionary", Namespace: "default"},
+				CacheTTL:  &metav1.Duration{Duration: time.Minute},
+			},
+			expectedResult: "cacheTTL is only supported for apiCall and imageRegistry context entries",
+		},
+		{
+			entry: kyverno.ContextEntry{
+				Name:     "namespaces",
+				APICall:  &kyverno.APICall{URLPath: "/api/v1/namespaces"},
+				CacheTTL: &metav1.Duration{Duration: -time.Minute},
+			},
+			expectedResult: "cacheTTL must not be negative for context entry namespaces",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := validateRuleContext(kyverno.Rule{Context: []kyverno.ContextEntry{testCase.entry}})
+
+		if err == nil {
+			assert.Equal(t, err, testCase.expectedResult)
+		} else {
+			assert.Equal(t, err.Error(), testCase.expectedResult)
+		}
+	}
+}
+
 func Test_Wildcards_Kind(t *testing.T) {
 	rawPolicy := []byte(`
 	{
@@ -33,7 +33,7 @@ type Client interface {
 
 	// FetchImageDescriptor fetches Descriptor from registry with given imageRef
 	// and provides access to metadata about remote artifact.
-	FetchImageDescriptor(imageRef string) (*gcrremote.Descriptor, error)
+	FetchImageDescriptor(ctx context.Context, imageRef string) (*gcrremote.Descriptor, error)
 
 	// UseLocalKeychain updates keychain with the default local keychain.
 	UseLocalKeychain()
@@ -126,13 +126,13 @@ func (c *client) UseLocalKeychain() {
 
 // FetchImageDescriptor fetches Descriptor from registry with given imageRef
 // and provides access to metadata about remote artifact.
-func (c *client) FetchImageDescriptor(imageRef string) (*gcrremote.Descriptor, error) {
+func (c *client) FetchImageDescriptor(ctx context.Context, imageRef string) (*gcrremote.Descriptor, error) {
 	parsedRef, err := name.ParseReference(imageRef)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse image reference: %s, error: %v", imageRef, err)
 	}
 
-	desc, err := gcrremote.Get(parsedRef, gcrremote.WithAuthFromKeychain(c.keychain))
+	desc, err := gcrremote.Get(parsedRef, gcrremote.WithAuthFromKeychain(c.keychain), gcrremote.WithContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch image reference: %s, error: %v", imageRef, err)
 	}
@@ -0,0 +1,36 @@
+package admission
+
+import (
+	"context"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+type policyGroupKey struct{}
+
+// PolicyWebhookGroup returns the webhook group of the policy when resource webhooks are configured per policy,
+// policies with the webhook group label share the webhook of their group, other policies have their own webhook
+func PolicyWebhookGroup(policy kyvernov1.PolicyInterface) string {
+	if group := policy.GetLabels()[kyvernov1.LabelWebhookGroup]; group != "" {
+		return "group-" + group
+	}
+	if policy.IsNamespaced() {
+		// namespaces can't contain dots, the key is not ambiguous
+		return "pol-" + policy.GetNamespace() + "." + policy.GetName()
+	}
+	return "cpol-" + policy.GetName()
+}
+
+// WithPolicyGroup returns a context restricting the admission request to the policies of the webhook group
+func WithPolicyGroup(ctx context.Context, group string) context.Context {
+	return context.WithValue(ctx, policyGroupKey{}, group)
+}
+
+// PolicyGroup returns the webhook group of the admission request, it is empty for the requests
+// received by the default resource webhooks
+func PolicyGroup(ctx context.Context) string {
+	if group, ok := ctx.Value(policyGroupKey{}).(string); ok {
+		return group
+	}
+	return ""
+}
@@ -0,0 +1,22 @@
+package admission
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPolicyWebhookGroup(t *testing.T) {
+	grouped := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels", Labels: map[string]string{kyvernov1.LabelWebhookGroup: "security"}}}
+	assert.Equal(t, PolicyWebhookGroup(grouped), "group-security")
+	assert.Equal(t, PolicyWebhookGroup(&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels"}}), "cpol-require-labels")
+	assert.Equal(t, PolicyWebhookGroup(&kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels", Namespace: "team-a"}}), "pol-team-a.require-labels")
+}
+
+func TestPolicyGroup(t *testing.T) {
+	assert.Equal(t, PolicyGroup(context.TODO()), "")
+	assert.Equal(t, PolicyGroup(WithPolicyGroup(context.TODO(), "group-security")), "group-security")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
 	admissionv1 "k8s.io/api/admission/v1"
 )
 
@@ -40,3 +41,11 @@ func GetPolicies(request *admissionv1.AdmissionRequest) (kyvernov1.PolicyInterfa
 	}
 	return policy, nil, nil
 }
+
+func UnmarshalPolicyException(raw []byte) (*kyvernov1alpha1.PolicyException, error) {
+	var exception *kyvernov1alpha1.PolicyException
+	if err := json.Unmarshal(raw, &exception); err != nil {
+		return nil, err
+	}
+	return exception, nil
+}
@@ -120,6 +120,9 @@ func annotationFromEngineResponses(engineResponses []*response.EngineResponse, l
 func annotationFromPolicyResponse(policyResponse response.PolicyResponse, log logr.Logger) []RulePatch {
 	var RulePatches []RulePatch
 	for _, ruleInfo := range policyResponse.Rules {
+		if ruleInfo.Audit {
+			continue
+		}
 		for _, patch := range ruleInfo.Patches {
 			var patchmap map[string]interface{}
 			if err := json.Unmarshal(patch, &patchmap); err != nil {
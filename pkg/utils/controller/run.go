@@ -92,6 +92,8 @@ func reconcile(ctx context.Context, logger logr.Logger, obj interface{}, r recon
 	}
 	logger = logger.WithValues("key", k, "namespace", ns, "name", n)
 	logger.V(6).Info("reconciling ...")
-	defer logger.V(6).Info("done", "duration", time.Since(start).String())
+	defer func() {
+		logger.V(6).Info("done", "duration", time.Since(start).String())
+	}()
 	return r(ctx, logger, k, ns, n)
 }
@@ -8,11 +8,15 @@ import (
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/engine/response"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	"golang.org/x/exp/slices"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
+// AuditPatchProperty is the result property holding the patch of a mutation rule in audit mode
+const AuditPatchProperty = "patch"
+
 func SortReportResults(results []policyreportv1alpha2.PolicyReportResult) {
 	slices.SortFunc(results, func(a policyreportv1alpha2.PolicyReportResult, b policyreportv1alpha2.PolicyReportResult) bool {
 		if a.Policy != b.Policy {
@@ -57,7 +61,7 @@ func toPolicyResult(status response.RuleStatus) policyreportv1alpha2.PolicyResul
 		return policyreportv1alpha2.StatusPass
 	case response.RuleStatusFail:
 		return policyreportv1alpha2.StatusFail
-	case response.RuleStatusError:
+	case response.RuleStatusError, response.RuleStatusTimeout:
 		return policyreportv1alpha2.StatusError
 	case response.RuleStatusWarn:
 		return policyreportv1alpha2.StatusWarn
@@ -100,6 +104,11 @@ func EngineResponseToReportResults(response *response.EngineResponse) []policyre
 		if result.Result == "fail" && !result.Scored {
 			result.Result = "warn"
 		}
+		if ruleResult.Audit && len(ruleResult.Patches) > 0 {
+			result.Properties = map[string]string{
+				AuditPatchProperty: string(jsonutils.JoinPatches(ruleResult.Patches...)),
+			}
+		}
 		results = append(results, result)
 	}
 	return results
@@ -0,0 +1,32 @@
+package exception
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type handlers struct{}
+
+func NewHandlers() webhooks.ExceptionHandlers {
+	return &handlers{}
+}
+
+func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, _ time.Time) *admissionv1.AdmissionResponse {
+	exception, err := admissionutils.UnmarshalPolicyException(request.Object.Raw)
+	if err != nil {
+		logger.Error(err, "failed to unmarshal policy exception from admission request")
+		return admissionutils.Response(err)
+	}
+	if errs := exception.Validate(sets.NewString()); len(errs) != 0 {
+		err := errs.ToAggregate()
+		logger.Error(err, "policy exception validation errors")
+		return admissionutils.Response(err)
+	}
+	return admissionutils.ResponseSuccess()
+}
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// AuditEvent is the structured record of an admission decision
+type AuditEvent struct {
+	Timestamp      time.Time                   `json:"timestamp"`
+	UID            types.UID                   `json:"uid"`
+	Operation      string                      `json:"operation"`
+	Kind           metav1.GroupVersionKind     `json:"kind"`
+	Resource       metav1.GroupVersionResource `json:"resource"`
+	SubResource    string                      `json:"subResource,omitempty"`
+	Namespace      string                      `json:"namespace,omitempty"`
+	Name           string                      `json:"name,omitempty"`
+	UserInfo       authenticationv1.UserInfo   `json:"userInfo"`
+	DryRun         bool                        `json:"dryRun,omitempty"`
+	Allowed        bool                        `json:"allowed"`
+	Message        string                      `json:"message,omitempty"`
+	Warnings       []string                    `json:"warnings,omitempty"`
+	Policies       []AuditPolicy               `json:"policies,omitempty"`
+	Patch          []AuditPatch                `json:"patch,omitempty"`
+	LatencySeconds float64                     `json:"latencySeconds"`
+	// Request is the redacted admission request payload, it is only set when configured
+	Request *admissionRequestPayload `json:"request,omitempty"`
+}
+
+// AuditPolicy is the result of a policy evaluated for an admission request
+type AuditPolicy struct {
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Rules     []AuditRule `json:"rules,omitempty"`
+}
+
+// AuditRule is the result of a rule evaluated for an admission request
+type AuditRule struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// AuditPatch is a JSON patch operation applied to the resource of an admission request
+type AuditPatch struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// AuditSink receives the audit events of the admission decisions
+type AuditSink interface {
+	Write(AuditEvent) error
+}
+
+type jsonAuditSink struct {
+	lock   sync.Mutex
+	writer io.Writer
+}
+
+// NewJSONAuditSink creates a sink writing the audit events to the writer as JSON lines
+func NewJSONAuditSink(writer io.Writer) AuditSink {
+	return &jsonAuditSink{writer: writer}
+}
+
+func (s *jsonAuditSink) Write(event AuditEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err = s.writer.Write(append(data, '\n'))
+	return err
+}
+
+type httpAuditSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPAuditSink creates a sink posting each audit event as JSON to the url
+func NewHTTPAuditSink(url string, client *http.Client) AuditSink {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &httpAuditSink{url: url, client: client}
+}
+
+func (s *httpAuditSink) Write(event AuditEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit sink %s returned HTTP %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
+
+type asyncAuditSink struct {
+	events chan AuditEvent
+}
+
+// NewAsyncAuditSink creates a sink queuing the audit events and writing them to the inner sink in the background
+// until the context is done, so that slow sinks don't delay admission responses. Events are dropped with
+// an error when the queue is full.
+func NewAsyncAuditSink(ctx context.Context, logger logr.Logger, inner AuditSink, queueSize int) AuditSink {
+	s := newAsyncAuditSink(queueSize)
+	go s.run(ctx, logger, inner)
+	return s
+}
+
+func newAsyncAuditSink(queueSize int) *asyncAuditSink {
+	return &asyncAuditSink{events: make(chan AuditEvent, queueSize)}
+}
+
+// run writes the queued events to the inner sink until the context is done
+func (s *asyncAuditSink) run(ctx context.Context, logger logr.Logger, inner AuditSink) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-s.events:
+			if err := inner.Write(event); err != nil {
+				logger.Error(err, "failed to write audit event", "uid", event.UID)
+			}
+		}
+	}
+}
+
+func (s *asyncAuditSink) Write(event AuditEvent) error {
+	select {
+	case s.events <- event:
+		return nil
+	default:
+		return errors.New("audit queue is full, event dropped")
+	}
+}
+
+type auditRecorderKey struct{}
+
+// auditRecorder collects the policy results of an admission request
+type auditRecorder struct {
+	lock     sync.Mutex
+	policies []AuditPolicy
+}
+
+// AuditEnabled returns true if the admission decisions are audited, the results of the policies evaluated for
+// the request must then be recorded with RecordAuditResponses before the response is returned
+func AuditEnabled(ctx context.Context) bool {
+	_, ok := ctx.Value(auditRecorderKey{}).(*auditRecorder)
+	return ok
+}
+
+// RecordAuditResponses adds the results of the engine responses to the audit event of the admission request,
+// it does nothing when admission decisions are not audited. The audit event includes the results of the
+// mutate, validate (enforce and audit) and verifyImages policies, generate policies are applied in the
+// background by update requests and are not part of it.
+func RecordAuditResponses(ctx context.Context, responses ...*response.EngineResponse) {
+	recorder, ok := ctx.Value(auditRecorderKey{}).(*auditRecorder)
+	if !ok {
+		return
+	}
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	for _, engineResponse := range responses {
+		if engineResponse == nil {
+			continue
+		}
+		policy := AuditPolicy{
+			Name:      engineResponse.PolicyResponse.Policy.Name,
+			Namespace: engineResponse.PolicyResponse.Policy.Namespace,
+		}
+		for _, rule := range engineResponse.PolicyResponse.Rules {
+			policy.Rules = append(policy.Rules, AuditRule{
+				Name:    rule.Name,
+				Type:    string(rule.Type),
+				Status:  rule.Status.String(),
+				Message: rule.Message,
+			})
+		}
+		recorder.policies = append(recorder.policies, policy)
+	}
+}
+
+func newAuditEvent(request *admissionv1.AdmissionRequest, response *admissionv1.AdmissionResponse, policies []AuditPolicy, startTime time.Time) AuditEvent {
+	event := AuditEvent{
+		Timestamp:      time.Now().UTC(),
+		UID:            request.UID,
+		Operation:      string(request.Operation),
+		Kind:           request.Kind,
+		Resource:       request.Resource,
+		SubResource:    request.SubResource,
+		Namespace:      request.Namespace,
+		Name:           request.Name,
+		UserInfo:       request.UserInfo,
+		DryRun:         request.DryRun != nil && *request.DryRun,
+		Allowed:        response.Allowed,
+		Warnings:       response.Warnings,
+		Policies:       policies,
+		LatencySeconds: time.Since(startTime).Seconds(),
+	}
+	if response.Result != nil {
+		event.Message = response.Result.Message
+	}
+	return event
+}
+
+// redactPatch removes the values of the patch operations applied to secrets
+func redactPatch(kind string, patch []AuditPatch) []AuditPatch {
+	if !strings.EqualFold(kind, "Secret") {
+		return patch
+	}
+	for i := range patch {
+		patch[i].Value = nil
+	}
+	return patch
+}
+
+func (h AdmissionHandler) WithAudit(sink AuditSink, includeRequest bool) AdmissionHandler {
+	if sink == nil {
+		return h
+	}
+	return withAudit(sink, includeRequest, h)
+}
+
+func withAudit(sink AuditSink, includeRequest bool, inner AdmissionHandler) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
+		return tracing.Span1(
+			ctx,
+			"webhooks/handlers",
+			fmt.Sprintf("AUDIT %s %s", request.Operation, request.Kind),
+			func(ctx context.Context, span trace.Span) *admissionv1.AdmissionResponse {
+				recorder := &auditRecorder{}
+				response := inner(context.WithValue(ctx, auditRecorderKey{}, recorder), logger, request, startTime)
+				// requests filtered by the configuration are not processed by Kyverno
+				if response == nil {
+					return response
+				}
+				recorder.lock.Lock()
+				event := newAuditEvent(request, response, recorder.policies, startTime)
+				recorder.lock.Unlock()
+				if len(response.Patch) > 0 {
+					var patch []AuditPatch
+					if err := json.Unmarshal(response.Patch, &patch); err != nil {
+						logger.Error(err, "failed to decode patch for audit event")
+					} else {
+						event.Patch = redactPatch(request.Kind.Kind, patch)
+					}
+				}
+				if includeRequest {
+					payload, err := newAdmissionRequestPayload(request)
+					if err != nil {
+						logger.Error(err, "failed to extract resources for audit event")
+					} else {
+						event.Request = payload
+					}
+				}
+				if err := sink.Write(event); err != nil {
+					logger.Error(err, "failed to write audit event")
+				}
+				return response
+			},
+			trace.WithAttributes(admissionRequestAttributes(request)...),
+		)
+	}
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type auditEvents []AuditEvent
+
+func (e *auditEvents) Write(event AuditEvent) error {
+	*e = append(*e, event)
+	return nil
+}
+
+func newAuditRequest(kind string, object string) *admissionv1.AdmissionRequest {
+	return &admissionv1.AdmissionRequest{
+		UID:       "631a230b-b949-468d-b9ae-927fdd76217e",
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: kind},
+		Namespace: "default",
+		Name:      "test",
+		Operation: admissionv1.Create,
+		UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+		Object:    runtime.RawExtension{Raw: []byte(object)},
+	}
+}
+
+func Test_WithAudit(t *testing.T) {
+	inner := func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
+		assert.Assert(t, AuditEnabled(ctx))
+		RecordAuditResponses(ctx, &response.EngineResponse{
+			PolicyResponse: response.PolicyResponse{
+				Policy: response.PolicySpec{Name: "add-labels"},
+				Rules: []response.RuleResponse{
+					{Name: "add-team", Type: response.Mutation, Status: response.RuleStatusPass, Message: "mutated"},
+				},
+			},
+		})
+		return &admissionv1.AdmissionResponse{
+			Allowed:  true,
+			Patch:    []byte(`[{"op":"add","path":"/data/token","value":"c2VjcmV0"}]`),
+			Warnings: []string{"deprecated"},
+		}
+	}
+	var events auditEvents
+	handler := AdmissionHandler(inner).WithAudit(&events, true)
+	request := newAuditRequest("Secret", `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"test","namespace":"default"},"data":{"password":"c2VjcmV0"}}`)
+	response := handler(context.TODO(), logging.GlobalLogger(), request, time.Now())
+	assert.Assert(t, response.Allowed)
+	assert.Equal(t, len(events), 1)
+	event := events[0]
+	assert.Equal(t, event.UID, request.UID)
+	assert.Equal(t, event.UserInfo.Username, "alice")
+	assert.Assert(t, event.Allowed)
+	assert.DeepEqual(t, event.Warnings, []string{"deprecated"})
+	assert.DeepEqual(t, event.Policies, []AuditPolicy{{
+		Name:  "add-labels",
+		Rules: []AuditRule{{Name: "add-team", Type: "Mutation", Status: "pass", Message: "mutated"}},
+	}})
+	// secret values are redacted
+	assert.DeepEqual(t, event.Patch, []AuditPatch{{Op: "add", Path: "/data/token"}})
+	assert.Assert(t, event.Request != nil)
+	data, err := json.Marshal(event.Request.Object.Object["data"])
+	assert.NilError(t, err)
+	assert.Assert(t, !bytes.Contains(data, []byte("c2VjcmV0")))
+}
+
+func Test_WithAudit_Denied(t *testing.T) {
+	inner := func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result:  &metav1.Status{Status: metav1.StatusFailure, Message: "label team is required"},
+		}
+	}
+	var events auditEvents
+	handler := AdmissionHandler(inner).WithAudit(&events, false)
+	handler(context.TODO(), logging.GlobalLogger(), newAuditRequest("Pod", `{"apiVersion":"v1","kind":"Pod"}`), time.Now())
+	assert.Equal(t, len(events), 1)
+	assert.Assert(t, !events[0].Allowed)
+	assert.Equal(t, events[0].Message, "label team is required")
+	assert.Assert(t, events[0].Request == nil)
+}
+
+func Test_WithAudit_Filtered(t *testing.T) {
+	inner := func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
+		return nil
+	}
+	var events auditEvents
+	handler := AdmissionHandler(inner).WithAudit(&events, false)
+	handler(context.TODO(), logging.GlobalLogger(), newAuditRequest("Pod", `{"apiVersion":"v1","kind":"Pod"}`), time.Now())
+	assert.Equal(t, len(events), 0)
+}
+
+func Test_AuditEnabled(t *testing.T) {
+	assert.Assert(t, !AuditEnabled(context.TODO()))
+	// recording without an audit event is a no-op
+	RecordAuditResponses(context.TODO(), &response.EngineResponse{})
+}
+
+func Test_JSONAuditSink(t *testing.T) {
+	var buffer bytes.Buffer
+	sink := NewJSONAuditSink(&buffer)
+	assert.NilError(t, sink.Write(AuditEvent{UID: "1", Allowed: true}))
+	assert.NilError(t, sink.Write(AuditEvent{UID: "2"}))
+	lines := bytes.Split(bytes.TrimSpace(buffer.Bytes()), []byte("\n"))
+	assert.Equal(t, len(lines), 2)
+	var event AuditEvent
+	assert.NilError(t, json.Unmarshal(lines[1], &event))
+	assert.Equal(t, string(event.UID), "2")
+}
+
+func Test_HTTPAuditSink(t *testing.T) {
+	received := make(chan AuditEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPost)
+		assert.Equal(t, r.Header.Get("Content-Type"), "application/json")
+		var event AuditEvent
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&event))
+		if event.UID == "rejected" {
+			http.Error(w, "rejected", http.StatusBadRequest)
+			return
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	sink := NewHTTPAuditSink(server.URL, nil)
+	assert.NilError(t, sink.Write(AuditEvent{UID: "1", Policies: []AuditPolicy{{Name: "require-labels"}}}))
+	event := <-received
+	assert.Equal(t, string(event.UID), "1")
+	assert.Equal(t, event.Policies[0].Name, "require-labels")
+	assert.ErrorContains(t, sink.Write(AuditEvent{UID: "rejected"}), "returned HTTP 400")
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	async := NewAsyncAuditSink(ctx, logging.GlobalLogger(), sink, 10)
+	assert.NilError(t, async.Write(AuditEvent{UID: "2"}))
+	select {
+	case event := <-received:
+		assert.Equal(t, string(event.UID), "2")
+	case <-time.After(5 * time.Second):
+		t.Fatal("audit event not received")
+	}
+}
+
+func Test_AsyncAuditSink_Full(t *testing.T) {
+	// no worker consumes the queue
+	async := newAsyncAuditSink(1)
+	assert.NilError(t, async.Write(AuditEvent{UID: "1"}))
+	assert.ErrorContains(t, async.Write(AuditEvent{UID: "2"}), "audit queue is full")
+}
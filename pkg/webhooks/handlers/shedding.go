@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"go.opentelemetry.io/otel/trace"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// SheddingMode is the response given to the admission requests shed when the server is saturated
+type SheddingMode string
+
+const (
+	// SheddingFailurePolicy denies the requests of the webhooks failing closed and allows the requests of the webhooks failing open
+	SheddingFailurePolicy SheddingMode = "failurePolicy"
+	// SheddingFail denies the requests
+	SheddingFail SheddingMode = "fail"
+	// SheddingAllow allows the requests
+	SheddingAllow SheddingMode = "allow"
+)
+
+// ParseSheddingMode returns the shedding mode with the given name
+func ParseSheddingMode(mode string) (SheddingMode, error) {
+	switch SheddingMode(mode) {
+	case SheddingFailurePolicy, SheddingFail, SheddingAllow:
+		return SheddingMode(mode), nil
+	}
+	return "", fmt.Errorf("invalid shedding mode %s, must be one of %s, %s or %s", mode, SheddingFailurePolicy, SheddingFail, SheddingAllow)
+}
+
+const (
+	// maxBuckets is the maximum number of token buckets, the least recently used ones are evicted first
+	maxBuckets = 10000
+	// bucketTTL is how long the token bucket of a kind and namespace is kept without requests
+	bucketTTL = 10 * time.Minute
+)
+
+// Limiter bounds the number of admission requests processed concurrently and the rate of the
+// admission requests per kind and namespace, the requests above the limits are shed
+type Limiter struct {
+	inFlight chan struct{}
+	qps      float32
+	burst    int
+	mode     SheddingMode
+
+	lock    sync.Mutex
+	buckets *cache.LRUExpireCache
+}
+
+// NewLimiter creates a limiter allowing maxInFlight concurrent requests and qps requests per second,
+// with bursts of burst requests, per kind and namespace. Zero values disable the corresponding limit.
+func NewLimiter(maxInFlight int, qps float64, burst int, mode SheddingMode) *Limiter {
+	l := &Limiter{
+		qps:     float32(qps),
+		burst:   burst,
+		mode:    mode,
+		buckets: cache.NewLRUExpireCache(maxBuckets),
+	}
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+	if l.burst < 1 {
+		l.burst = 1
+	}
+	return l
+}
+
+// Enabled returns true if at least one limit is configured
+func (l *Limiter) Enabled() bool {
+	return l != nil && (l.inFlight != nil || l.qps > 0)
+}
+
+// acquire returns a func releasing the request once processed, or the reason the request is shed
+func (l *Limiter) acquire(request *admissionv1.AdmissionRequest) (func(), metrics.SheddingReason) {
+	if l.qps > 0 && !l.bucket(request).TryAccept() {
+		return nil, metrics.SheddingRateLimit
+	}
+	if l.inFlight == nil {
+		return func() {}, ""
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, ""
+	default:
+		return nil, metrics.SheddingInFlight
+	}
+}
+
+// bucket returns the token bucket of the kind and namespace of the request
+func (l *Limiter) bucket(request *admissionv1.AdmissionRequest) flowcontrol.RateLimiter {
+	key := request.Kind.String() + "/" + request.Namespace
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if bucket, ok := l.buckets.Get(key); ok {
+		// refresh the expiration of the bucket
+		l.buckets.Add(key, bucket, bucketTTL)
+		return bucket.(flowcontrol.RateLimiter)
+	}
+	bucket := flowcontrol.NewTokenBucketRateLimiter(l.qps, l.burst)
+	l.buckets.Add(key, bucket, bucketTTL)
+	return bucket
+}
+
+// allowShed returns true if the requests shed for a webhook with the given failure policy are allowed
+func (l *Limiter) allowShed(failurePolicy string) bool {
+	switch l.mode {
+	case SheddingAllow:
+		return true
+	case SheddingFail:
+		return false
+	}
+	return failurePolicy == "ignore"
+}
+
+func (h AdmissionHandler) WithLoadShedding(limiter *Limiter, failurePolicy string, metricsConfig *metrics.MetricsConfig) AdmissionHandler {
+	if !limiter.Enabled() {
+		return h
+	}
+	return withLoadShedding(limiter, failurePolicy, metricsConfig, h)
+}
+
+func withLoadShedding(limiter *Limiter, failurePolicy string, metricsConfig *metrics.MetricsConfig, inner AdmissionHandler) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
+		return tracing.Span1(
+			ctx,
+			"webhooks/handlers",
+			fmt.Sprintf("SHEDDING %s %s", request.Operation, request.Kind),
+			func(ctx context.Context, span trace.Span) *admissionv1.AdmissionResponse {
+				release, reason := limiter.acquire(request)
+				if release != nil {
+					defer release()
+					return inner(ctx, logger, request, startTime)
+				}
+				allowed := limiter.allowShed(failurePolicy)
+				logger.V(2).Info("admission request shed", "reason", reason, "allowed", allowed)
+				if metricsConfig != nil {
+					metricsConfig.RecordAdmissionRequestsShed(request.Kind.Kind, request.Namespace, reason, allowed)
+				}
+				if allowed {
+					return admissionutils.ResponseSuccess("Kyverno is overloaded, the request was admitted without applying policies")
+				}
+				return admissionutils.Response(errors.New("Kyverno is overloaded, the request was rejected, retry later"))
+			},
+			trace.WithAttributes(admissionRequestAttributes(request)...),
+		)
+	}
+}
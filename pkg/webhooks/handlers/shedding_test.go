@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newShedRequest(kind, namespace string) *admissionv1.AdmissionRequest {
+	return &admissionv1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: kind},
+		Namespace: namespace,
+		Operation: admissionv1.Create,
+	}
+}
+
+func Test_Limiter_InFlight(t *testing.T) {
+	limiter := NewLimiter(1, 0, 0, SheddingFailurePolicy)
+	assert.Assert(t, limiter.Enabled())
+	release, reason := limiter.acquire(newShedRequest("Pod", "default"))
+	assert.Assert(t, release != nil)
+	assert.Equal(t, reason, metrics.SheddingReason(""))
+	_, reason = limiter.acquire(newShedRequest("Pod", "default"))
+	assert.Equal(t, reason, metrics.SheddingInFlight)
+	release()
+	release, _ = limiter.acquire(newShedRequest("Pod", "default"))
+	assert.Assert(t, release != nil)
+}
+
+func Test_Limiter_RateLimit(t *testing.T) {
+	limiter := NewLimiter(0, 0.001, 2, SheddingFailurePolicy)
+	for i := 0; i < 2; i++ {
+		release, _ := limiter.acquire(newShedRequest("Pod", "default"))
+		assert.Assert(t, release != nil)
+	}
+	_, reason := limiter.acquire(newShedRequest("Pod", "default"))
+	assert.Equal(t, reason, metrics.SheddingRateLimit)
+	// kinds and namespaces have their own bucket
+	release, _ := limiter.acquire(newShedRequest("Pod", "team-a"))
+	assert.Assert(t, release != nil)
+	release, _ = limiter.acquire(newShedRequest("ConfigMap", "default"))
+	assert.Assert(t, release != nil)
+}
+
+func Test_Limiter_Disabled(t *testing.T) {
+	var limiter *Limiter
+	assert.Assert(t, !limiter.Enabled())
+	assert.Assert(t, !NewLimiter(0, 0, 0, SheddingFailurePolicy).Enabled())
+}
+
+func Test_WithLoadShedding(t *testing.T) {
+	inner := func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	testCases := []struct {
+		mode          SheddingMode
+		failurePolicy string
+		allowed       bool
+	}{
+		{SheddingFailurePolicy, "fail", false},
+		{SheddingFailurePolicy, "ignore", true},
+		{SheddingFailurePolicy, "all", false},
+		{SheddingFail, "ignore", false},
+		{SheddingAllow, "fail", true},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.mode)+"/"+tc.failurePolicy, func(t *testing.T) {
+			limiter := NewLimiter(0, 0.001, 1, tc.mode)
+			handler := AdmissionHandler(inner).WithLoadShedding(limiter, tc.failurePolicy, nil)
+			request := newShedRequest("Pod", "default")
+			response := handler(context.TODO(), logging.GlobalLogger(), request, time.Now())
+			assert.Assert(t, response.Allowed)
+			response = handler(context.TODO(), logging.GlobalLogger(), request, time.Now())
+			assert.Equal(t, response.Allowed, tc.allowed)
+		})
+	}
+}
+
+func Test_WithLoadShedding_Filter(t *testing.T) {
+	client := kubefake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.KyvernoNamespace(), Name: config.KyvernoConfigMapName()},
+		Data:       map[string]string{"resourceFilters": "[Event,*,*]"},
+	})
+	configuration, err := config.NewConfiguration(client)
+	assert.NilError(t, err)
+	inner := func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	limiter := NewLimiter(0, 0.001, 1, SheddingFail)
+	// the filter is applied before the limiter, as registered by the webhook server
+	handler := AdmissionHandler(inner).WithLoadShedding(limiter, "fail", nil).WithFilter(configuration)
+	for i := 0; i < 3; i++ {
+		response := handler(context.TODO(), logging.GlobalLogger(), newShedRequest("Event", "default"), time.Now())
+		assert.Assert(t, response == nil)
+	}
+	// filtered requests didn't consume the token
+	response := handler(context.TODO(), logging.GlobalLogger(), newShedRequest("Pod", "default"), time.Now())
+	assert.Assert(t, response.Allowed)
+	response = handler(context.TODO(), logging.GlobalLogger(), newShedRequest("Pod", "default"), time.Now())
+	assert.Assert(t, !response.Allowed)
+}
+
+func Test_ParseSheddingMode(t *testing.T) {
+	mode, err := ParseSheddingMode("allow")
+	assert.NilError(t, err)
+	assert.Equal(t, mode, SheddingAllow)
+	_, err = ParseSheddingMode("drop")
+	assert.ErrorContains(t, err, "invalid shedding mode")
+}
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"time"
 
 	fakekyvernov1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
 	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
@@ -34,6 +35,7 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhook
 	rbLister := informers.Rbac().V1().RoleBindings().Lister()
 	crbLister := informers.Rbac().V1().ClusterRoleBindings().Lister()
 	urLister := kyvernoInformers.Kyverno().V1beta1().UpdateRequests().Lister().UpdateRequests(config.KyvernoNamespace())
+	polexLister := kyvernoInformers.Kyverno().V1alpha1().PolicyExceptions().Lister()
 
 	return &handlers{
 		client:         dclient,
@@ -44,10 +46,12 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhook
 		rbLister:       rbLister,
 		crbLister:      crbLister,
 		urLister:       urLister,
+		polexLister:    polexLister,
 		urGenerator:    updaterequest.NewFake(),
 		eventGen:       event.NewFake(),
 		openApiManager: openapi.NewFake(),
-		pcBuilder:      webhookutils.NewPolicyContextBuilder(configuration, dclient, rbLister, crbLister),
+		pcBuilder:      webhookutils.NewPolicyContextBuilder(configuration, dclient, rbLister, crbLister, polexLister),
 		urUpdater:      webhookutils.NewUpdateRequestUpdater(kyvernoclient, urLister),
+		webhookTimeout: 10 * time.Second,
 	}
 }
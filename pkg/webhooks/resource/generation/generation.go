@@ -10,11 +10,11 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/background/common"
 	gen "github.com/kyverno/kyverno/pkg/background/generate"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
-	"github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/response"
@@ -90,7 +90,7 @@ func (h *generationHandler) Handle(
 			var rules []response.RuleResponse
 			policyContext.Policy = policy
 			if request.Kind.Kind != "Namespace" && request.Namespace != "" {
-				policyContext.NamespaceLabels = common.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, h.log)
+				policyContext.NamespaceLabels = webhookutils.GetNamespaceLabels(policyContext, request, h.nsLister, h.log)
 			}
 			engineResponse := engine.ApplyBackgroundChecks(policyContext)
 			for _, rule := range engineResponse.PolicyResponse.Rules {
@@ -198,6 +198,15 @@ func (h *generationHandler) handleUpdateGenerateTargetResource(request *admissio
 	}
 
 	for _, rule := range autogen.ComputeRules(policy) {
+		// the element of a foreach target is not known here, any change outside of the
+		// metadata of the target synchronizes it again
+		if len(rule.Generation.ForEachGeneration) != 0 {
+			if resLabels[common.ForEachRuleLabel] == rule.Name && foreachTargetChanged(request, h.log) {
+				enqueueBool = true
+				break
+			}
+			continue
+		}
 		if rule.Generation.Kind == targetSourceKind && rule.Generation.Name == targetSourceName {
 			updatedRule, err := getGeneratedByResource(newRes, resLabels, h.client, rule, h.log)
 			if err != nil {
@@ -6,6 +6,8 @@ import (
 
 	"github.com/go-logr/logr"
 	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func Test_updateFeildsInSourceAndUpdatedResource(t *testing.T) {
@@ -273,3 +275,31 @@ func Test_updateFeildsInSourceAndUpdatedResource(t *testing.T) {
 	}
 
 }
+
+func Test_foreachTargetChanged(t *testing.T) {
+	testcases := []struct {
+		name      string
+		oldObject string
+		object    string
+		want      bool
+	}{{
+		name:      "data-changed",
+		oldObject: `{"kind":"ConfigMap","metadata":{"name":"nginx-config"},"data":{"app":"nginx"}}`,
+		object:    `{"kind":"ConfigMap","metadata":{"name":"nginx-config"},"data":{"app":"redis"}}`,
+		want:      true,
+	}, {
+		name:      "metadata-changed",
+		oldObject: `{"kind":"ConfigMap","metadata":{"name":"nginx-config"},"data":{"app":"nginx"}}`,
+		object:    `{"kind":"ConfigMap","metadata":{"name":"nginx-config","labels":{"team":"a"}},"data":{"app":"nginx"}}`,
+		want:      false,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := &admissionv1.AdmissionRequest{
+				OldObject: runtime.RawExtension{Raw: []byte(tc.oldObject)},
+				Object:    runtime.RawExtension{Raw: []byte(tc.object)},
+			}
+			assert.Equal(t, foreachTargetChanged(request, logr.Discard()), tc.want)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package generation
 
 import (
 	"encoding/json"
+	"reflect"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -109,6 +110,25 @@ func stripNonPolicyFields(obj, newRes map[string]interface{}, logger logr.Logger
 	return obj, newRes
 }
 
+// foreachTargetChanged checks if an update of a target generated by a foreach rule changed the resource
+// outside of its metadata and status
+func foreachTargetChanged(request *admissionv1.AdmissionRequest, logger logr.Logger) bool {
+	var oldObj, newObj map[string]interface{}
+	if err := json.Unmarshal(request.OldObject.Raw, &oldObj); err != nil {
+		logger.Error(err, "failed to unmarshal old object")
+		return false
+	}
+	if err := json.Unmarshal(request.Object.Raw, &newObj); err != nil {
+		logger.Error(err, "failed to unmarshal object")
+		return false
+	}
+	for _, field := range []string{"metadata", "status"} {
+		delete(oldObj, field)
+		delete(newObj, field)
+	}
+	return !reflect.DeepEqual(oldObj, newObj)
+}
+
 type updateRequestResponse struct {
 	ur  kyvernov1beta1.UpdateRequestSpec
 	err error
@@ -9,10 +9,11 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1alpha1"
 	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
-	"github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
 	enginectx "github.com/kyverno/kyverno/pkg/engine/context"
 	engineutils2 "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/event"
@@ -46,10 +47,11 @@ type handlers struct {
 	pCache policycache.Cache
 
 	// listers
-	nsLister  corev1listers.NamespaceLister
-	rbLister  rbacv1listers.RoleBindingLister
-	crbLister rbacv1listers.ClusterRoleBindingLister
-	urLister  kyvernov1beta1listers.UpdateRequestNamespaceLister
+	nsLister    corev1listers.NamespaceLister
+	rbLister    rbacv1listers.RoleBindingLister
+	crbLister   rbacv1listers.ClusterRoleBindingLister
+	urLister    kyvernov1beta1listers.UpdateRequestNamespaceLister
+	polexLister kyvernov1alpha1listers.PolicyExceptionLister
 
 	urGenerator    webhookgenerate.Generator
 	eventGen       event.Interface
@@ -58,6 +60,7 @@ type handlers struct {
 	urUpdater      webhookutils.UpdateRequestUpdater
 
 	admissionReports bool
+	webhookTimeout   time.Duration
 }
 
 func NewHandlers(
@@ -70,10 +73,12 @@ func NewHandlers(
 	rbLister rbacv1listers.RoleBindingLister,
 	crbLister rbacv1listers.ClusterRoleBindingLister,
 	urLister kyvernov1beta1listers.UpdateRequestNamespaceLister,
+	polexLister kyvernov1alpha1listers.PolicyExceptionLister,
 	urGenerator webhookgenerate.Generator,
 	eventGen event.Interface,
 	openApiManager openapi.ValidateInterface,
 	admissionReports bool,
+	webhookTimeout time.Duration,
 ) webhooks.ResourceHandlers {
 	return &handlers{
 		client:           client,
@@ -85,12 +90,14 @@ func NewHandlers(
 		rbLister:         rbLister,
 		crbLister:        crbLister,
 		urLister:         urLister,
+		polexLister:      polexLister,
 		urGenerator:      urGenerator,
 		eventGen:         eventGen,
 		openApiManager:   openApiManager,
-		pcBuilder:        webhookutils.NewPolicyContextBuilder(configuration, client, rbLister, crbLister),
+		pcBuilder:        webhookutils.NewPolicyContextBuilder(configuration, client, rbLister, crbLister, polexLister),
 		urUpdater:        webhookutils.NewUpdateRequestUpdater(kyvernoClient, urLister),
 		admissionReports: admissionReports,
+		webhookTimeout:   webhookTimeout,
 	}
 }
 
@@ -103,10 +110,10 @@ func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *ad
 	logger.V(4).Info("received an admission request in validating webhook")
 
 	// timestamp at which this admission request got triggered
-	policies := filterPolicies(failurePolicy, h.pCache.GetPolicies(policycache.ValidateEnforce, kind, request.Namespace)...)
-	mutatePolicies := filterPolicies(failurePolicy, h.pCache.GetPolicies(policycache.Mutate, kind, request.Namespace)...)
-	generatePolicies := filterPolicies(failurePolicy, h.pCache.GetPolicies(policycache.Generate, kind, request.Namespace)...)
-	imageVerifyValidatePolicies := filterPolicies(failurePolicy, h.pCache.GetPolicies(policycache.VerifyImagesValidate, kind, request.Namespace)...)
+	policies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.ValidateEnforce, kind, request.Namespace)...)
+	mutatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Mutate, kind, request.Namespace)...)
+	generatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Generate, kind, request.Namespace)...)
+	imageVerifyValidatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.VerifyImagesValidate, kind, request.Namespace)...)
 	policies = append(policies, imageVerifyValidatePolicies...)
 
 	if len(policies) == 0 && len(mutatePolicies) == 0 && len(generatePolicies) == 0 {
@@ -125,14 +132,16 @@ func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *ad
 		return errorResponse(logger, err, "failed create policy context")
 	}
 
-	namespaceLabels := make(map[string]string)
-	if request.Kind.Kind != "Namespace" && request.Namespace != "" {
-		namespaceLabels = common.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
-	}
+	namespaceLabels := webhookutils.GetNamespaceLabels(policyContext, request, h.nsLister, logger)
 
 	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.pCache, h.pcBuilder, h.eventGen, h.admissionReports)
 
-	ok, msg, warnings := vh.HandleValidation(h.metricsConfig, request, policies, policyContext, namespaceLabels, startTime)
+	budget := h.executionBudget(ctx, policies...)
+	policyContext.Budget = budget
+	ok, msg, warnings := vh.HandleValidation(ctx, h.metricsConfig, request, policies, policyContext, namespaceLabels, startTime)
+	budget.Release()
+	// generate and mutate existing policies are processed after the response, they are not bound to the webhook call
+	policyContext.Budget = nil
 	if !ok {
 		logger.Info("admission request denied")
 		return admissionutils.Response(errors.New(msg), warnings...)
@@ -154,8 +163,8 @@ func (h *handlers) Mutate(ctx context.Context, logger logr.Logger, request *admi
 	kind := request.Kind.Kind
 	logger = logger.WithValues("kind", kind)
 	logger.V(4).Info("received an admission request in mutating webhook")
-	mutatePolicies := filterPolicies(failurePolicy, h.pCache.GetPolicies(policycache.Mutate, kind, request.Namespace)...)
-	verifyImagesPolicies := filterPolicies(failurePolicy, h.pCache.GetPolicies(policycache.VerifyImagesMutate, kind, request.Namespace)...)
+	mutatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Mutate, kind, request.Namespace)...)
+	verifyImagesPolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.VerifyImagesMutate, kind, request.Namespace)...)
 	if len(mutatePolicies) == 0 && len(verifyImagesPolicies) == 0 {
 		logger.V(4).Info("no policies matched mutate admission request")
 		return admissionutils.ResponseSuccess()
@@ -166,12 +175,15 @@ func (h *handlers) Mutate(ctx context.Context, logger logr.Logger, request *admi
 		logger.Error(err, "failed to build policy context")
 		return admissionutils.Response(err)
 	}
+	budget := h.executionBudget(ctx, append(mutatePolicies, verifyImagesPolicies...)...)
+	defer budget.Release()
+	policyContext.Budget = budget
 	// update container images to a canonical form
 	if err := enginectx.MutateResourceWithImageInfo(request.Object.Raw, policyContext.JSONContext); err != nil {
 		logger.Error(err, "failed to patch images info to resource, policies that mutate images may be impacted")
 	}
-	mh := mutation.NewMutationHandler(logger, h.eventGen, h.openApiManager, h.nsLister)
-	mutatePatches, mutateWarnings, err := mh.HandleMutation(h.metricsConfig, request, mutatePolicies, policyContext, startTime)
+	mh := mutation.NewMutationHandler(logger, h.kyvernoClient, h.eventGen, h.openApiManager, h.nsLister, h.admissionReports)
+	mutatePatches, mutateWarnings, err := mh.HandleMutation(ctx, h.metricsConfig, request, mutatePolicies, policyContext, startTime)
 	if err != nil {
 		logger.Error(err, "mutation failed")
 		return admissionutils.Response(err)
@@ -183,8 +195,9 @@ func (h *handlers) Mutate(ctx context.Context, logger logr.Logger, request *admi
 		logger.Error(err, "failed to build policy context")
 		return admissionutils.Response(err)
 	}
+	policyContext.Budget = budget
 	ivh := imageverification.NewImageVerificationHandler(logger, h.kyvernoClient, h.eventGen, h.admissionReports)
-	imagePatches, imageVerifyWarnings, err := ivh.Handle(h.metricsConfig, newRequest, verifyImagesPolicies, policyContext)
+	imagePatches, imageVerifyWarnings, err := ivh.Handle(ctx, h.metricsConfig, newRequest, verifyImagesPolicies, policyContext)
 	if err != nil {
 		logger.Error(err, "image verification failed")
 		return admissionutils.Response(err)
@@ -220,9 +233,27 @@ func (h *handlers) handleDelete(logger logr.Logger, request *admissionv1.Admissi
 	}
 }
 
-func filterPolicies(failurePolicy string, policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+// executionBudget returns the execution budget of the webhook call evaluating the policies, the timeout of the
+// call is the highest webhook timeout of the policies or the default webhook timeout, as configured on the webhook
+func (h *handlers) executionBudget(ctx context.Context, policies ...kyvernov1.PolicyInterface) *engine.ExecutionBudget {
+	timeout := h.webhookTimeout
+	for _, policy := range policies {
+		if seconds := policy.GetSpec().WebhookTimeoutSeconds; seconds != nil && time.Duration(*seconds)*time.Second > timeout {
+			timeout = time.Duration(*seconds) * time.Second
+		}
+	}
+	return engine.NewExecutionBudget(ctx, timeout, len(policies))
+}
+
+// filterPolicies returns the policies with the failure policy of the webhook, requests received by
+// the webhook of a policy group are restricted to the policies of the group
+func filterPolicies(ctx context.Context, failurePolicy string, policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
 	var results []kyvernov1.PolicyInterface
+	group := admissionutils.PolicyGroup(ctx)
 	for _, policy := range policies {
+		if group != "" && admissionutils.PolicyWebhookGroup(policy) != group {
+			continue
+		}
 		if failurePolicy == "fail" {
 			if policy.GetSpec().GetFailurePolicy() == kyvernov1.Fail {
 				results = append(results, policy)
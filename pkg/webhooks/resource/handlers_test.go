@@ -3,12 +3,14 @@ package resource
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
 	log "github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	"gotest.tools/assert"
 	v1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -419,3 +421,50 @@ func makeKey(policy kyverno.PolicyInterface) string {
 
 	return namespace + "/" + name
 }
+
+func Test_Validate_PolicyGroup(t *testing.T) {
+	policyCache := policycache.NewCache()
+	logger := log.WithName("Test_Validate_PolicyGroup")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handlers := NewFakeHandlers(ctx, policyCache)
+
+	var grouped, ungrouped kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(policyCheckLabel), &grouped))
+	grouped.Name = "check-label-app-grouped"
+	grouped.Labels = map[string]string{kyverno.LabelWebhookGroup: "security"}
+	grouped.Spec.ValidationFailureAction = "Enforce"
+	assert.NilError(t, json.Unmarshal([]byte(policyCheckLabel), &ungrouped))
+	ungrouped.Name = "require-app-label"
+	ungrouped.Spec.ValidationFailureAction = "Enforce"
+	policyCache.Set(makeKey(&grouped), &grouped)
+	policyCache.Set(makeKey(&ungrouped), &ungrouped)
+
+	request := &v1.AdmissionRequest{
+		Operation: v1.Create,
+		Kind:      metav1.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+		Resource:  metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "Pod"},
+		Object: runtime.RawExtension{
+			Raw: []byte(pod),
+		},
+	}
+
+	// the webhook of the group only evaluates the policies of the group
+	response := handlers.Validate(admissionutils.WithPolicyGroup(ctx, "group-security"), logger, request, "fail", time.Now())
+	assert.Equal(t, response.Allowed, false)
+	assert.Assert(t, strings.Contains(response.Result.Message, "check-label-app-grouped"), response.Result.Message)
+	assert.Assert(t, !strings.Contains(response.Result.Message, "require-app-label"), response.Result.Message)
+
+	response = handlers.Validate(admissionutils.WithPolicyGroup(ctx, "cpol-require-app-label"), logger, request, "fail", time.Now())
+	assert.Equal(t, response.Allowed, false)
+	assert.Assert(t, strings.Contains(response.Result.Message, "require-app-label"), response.Result.Message)
+	assert.Assert(t, !strings.Contains(response.Result.Message, "check-label-app-grouped"), response.Result.Message)
+
+	// policies of other groups or failure policies are not evaluated
+	response = handlers.Validate(admissionutils.WithPolicyGroup(ctx, "group-other"), logger, request, "fail", time.Now())
+	assert.Equal(t, response.Allowed, true)
+	response = handlers.Validate(admissionutils.WithPolicyGroup(ctx, "group-security"), logger, request, "ignore", time.Now())
+	assert.Equal(t, response.Allowed, true)
+}
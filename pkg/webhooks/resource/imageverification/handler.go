@@ -15,6 +15,7 @@ import (
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,6 +25,7 @@ import (
 
 type ImageVerificationHandler interface {
 	Handle(
+		context.Context,
 		*metrics.MetricsConfig,
 		*admissionv1.AdmissionRequest,
 		[]kyvernov1.PolicyInterface,
@@ -53,12 +55,13 @@ type imageVerificationHandler struct {
 }
 
 func (h *imageVerificationHandler) Handle(
+	ctx context.Context,
 	metricsConfig *metrics.MetricsConfig,
 	request *admissionv1.AdmissionRequest,
 	policies []kyvernov1.PolicyInterface,
 	policyContext *engine.PolicyContext,
 ) ([]byte, []string, error) {
-	ok, message, imagePatches, warnings := h.handleVerifyImages(h.log, request, policyContext, policies)
+	ok, message, imagePatches, warnings := h.handleVerifyImages(ctx, h.log, request, policyContext, policies)
 	if !ok {
 		return nil, nil, errors.New(message)
 	}
@@ -66,7 +69,7 @@ func (h *imageVerificationHandler) Handle(
 	return imagePatches, warnings, nil
 }
 
-func (h *imageVerificationHandler) handleVerifyImages(logger logr.Logger, request *admissionv1.AdmissionRequest, policyContext *engine.PolicyContext, policies []kyvernov1.PolicyInterface) (bool, string, []byte, []string) {
+func (h *imageVerificationHandler) handleVerifyImages(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, policyContext *engine.PolicyContext, policies []kyvernov1.PolicyInterface) (bool, string, []byte, []string) {
 	if len(policies) == 0 {
 		return true, "", nil, nil
 	}
@@ -83,6 +86,7 @@ func (h *imageVerificationHandler) handleVerifyImages(logger logr.Logger, reques
 		verifiedImageData.Merge(ivm)
 	}
 
+	handlers.RecordAuditResponses(ctx, engineResponses...)
 	failurePolicy := policyContext.Policy.GetSpec().GetFailurePolicy()
 	blocked := webhookutils.BlockRequest(engineResponses, failurePolicy, logger)
 	if !isResourceDeleted(policyContext) {
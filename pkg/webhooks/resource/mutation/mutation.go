@@ -1,26 +1,31 @@
 package mutation
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
-	"github.com/kyverno/kyverno/pkg/common"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	"github.com/kyverno/kyverno/pkg/utils"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
@@ -29,6 +34,7 @@ type MutationHandler interface {
 	// If there are no errors in validating rule we apply generation rules
 	// patchedResource is the (resource + patches) after applying mutation rules
 	HandleMutation(
+		context.Context,
 		*metrics.MetricsConfig,
 		*admissionv1.AdmissionRequest,
 		[]kyvernov1.PolicyInterface,
@@ -40,26 +46,33 @@ type MutationHandler interface {
 
 func NewMutationHandler(
 	log logr.Logger,
+	kyvernoClient versioned.Interface,
 	eventGen event.Interface,
 	openApiManager openapi.ValidateInterface,
 	nsLister corev1listers.NamespaceLister,
+	admissionReports bool,
 ) MutationHandler {
 	return &mutationHandler{
-		log:            log,
-		eventGen:       eventGen,
-		openApiManager: openApiManager,
-		nsLister:       nsLister,
+		log:              log,
+		kyvernoClient:    kyvernoClient,
+		eventGen:         eventGen,
+		openApiManager:   openApiManager,
+		nsLister:         nsLister,
+		admissionReports: admissionReports,
 	}
 }
 
 type mutationHandler struct {
-	log            logr.Logger
-	eventGen       event.Interface
-	openApiManager openapi.ValidateInterface
-	nsLister       corev1listers.NamespaceLister
+	log              logr.Logger
+	kyvernoClient    versioned.Interface
+	eventGen         event.Interface
+	openApiManager   openapi.ValidateInterface
+	nsLister         corev1listers.NamespaceLister
+	admissionReports bool
 }
 
 func (h *mutationHandler) HandleMutation(
+	ctx context.Context,
 	metricsConfig *metrics.MetricsConfig,
 	request *admissionv1.AdmissionRequest,
 	policies []kyvernov1.PolicyInterface,
@@ -67,6 +80,7 @@ func (h *mutationHandler) HandleMutation(
 	admissionRequestTimestamp time.Time,
 ) ([]byte, []string, error) {
 	mutatePatches, mutateEngineResponses, err := h.applyMutations(metricsConfig, request, policies, policyContext)
+	handlers.RecordAuditResponses(ctx, mutateEngineResponses...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -129,9 +143,12 @@ func (v *mutationHandler) applyMutations(
 
 	if !isResourceDeleted(policyContext) {
 		events := webhookutils.GenerateEvents(engineResponses, false)
+		events = append(events, generateAuditEvents(engineResponses)...)
 		v.eventGen.Add(events...)
 	}
 
+	go v.handleAudit(policyContext.NewResource, request, engineResponses...)
+
 	logMutationResponse(patches, engineResponses, v.log)
 
 	// patches holds all the successful patches, if no patch is created, it returns nil
@@ -140,7 +157,7 @@ func (v *mutationHandler) applyMutations(
 
 func (h *mutationHandler) applyMutation(request *admissionv1.AdmissionRequest, policyContext *engine.PolicyContext) (*response.EngineResponse, [][]byte, error) {
 	if request.Kind.Kind != "Namespace" && request.Namespace != "" {
-		policyContext.NamespaceLabels = common.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, h.log)
+		policyContext.NamespaceLabels = webhookutils.GetNamespaceLabels(policyContext, request, h.nsLister, h.log)
 	}
 
 	engineResponse := engine.Mutate(policyContext)
@@ -160,6 +177,79 @@ func (h *mutationHandler) applyMutation(request *admissionv1.AdmissionRequest, p
 	return engineResponse, policyPatches, nil
 }
 
+// generateAuditEvents generates an event for every mutation rule in audit mode, with the patch that was not applied
+func generateAuditEvents(engineResponses []*response.EngineResponse) []event.Info {
+	var events []event.Info
+	for _, er := range engineResponses {
+		for i, ruleResp := range er.PolicyResponse.Rules {
+			if ruleResp.Audit && len(ruleResp.Patches) > 0 {
+				patch := jsonutils.JoinPatches(ruleResp.Patches...)
+				events = append(events, event.NewPolicyAuditMutationEvent(event.AdmissionController, er, &er.PolicyResponse.Rules[i], patch))
+			}
+		}
+	}
+	return events
+}
+
+// handleAudit creates an admission report with the results of the mutation rules in audit mode
+func (h *mutationHandler) handleAudit(
+	resource unstructured.Unstructured,
+	request *admissionv1.AdmissionRequest,
+	engineResponses ...*response.EngineResponse,
+) {
+	if !h.admissionReports {
+		return
+	}
+	if request.DryRun != nil && *request.DryRun {
+		return
+	}
+	// we don't need reports for deletions and when it's about sub resources
+	if request.Operation == admissionv1.Delete || request.SubResource != "" {
+		return
+	}
+	// check if the resource supports reporting
+	if !reportutils.IsGvkSupported(schema.GroupVersionKind(request.Kind)) {
+		return
+	}
+	auditResponses := auditEngineResponses(engineResponses)
+	if len(auditResponses) == 0 {
+		return
+	}
+	report := reportutils.NewAdmissionReport(resource, request, request.Kind, auditResponses...)
+	// the image verification handler creates the admission report of the same request
+	report.SetName(report.GetName() + "-mutate")
+	// if it's not a creation, the resource already exists, we can set the owner
+	if request.Operation != admissionv1.Create {
+		gv := metav1.GroupVersion{Group: request.Kind.Group, Version: request.Kind.Version}
+		controllerutils.SetOwner(report, gv.String(), request.Kind.Kind, resource.GetName(), resource.GetUID())
+	}
+	if len(report.GetResults()) > 0 {
+		_, err := reportutils.CreateReport(context.Background(), report, h.kyvernoClient)
+		if err != nil {
+			h.log.Error(err, "failed to create report")
+		}
+	}
+}
+
+// auditEngineResponses returns copies of the engine responses restricted to the mutation rules in audit mode
+func auditEngineResponses(engineResponses []*response.EngineResponse) []*response.EngineResponse {
+	var auditResponses []*response.EngineResponse
+	for _, er := range engineResponses {
+		var rules []response.RuleResponse
+		for _, ruleResp := range er.PolicyResponse.Rules {
+			if ruleResp.Audit {
+				rules = append(rules, ruleResp)
+			}
+		}
+		if len(rules) > 0 {
+			auditResponse := *er
+			auditResponse.PolicyResponse.Rules = rules
+			auditResponses = append(auditResponses, &auditResponse)
+		}
+	}
+	return auditResponses
+}
+
 func logMutationResponse(patches [][]byte, engineResponses []*response.EngineResponse, logger logr.Logger) {
 	if len(patches) != 0 {
 		logger.V(4).Info("created patches", "count", len(patches))
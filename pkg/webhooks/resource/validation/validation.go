@@ -16,6 +16,7 @@ import (
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,7 +28,7 @@ type ValidationHandler interface {
 	// HandleValidation handles validating webhook admission request
 	// If there are no errors in validating rule we apply generation rules
 	// patchedResource is the (resource + patches) after applying mutation rules
-	HandleValidation(*metrics.MetricsConfig, *admissionv1.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext, map[string]string, time.Time) (bool, string, []string)
+	HandleValidation(context.Context, *metrics.MetricsConfig, *admissionv1.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext, map[string]string, time.Time) (bool, string, []string)
 }
 
 func NewValidationHandler(
@@ -58,6 +59,7 @@ type validationHandler struct {
 }
 
 func (v *validationHandler) HandleValidation(
+	ctx context.Context,
 	metricsConfig *metrics.MetricsConfig,
 	request *admissionv1.AdmissionRequest,
 	policies []kyvernov1.PolicyInterface,
@@ -67,7 +69,8 @@ func (v *validationHandler) HandleValidation(
 ) (bool, string, []string) {
 	if len(policies) == 0 {
 		// invoke handleAudit as we may have some policies in audit mode to consider
-		go v.handleAudit(policyContext.NewResource, request, namespaceLabels)
+		auditResponses := v.recordAuditResponses(ctx, policyContext.NewResource, request, namespaceLabels)
+		go v.handleAudit(policyContext.NewResource, request, namespaceLabels, auditResponses)
 		return true, "", nil
 	}
 
@@ -115,6 +118,8 @@ func (v *validationHandler) HandleValidation(
 		}
 	}
 
+	handlers.RecordAuditResponses(ctx, engineResponses...)
+	auditResponses := v.recordAuditResponses(ctx, policyContext.NewResource, request, namespaceLabels)
 	blocked := webhookutils.BlockRequest(engineResponses, failurePolicy, logger)
 	if deletionTimeStamp == nil {
 		events := webhookutils.GenerateEvents(engineResponses, blocked)
@@ -126,7 +131,7 @@ func (v *validationHandler) HandleValidation(
 		return false, webhookutils.GetBlockedMessages(engineResponses), nil
 	}
 
-	go v.handleAudit(policyContext.NewResource, request, namespaceLabels, engineResponses...)
+	go v.handleAudit(policyContext.NewResource, request, namespaceLabels, auditResponses, engineResponses...)
 
 	warnings := webhookutils.GetWarningMessages(engineResponses)
 	return true, "", warnings
@@ -147,10 +152,30 @@ func (v *validationHandler) buildAuditResponses(resource unstructured.Unstructur
 	return responses, nil
 }
 
+// recordAuditResponses evaluates the policies in audit mode before the response is returned when admission
+// decisions are audited and records their results, the responses are reused to build the admission report
+func (v *validationHandler) recordAuditResponses(ctx context.Context, resource unstructured.Unstructured, request *admissionv1.AdmissionRequest, namespaceLabels map[string]string) []*response.EngineResponse {
+	if !handlers.AuditEnabled(ctx) {
+		return nil
+	}
+	responses, err := v.buildAuditResponses(resource, request, namespaceLabels)
+	if err != nil {
+		v.log.Error(err, "failed to build audit responses")
+		return nil
+	}
+	for _, engineResponse := range responses {
+		if !engineResponse.IsEmpty() {
+			handlers.RecordAuditResponses(ctx, engineResponse)
+		}
+	}
+	return responses
+}
+
 func (v *validationHandler) handleAudit(
 	resource unstructured.Unstructured,
 	request *admissionv1.AdmissionRequest,
 	namespaceLabels map[string]string,
+	auditResponses []*response.EngineResponse,
 	engineResponses ...*response.EngineResponse,
 ) {
 	if !v.admissionReports {
@@ -167,9 +192,13 @@ func (v *validationHandler) handleAudit(
 	if !reportutils.IsGvkSupported(schema.GroupVersionKind(request.Kind)) {
 		return
 	}
-	responses, err := v.buildAuditResponses(resource, request, namespaceLabels)
-	if err != nil {
-		v.log.Error(err, "failed to build audit responses")
+	responses := auditResponses
+	if responses == nil {
+		var err error
+		responses, err = v.buildAuditResponses(resource, request, namespaceLabels)
+		if err != nil {
+			v.log.Error(err, "failed to build audit responses")
+		}
 	}
 	responses = append(responses, engineResponses...)
 	report := reportutils.NewAdmissionReport(resource, request, request.Kind, responses...)
@@ -179,8 +208,7 @@ func (v *validationHandler) handleAudit(
 		controllerutils.SetOwner(report, gv.String(), request.Kind.Kind, resource.GetName(), resource.GetUID())
 	}
 	if len(report.GetResults()) > 0 {
-		_, err = reportutils.CreateReport(context.Background(), report, v.kyvernoClient)
-		if err != nil {
+		if _, err := reportutils.CreateReport(context.Background(), report, v.kyvernoClient); err != nil {
 			v.log.Error(err, "failed to create report")
 		}
 	}
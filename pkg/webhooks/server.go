@@ -12,6 +12,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/toggle"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
@@ -28,6 +29,14 @@ type DebugModeOptions struct {
 	DumpPayload bool
 }
 
+// AuditOptions holds the options to configure the audit of admission decisions
+type AuditOptions struct {
+	// Sink receives the audit events, admission decisions are not audited when it is nil
+	Sink handlers.AuditSink
+	// IncludeRequest adds the redacted admission request payload to the audit events
+	IncludeRequest bool
+}
+
 type Server interface {
 	// Run TLS server in separate thread and returns control immediately
 	Run(<-chan struct{})
@@ -44,6 +53,11 @@ type PolicyHandlers interface {
 	Validate(context.Context, logr.Logger, *admissionv1.AdmissionRequest, time.Time) *admissionv1.AdmissionResponse
 }
 
+type ExceptionHandlers interface {
+	// Validate performs the validation check on policy exception resources
+	Validate(context.Context, logr.Logger, *admissionv1.AdmissionRequest, time.Time) *admissionv1.AdmissionResponse
+}
+
 type ResourceHandlers interface {
 	// Mutate performs the mutation of kube resources
 	Mutate(context.Context, logr.Logger, *admissionv1.AdmissionRequest, string, time.Time) *admissionv1.AdmissionResponse
@@ -65,10 +79,13 @@ type TlsProvider func() ([]byte, []byte, error)
 // NewServer creates new instance of server accordingly to given configuration
 func NewServer(
 	policyHandlers PolicyHandlers,
+	exceptionHandlers ExceptionHandlers,
 	resourceHandlers ResourceHandlers,
 	configuration config.Configuration,
 	metricsConfig *metrics.MetricsConfig,
 	debugModeOpts DebugModeOptions,
+	limiter *handlers.Limiter,
+	auditOpts AuditOptions,
 	tlsProvider TlsProvider,
 	mwcClient controllerutils.DeleteClient[*admissionregistrationv1.MutatingWebhookConfiguration],
 	vwcClient controllerutils.DeleteClient[*admissionregistrationv1.ValidatingWebhookConfiguration],
@@ -78,9 +95,10 @@ func NewServer(
 	mux := httprouter.New()
 	resourceLogger := logger.WithName("resource")
 	policyLogger := logger.WithName("policy")
+	exceptionLogger := logger.WithName("exception")
 	verifyLogger := logger.WithName("verify")
-	registerWebhookHandlers(resourceLogger.WithName("mutate"), mux, config.MutatingWebhookServicePath, configuration, metricsConfig, resourceHandlers.Mutate, debugModeOpts)
-	registerWebhookHandlers(resourceLogger.WithName("validate"), mux, config.ValidatingWebhookServicePath, configuration, metricsConfig, resourceHandlers.Validate, debugModeOpts)
+	registerWebhookHandlers(resourceLogger.WithName("mutate"), mux, config.MutatingWebhookServicePath, configuration, metricsConfig, resourceHandlers.Mutate, debugModeOpts, limiter, auditOpts)
+	registerWebhookHandlers(resourceLogger.WithName("validate"), mux, config.ValidatingWebhookServicePath, configuration, metricsConfig, resourceHandlers.Validate, debugModeOpts, limiter, auditOpts)
 	mux.HandlerFunc(
 		"POST",
 		config.PolicyMutatingWebhookServicePath,
@@ -89,6 +107,7 @@ func NewServer(
 				WithFilter(configuration).
 				WithDump(debugModeOpts.DumpPayload).
 				WithMetrics(metricsConfig).
+				WithAudit(auditOpts.Sink, auditOpts.IncludeRequest).
 				WithAdmission(policyLogger.WithName("mutate")).
 				WithTrace(),
 		),
@@ -101,10 +120,24 @@ func NewServer(
 				WithFilter(configuration).
 				WithDump(debugModeOpts.DumpPayload).
 				WithMetrics(metricsConfig).
+				WithAudit(auditOpts.Sink, auditOpts.IncludeRequest).
 				WithAdmission(policyLogger.WithName("validate")).
 				WithTrace(),
 		),
 	)
+	mux.HandlerFunc(
+		"POST",
+		config.ExceptionValidatingWebhookServicePath,
+		http.HandlerFunc(
+			handlers.AdmissionHandler(exceptionHandlers.Validate).
+				WithFilter(configuration).
+				WithDump(debugModeOpts.DumpPayload).
+				WithMetrics(metricsConfig).
+				WithAudit(auditOpts.Sink, auditOpts.IncludeRequest).
+				WithAdmission(exceptionLogger.WithName("validate")).
+				WithTrace(),
+		),
+	)
 	mux.HandlerFunc(
 		"POST",
 		config.VerifyMutatingWebhookServicePath,
@@ -195,6 +228,7 @@ func (s *server) cleanup(ctx context.Context) {
 		deleteLease("kyverno-health")
 		deleteVwc(config.ValidatingWebhookConfigurationName)
 		deleteVwc(config.PolicyValidatingWebhookConfigurationName)
+		deleteVwc(config.ExceptionValidatingWebhookConfigurationName)
 		deleteMwc(config.MutatingWebhookConfigurationName)
 		deleteMwc(config.PolicyMutatingWebhookConfigurationName)
 		deleteMwc(config.VerifyMutatingWebhookConfigurationName)
@@ -202,6 +236,8 @@ func (s *server) cleanup(ctx context.Context) {
 	close(s.cleanUp)
 }
 
+// registerWebhookHandlers registers the resource webhook handlers, the last applied handler runs first:
+// requests filtered by the configuration don't go through load shedding and don't consume rate limiter tokens
 func registerWebhookHandlers(
 	logger logr.Logger,
 	mux *httprouter.Router,
@@ -210,6 +246,8 @@ func registerWebhookHandlers(
 	metricsConfig *metrics.MetricsConfig,
 	handlerFunc func(context.Context, logr.Logger, *admissionv1.AdmissionRequest, string, time.Time) *admissionv1.AdmissionResponse,
 	debugModeOpts DebugModeOptions,
+	limiter *handlers.Limiter,
+	auditOpts AuditOptions,
 ) {
 	mux.HandlerFunc(
 		"POST",
@@ -218,10 +256,12 @@ func registerWebhookHandlers(
 			handlers.AdmissionHandler(func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
 				return handlerFunc(ctx, logger, request, "all", startTime)
 			}).
-				WithFilter(configuration).
-				WithProtection(toggle.ProtectManagedResources.Enabled()).
 				WithDump(debugModeOpts.DumpPayload).
 				WithMetrics(metricsConfig).
+				WithLoadShedding(limiter, "all", metricsConfig).
+				WithFilter(configuration).
+				WithProtection(toggle.ProtectManagedResources.Enabled()).
+				WithAudit(auditOpts.Sink, auditOpts.IncludeRequest).
 				WithAdmission(logger).
 				WithTrace(),
 		),
@@ -233,10 +273,12 @@ func registerWebhookHandlers(
 			handlers.AdmissionHandler(func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
 				return handlerFunc(ctx, logger, request, "fail", startTime)
 			}).
-				WithFilter(configuration).
-				WithProtection(toggle.ProtectManagedResources.Enabled()).
 				WithDump(debugModeOpts.DumpPayload).
 				WithMetrics(metricsConfig).
+				WithLoadShedding(limiter, "fail", metricsConfig).
+				WithFilter(configuration).
+				WithProtection(toggle.ProtectManagedResources.Enabled()).
+				WithAudit(auditOpts.Sink, auditOpts.IncludeRequest).
 				WithAdmission(logger).
 				WithTrace(),
 		),
@@ -248,12 +290,36 @@ func registerWebhookHandlers(
 			handlers.AdmissionHandler(func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
 				return handlerFunc(ctx, logger, request, "ignore", startTime)
 			}).
-				WithFilter(configuration).
-				WithProtection(toggle.ProtectManagedResources.Enabled()).
 				WithDump(debugModeOpts.DumpPayload).
 				WithMetrics(metricsConfig).
+				WithLoadShedding(limiter, "ignore", metricsConfig).
+				WithFilter(configuration).
+				WithProtection(toggle.ProtectManagedResources.Enabled()).
+				WithAudit(auditOpts.Sink, auditOpts.IncludeRequest).
 				WithAdmission(logger).
 				WithTrace(),
 		),
 	)
+	// webhooks of the policy groups, when resource webhooks are configured per policy
+	for _, failurePolicy := range []string{"fail", "ignore"} {
+		failurePolicy := failurePolicy
+		mux.HandlerFunc(
+			"POST",
+			basePath+"/group/:group/"+failurePolicy,
+			http.HandlerFunc(
+				handlers.AdmissionHandler(func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
+					group := httprouter.ParamsFromContext(ctx).ByName("group")
+					return handlerFunc(admissionutils.WithPolicyGroup(ctx, group), logger.WithValues("group", group), request, failurePolicy, startTime)
+				}).
+					WithDump(debugModeOpts.DumpPayload).
+					WithMetrics(metricsConfig).
+					WithLoadShedding(limiter, failurePolicy, metricsConfig).
+					WithFilter(configuration).
+					WithProtection(toggle.ProtectManagedResources.Enabled()).
+					WithAudit(auditOpts.Sink, auditOpts.IncludeRequest).
+					WithAdmission(logger).
+					WithTrace(),
+			),
+		)
+	}
 }
@@ -24,7 +24,7 @@ func GenerateEvents(engineResponses []*response.EngineResponse, blocked bool) []
 
 		if !er.IsSuccessful() {
 			for i, ruleResp := range er.PolicyResponse.Rules {
-				if ruleResp.Status == response.RuleStatusFail || ruleResp.Status == response.RuleStatusError {
+				if ruleResp.Status == response.RuleStatusFail || ruleResp.Status == response.RuleStatusError || ruleResp.Status == response.RuleStatusTimeout {
 					e := event.NewPolicyFailEvent(event.AdmissionController, event.PolicyViolation, er, &er.PolicyResponse.Rules[i], blocked)
 					events = append(events, e)
 				}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/common"
+	"github.com/kyverno/kyverno/pkg/engine"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// GetNamespaceLabels returns the labels of the namespace of the admission request, they are resolved
+// once and shared by the webhook calls of the request through the admission cache of the policy context
+func GetNamespaceLabels(policyContext *engine.PolicyContext, request *admissionv1.AdmissionRequest, nsLister corev1listers.NamespaceLister, logger logr.Logger) map[string]string {
+	lookup := func() (interface{}, error) {
+		return common.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, nsLister, logger), nil
+	}
+	if policyContext == nil || policyContext.AdmissionCache == nil {
+		labels, _ := lookup()
+		return labels.(map[string]string)
+	}
+	labels, _ := policyContext.AdmissionCache.GetOrCompute("namespaceLabels", lookup)
+	return labels.(map[string]string)
+}
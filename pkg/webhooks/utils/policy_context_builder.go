@@ -3,9 +3,11 @@ package utils
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/admissioncache"
 	enginectx "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/userinfo"
 	"github.com/kyverno/kyverno/pkg/utils"
@@ -32,11 +34,18 @@ func newVariablesContext(request *admissionv1.AdmissionRequest, userRequestInfo
 	return ctx, nil
 }
 
+// roleRefs are the roles and cluster roles bound to the user of an admission request
+type roleRefs struct {
+	roles        []string
+	clusterRoles []string
+}
+
 type policyContextBuilder struct {
 	configuration config.Configuration
 	client        dclient.Interface
 	rbLister      rbacv1listers.RoleBindingLister
 	crbLister     rbacv1listers.ClusterRoleBindingLister
+	polexLister   kyvernov1alpha1listers.PolicyExceptionLister
 }
 
 func NewPolicyContextBuilder(
@@ -44,12 +53,14 @@ func NewPolicyContextBuilder(
 	client dclient.Interface,
 	rbLister rbacv1listers.RoleBindingLister,
 	crbLister rbacv1listers.ClusterRoleBindingLister,
+	polexLister kyvernov1alpha1listers.PolicyExceptionLister,
 ) PolicyContextBuilder {
 	return &policyContextBuilder{
 		configuration: configuration,
 		client:        client,
 		rbLister:      rbLister,
 		crbLister:     crbLister,
+		polexLister:   polexLister,
 	}
 }
 
@@ -57,11 +68,18 @@ func (b *policyContextBuilder) Build(request *admissionv1.AdmissionRequest, poli
 	userRequestInfo := kyvernov1beta1.RequestInfo{
 		AdmissionUserInfo: *request.UserInfo.DeepCopy(),
 	}
-	if roles, clusterRoles, err := userinfo.GetRoleRef(b.rbLister, b.crbLister, request, b.configuration); err != nil {
+	admissionCache := admissioncache.DefaultCache.ForRequest(admissioncache.RequestKey(request))
+	if refs, err := admissionCache.GetOrCompute("roleRefs", func() (interface{}, error) {
+		roles, clusterRoles, err := userinfo.GetRoleRef(b.rbLister, b.crbLister, request, b.configuration)
+		if err != nil {
+			return nil, err
+		}
+		return roleRefs{roles: roles, clusterRoles: clusterRoles}, nil
+	}); err != nil {
 		return nil, errors.Wrap(err, "failed to fetch RBAC information for request")
 	} else {
-		userRequestInfo.Roles = roles
-		userRequestInfo.ClusterRoles = clusterRoles
+		userRequestInfo.Roles = refs.(roleRefs).roles
+		userRequestInfo.ClusterRoles = refs.(roleRefs).clusterRoles
 	}
 	ctx, err := newVariablesContext(request, &userRequestInfo)
 	if err != nil {
@@ -83,6 +101,9 @@ func (b *policyContextBuilder) Build(request *admissionv1.AdmissionRequest, poli
 		JSONContext:         ctx,
 		Client:              b.client,
 		AdmissionOperation:  true,
+		ExceptionLister:     b.polexLister,
+		RequestCache:        admissioncache.NewRequestCache(),
+		AdmissionCache:      admissionCache,
 	}
 	return policyContext, nil
 }